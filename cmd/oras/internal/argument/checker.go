@@ -30,3 +30,11 @@ func AtLeast(cnt int) func(args []string) (bool, string) {
 		return len(args) >= cnt, fmt.Sprintf("at least %d argument", cnt)
 	}
 }
+
+// Between checks if the number of arguments is between minCnt and maxCnt,
+// inclusive.
+func Between(minCnt int, maxCnt int) func(args []string) (bool, string) {
+	return func(args []string) (bool, string) {
+		return len(args) >= minCnt && len(args) <= maxCnt, fmt.Sprintf("%d to %d arguments", minCnt, maxCnt)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/content"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/descriptor"
+	"oras.land/oras/cmd/oras/internal/display/metadata/diagram"
 	"oras.land/oras/cmd/oras/internal/display/metadata/json"
 	"oras.land/oras/cmd/oras/internal/display/metadata/table"
 	"oras.land/oras/cmd/oras/internal/display/metadata/template"
@@ -112,7 +113,9 @@ func NewPullHandler(printer *output.Printer, format option.Format, path string,
 }
 
 // NewDiscoverHandler returns status and metadata handlers for discover command.
-func NewDiscoverHandler(out io.Writer, format option.Format, path string, rawReference string, desc ocispec.Descriptor, verbose bool, tty *os.File) (metadata.DiscoverHandler, error) {
+// The values of labelAnnotations are added to the node labels of the diagram
+// formats.
+func NewDiscoverHandler(out io.Writer, format option.Format, path string, rawReference string, desc ocispec.Descriptor, verbose bool, tty *os.File, labelAnnotations []string) (metadata.DiscoverHandler, error) {
 	var handler metadata.DiscoverHandler
 	switch format.Type {
 	case option.FormatTypeTree.Name:
@@ -123,6 +126,27 @@ func NewDiscoverHandler(out io.Writer, format option.Format, path string, rawRef
 		handler = json.NewDiscoverHandler(out, desc, path)
 	case option.FormatTypeGoTemplate.Name:
 		handler = template.NewDiscoverHandler(out, desc, path, format.Template)
+	case option.FormatTypeJSONLines.Name:
+		handler = json.NewDiscoverLinesHandler(out, path)
+	case option.FormatTypeText.Name:
+		handler = text.NewDiscoverHandler(out, path)
+	case option.FormatTypeDOT.Name, option.FormatTypeMermaid.Name, option.FormatTypePlantUML.Name:
+		return diagram.NewDiscoverHandler(out, format.Type, path, desc, labelAnnotations)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewDiscoverSubjectHandler returns a metadata handler for discover command
+// walking up the subject chain.
+func NewDiscoverSubjectHandler(out io.Writer, format option.Format, path string, desc ocispec.Descriptor, verbose bool, tty *os.File) (metadata.DiscoverSubjectHandler, error) {
+	var handler metadata.DiscoverSubjectHandler
+	switch format.Type {
+	case option.FormatTypeTree.Name:
+		handler = tree.NewDiscoverSubjectHandler(out, path, desc, verbose, tty)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewDiscoverSubjectHandler(out, desc, path)
 	default:
 		return nil, errors.UnsupportedFormatTypeError(format.Type)
 	}
@@ -164,6 +188,21 @@ func NewManifestFetchHandler(out io.Writer, format option.Format, outputDescript
 	return metadataHandler, contentHandler, nil
 }
 
+// NewManifestExportHandler returns a metadata handler for recursive manifest
+// fetch.
+func NewManifestExportHandler(out io.Writer, format option.Format, outputDir string) (metadata.ManifestExportHandler, error) {
+	var handler metadata.ManifestExportHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = tree.NewManifestExportHandler(out, outputDir)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewManifestExportHandler(out, outputDir)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
 // NewTagHandler returns a tag handler.
 func NewTagHandler(printer *output.Printer, target option.Target) metadata.TagHandler {
 	return text.NewTagHandler(printer, target)
@@ -246,6 +285,81 @@ func NewRestoreHandler(printer *output.Printer, tty *os.File, fetcher fetcher.Fe
 	return status.NewTextRestoreHandler(printer, fetcher), text.NewRestoreHandler(printer, dryRun)
 }
 
+// NewBackupVerifyHandler returns a backup verify handler.
+func NewBackupVerifyHandler(printer *output.Printer, format option.Format) (metadata.BackupVerifyHandler, error) {
+	var handler metadata.BackupVerifyHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewBackupVerifyHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewBackupVerifyHandler(printer)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewManifestDiffHandler returns a metadata handler for manifest diff command.
+func NewManifestDiffHandler(printer *output.Printer, format option.Format) (metadata.ManifestDiffHandler, error) {
+	var handler metadata.ManifestDiffHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewManifestDiffHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewManifestDiffHandler(printer)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewManifestDiffHandler(printer, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewManifestAnnotateHandler returns a metadata handler for manifest annotate
+// command.
+func NewManifestAnnotateHandler(printer *output.Printer, format option.Format, target *option.Target) (metadata.ManifestAnnotateHandler, error) {
+	var handler metadata.ManifestAnnotateHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewManifestAnnotateHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewManifestAnnotateHandler(printer, target.Path)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewManifestConvertHandler returns a metadata handler for manifest convert
+// command.
+func NewManifestConvertHandler(printer *output.Printer, format option.Format, target *option.Target) (metadata.ManifestConvertHandler, error) {
+	var handler metadata.ManifestConvertHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewManifestConvertHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewManifestConvertHandler(printer, target.Path)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewManifestValidateHandler returns a metadata handler for manifest validate
+// command.
+func NewManifestValidateHandler(printer *output.Printer, format option.Format) (metadata.ManifestValidateHandler, error) {
+	var handler metadata.ManifestValidateHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewManifestValidateHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewManifestValidateHandler(printer)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
 // NewBlobPushHandler returns blob push handlers.
 func NewBlobPushHandler(printer *output.Printer, outputDescriptor bool, _ bool, desc ocispec.Descriptor, tty *os.File) (status.BlobPushHandler, metadata.BlobPushHandler) {
 	if outputDescriptor {
@@ -298,3 +412,20 @@ func NewRepoListHandler(out io.Writer, format option.Format, registry, namespace
 	}
 	return handler, nil
 }
+
+// NewLayerListHandler returns a layer list handler for blob ls and manifest
+// ls-files commands.
+func NewLayerListHandler(printer *output.Printer, format option.Format, name string, showLayers bool) (metadata.LayerListHandler, error) {
+	var handler metadata.LayerListHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewLayerListHandler(printer, showLayers)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewLayerListHandler(printer, name)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewLayerListHandler(printer, format.Template, name)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
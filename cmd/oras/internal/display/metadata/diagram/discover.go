@@ -0,0 +1,285 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diagram renders discovered artifact graphs as diagrams in the DOT,
+// Mermaid and PlantUML languages.
+package diagram
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/internal/descriptor"
+)
+
+// Supported diagram languages.
+const (
+	LanguageDOT      = "dot"
+	LanguageMermaid  = "mermaid"
+	LanguagePlantUML = "plantuml"
+)
+
+// edge kinds.
+const (
+	edgeSubject = iota
+	edgeContent
+)
+
+// edge is a directed edge of the graph.
+type edge struct {
+	from digest.Digest
+	to   digest.Digest
+	kind int
+}
+
+// discoverHandler handles diagram metadata output for discover events.
+type discoverHandler struct {
+	out         io.Writer
+	language    string
+	path        string
+	root        digest.Digest
+	annotations []string
+	nodes       map[digest.Digest]ocispec.Descriptor
+	edges       map[edge]struct{}
+}
+
+// NewDiscoverHandler creates a new handler for discover events rendering a
+// diagram in the given language. The values of the given annotation keys are
+// added to the node labels.
+// The returned handler also implements metadata.DiscoverChildHandler and
+// metadata.DiscoverContentHandler.
+func NewDiscoverHandler(out io.Writer, language string, path string, root ocispec.Descriptor, annotations []string) (metadata.DiscoverHandler, error) {
+	switch language {
+	case LanguageDOT, LanguageMermaid, LanguagePlantUML:
+	default:
+		return nil, fmt.Errorf("unsupported diagram language: %q", language)
+	}
+	return &discoverHandler{
+		out:         out,
+		language:    language,
+		path:        path,
+		root:        root.Digest,
+		annotations: annotations,
+		nodes: map[digest.Digest]ocispec.Descriptor{
+			root.Digest: root,
+		},
+		edges: make(map[edge]struct{}),
+	}, nil
+}
+
+// OnDiscovered implements metadata.DiscoverHandler.
+func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) error {
+	if _, ok := h.nodes[subject.Digest]; !ok {
+		return fmt.Errorf("unexpected subject descriptor: %v", subject)
+	}
+	h.addNode(referrer)
+	h.edges[edge{from: referrer.Digest, to: subject.Digest, kind: edgeSubject}] = struct{}{}
+	return nil
+}
+
+// OnChildDiscovered implements metadata.DiscoverChildHandler.
+func (h *discoverHandler) OnChildDiscovered(child, index ocispec.Descriptor) error {
+	return h.OnContentDiscovered(child, index)
+}
+
+// OnContentDiscovered implements metadata.DiscoverContentHandler.
+func (h *discoverHandler) OnContentDiscovered(node, parent ocispec.Descriptor) error {
+	if _, ok := h.nodes[parent.Digest]; !ok {
+		return fmt.Errorf("unexpected parent descriptor: %v", parent)
+	}
+	h.addNode(node)
+	h.edges[edge{from: parent.Digest, to: node.Digest, kind: edgeContent}] = struct{}{}
+	return nil
+}
+
+// addNode adds a node to the graph if it is not yet added.
+func (h *discoverHandler) addNode(desc ocispec.Descriptor) {
+	if _, ok := h.nodes[desc.Digest]; !ok {
+		h.nodes[desc.Digest] = desc
+	}
+}
+
+// Render implements metadata.DiscoverHandler.
+func (h *discoverHandler) Render() error {
+	var w writer
+	switch h.language {
+	case LanguageDOT:
+		w = &dotWriter{}
+	case LanguageMermaid:
+		w = &mermaidWriter{}
+	case LanguagePlantUML:
+		w = &plantUMLWriter{}
+	}
+
+	var sb strings.Builder
+	w.begin(&sb)
+	for _, dgst := range h.sortedNodes() {
+		w.node(&sb, nodeID(dgst), h.label(h.nodes[dgst]))
+	}
+	for _, e := range h.sortedEdges() {
+		w.edge(&sb, nodeID(e.from), nodeID(e.to), e.kind)
+	}
+	w.end(&sb)
+	_, err := io.WriteString(h.out, sb.String())
+	return err
+}
+
+// sortedNodes returns the digests of the nodes with the root first and the
+// rest ordered by digest, so that the output is deterministic.
+func (h *discoverHandler) sortedNodes() []digest.Digest {
+	nodes := make([]digest.Digest, 0, len(h.nodes))
+	for dgst := range h.nodes {
+		if dgst != h.root {
+			nodes = append(nodes, dgst)
+		}
+	}
+	slices.Sort(nodes)
+	return append([]digest.Digest{h.root}, nodes...)
+}
+
+// sortedEdges returns the edges ordered by kind, source and destination.
+func (h *discoverHandler) sortedEdges() []edge {
+	edges := make([]edge, 0, len(h.edges))
+	for e := range h.edges {
+		edges = append(edges, e)
+	}
+	slices.SortFunc(edges, func(a, b edge) int {
+		return cmp.Or(
+			cmp.Compare(a.kind, b.kind),
+			cmp.Compare(a.from, b.from),
+			cmp.Compare(a.to, b.to),
+		)
+	})
+	return edges
+}
+
+// label returns the lines of the label of a node, consisting of the artifact
+// type, the short digest and the selected annotations.
+func (h *discoverHandler) label(desc ocispec.Descriptor) []string {
+	title := desc.ArtifactType
+	if title == "" {
+		title = desc.MediaType
+	}
+	if desc.Digest == h.root {
+		title = h.path
+	}
+	lines := []string{title, descriptor.ShortDigest(desc)}
+	for _, key := range h.annotations {
+		if value, ok := desc.Annotations[key]; ok {
+			lines = append(lines, fmt.Sprintf("%s: %s", key, value))
+		}
+	}
+	return lines
+}
+
+// nodeID returns the identifier of the node of a digest, which is valid in
+// all the supported languages.
+func nodeID(dgst digest.Digest) string {
+	return "n_" + dgst.Encoded()
+}
+
+// writer writes a graph in a diagram language.
+type writer interface {
+	begin(sb *strings.Builder)
+	node(sb *strings.Builder, id string, label []string)
+	edge(sb *strings.Builder, from, to string, kind int)
+	end(sb *strings.Builder)
+}
+
+// dotWriter writes a graph in the Graphviz DOT language.
+type dotWriter struct{}
+
+func (dotWriter) begin(sb *strings.Builder) {
+	sb.WriteString("digraph discover {\n")
+	sb.WriteString("  node [shape=box];\n")
+}
+
+func (dotWriter) node(sb *strings.Builder, id string, label []string) {
+	escaped := make([]string, len(label))
+	for i, line := range label {
+		escaped[i] = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(line)
+	}
+	fmt.Fprintf(sb, "  %s [label=\"%s\"];\n", id, strings.Join(escaped, `\n`))
+}
+
+func (dotWriter) edge(sb *strings.Builder, from, to string, kind int) {
+	if kind == edgeContent {
+		fmt.Fprintf(sb, "  %s -> %s [style=dashed];\n", from, to)
+		return
+	}
+	fmt.Fprintf(sb, "  %s -> %s [label=\"subject\"];\n", from, to)
+}
+
+func (dotWriter) end(sb *strings.Builder) {
+	sb.WriteString("}\n")
+}
+
+// mermaidWriter writes a graph as a Mermaid flowchart.
+type mermaidWriter struct{}
+
+func (mermaidWriter) begin(sb *strings.Builder) {
+	sb.WriteString("flowchart TD\n")
+}
+
+func (mermaidWriter) node(sb *strings.Builder, id string, label []string) {
+	escaped := make([]string, len(label))
+	for i, line := range label {
+		escaped[i] = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(line)
+	}
+	fmt.Fprintf(sb, "  %s[\"%s\"]\n", id, strings.Join(escaped, "<br/>"))
+}
+
+func (mermaidWriter) edge(sb *strings.Builder, from, to string, kind int) {
+	if kind == edgeContent {
+		fmt.Fprintf(sb, "  %s -.-> %s\n", from, to)
+		return
+	}
+	fmt.Fprintf(sb, "  %s -->|subject| %s\n", from, to)
+}
+
+func (mermaidWriter) end(sb *strings.Builder) {}
+
+// plantUMLWriter writes a graph as a PlantUML diagram.
+type plantUMLWriter struct{}
+
+func (plantUMLWriter) begin(sb *strings.Builder) {
+	sb.WriteString("@startuml\n")
+}
+
+func (plantUMLWriter) node(sb *strings.Builder, id string, label []string) {
+	escaped := make([]string, len(label))
+	for i, line := range label {
+		escaped[i] = strings.NewReplacer(`\`, `\\`, `"`, "'").Replace(line)
+	}
+	fmt.Fprintf(sb, "rectangle \"%s\" as %s\n", strings.Join(escaped, `\n`), id)
+}
+
+func (plantUMLWriter) edge(sb *strings.Builder, from, to string, kind int) {
+	if kind == edgeContent {
+		fmt.Fprintf(sb, "%s ..> %s\n", from, to)
+		return
+	}
+	fmt.Fprintf(sb, "%s --> %s : subject\n", from, to)
+}
+
+func (plantUMLWriter) end(sb *strings.Builder) {
+	sb.WriteString("@enduml\n")
+}
@@ -0,0 +1,140 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagram
+
+import (
+	"bytes"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+)
+
+func TestDiscoverHandler_Render(t *testing.T) {
+	root := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		Size:      100,
+	}
+	manifest := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+		Size:      200,
+	}
+	sbom := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       "sha256:4444444444444444444444444444444444444444444444444444444444444444",
+		Size:         300,
+		ArtifactType: "test/sbom",
+		Annotations: map[string]string{
+			"org.example.version": `"v1"`,
+			"org.example.ignored": "ignored",
+		},
+	}
+	signature := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       "sha256:3333333333333333333333333333333333333333333333333333333333333333",
+		Size:         400,
+		ArtifactType: "test/signature",
+	}
+
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{
+			language: LanguageDOT,
+			want: `digraph discover {
+  node [shape=box];
+  n_1111111111111111111111111111111111111111111111111111111111111111 [label="localhost:5000/test\n111111111111"];
+  n_2222222222222222222222222222222222222222222222222222222222222222 [label="application/vnd.oci.image.manifest.v1+json\n222222222222"];
+  n_3333333333333333333333333333333333333333333333333333333333333333 [label="test/signature\n333333333333"];
+  n_4444444444444444444444444444444444444444444444444444444444444444 [label="test/sbom\n444444444444\norg.example.version: \"v1\""];
+  n_3333333333333333333333333333333333333333333333333333333333333333 -> n_4444444444444444444444444444444444444444444444444444444444444444 [label="subject"];
+  n_4444444444444444444444444444444444444444444444444444444444444444 -> n_1111111111111111111111111111111111111111111111111111111111111111 [label="subject"];
+  n_1111111111111111111111111111111111111111111111111111111111111111 -> n_2222222222222222222222222222222222222222222222222222222222222222 [style=dashed];
+}
+`,
+		},
+		{
+			language: LanguageMermaid,
+			want: `flowchart TD
+  n_1111111111111111111111111111111111111111111111111111111111111111["localhost:5000/test<br/>111111111111"]
+  n_2222222222222222222222222222222222222222222222222222222222222222["application/vnd.oci.image.manifest.v1+json<br/>222222222222"]
+  n_3333333333333333333333333333333333333333333333333333333333333333["test/signature<br/>333333333333"]
+  n_4444444444444444444444444444444444444444444444444444444444444444["test/sbom<br/>444444444444<br/>org.example.version: #quot;v1#quot;"]
+  n_3333333333333333333333333333333333333333333333333333333333333333 -->|subject| n_4444444444444444444444444444444444444444444444444444444444444444
+  n_4444444444444444444444444444444444444444444444444444444444444444 -->|subject| n_1111111111111111111111111111111111111111111111111111111111111111
+  n_1111111111111111111111111111111111111111111111111111111111111111 -.-> n_2222222222222222222222222222222222222222222222222222222222222222
+`,
+		},
+		{
+			language: LanguagePlantUML,
+			want: `@startuml
+rectangle "localhost:5000/test\n111111111111" as n_1111111111111111111111111111111111111111111111111111111111111111
+rectangle "application/vnd.oci.image.manifest.v1+json\n222222222222" as n_2222222222222222222222222222222222222222222222222222222222222222
+rectangle "test/signature\n333333333333" as n_3333333333333333333333333333333333333333333333333333333333333333
+rectangle "test/sbom\n444444444444\norg.example.version: 'v1'" as n_4444444444444444444444444444444444444444444444444444444444444444
+n_3333333333333333333333333333333333333333333333333333333333333333 --> n_4444444444444444444444444444444444444444444444444444444444444444 : subject
+n_4444444444444444444444444444444444444444444444444444444444444444 --> n_1111111111111111111111111111111111111111111111111111111111111111 : subject
+n_1111111111111111111111111111111111111111111111111111111111111111 ..> n_2222222222222222222222222222222222222222222222222222222222222222
+@enduml
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			var buf bytes.Buffer
+			h, err := NewDiscoverHandler(&buf, tt.language, "localhost:5000/test", root, []string{"org.example.version", "org.example.missing"})
+			if err != nil {
+				t.Fatalf("NewDiscoverHandler() error = %v", err)
+			}
+			if err := h.OnDiscovered(sbom, root); err != nil {
+				t.Fatalf("OnDiscovered() error = %v", err)
+			}
+			if err := h.OnDiscovered(signature, sbom); err != nil {
+				t.Fatalf("OnDiscovered() error = %v", err)
+			}
+			if err := h.(metadata.DiscoverContentHandler).OnContentDiscovered(manifest, root); err != nil {
+				t.Fatalf("OnContentDiscovered() error = %v", err)
+			}
+			if err := h.Render(); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverHandler_errors(t *testing.T) {
+	root := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"}
+	unknown := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:2222222222222222222222222222222222222222222222222222222222222222"}
+	if _, err := NewDiscoverHandler(&bytes.Buffer{}, "svg", "test", root, nil); err == nil {
+		t.Error("NewDiscoverHandler() expected error for unsupported language")
+	}
+	h, err := NewDiscoverHandler(&bytes.Buffer{}, LanguageDOT, "test", root, nil)
+	if err != nil {
+		t.Fatalf("NewDiscoverHandler() error = %v", err)
+	}
+	if err := h.OnDiscovered(root, unknown); err == nil {
+		t.Error("OnDiscovered() expected error for unknown subject")
+	}
+	if err := h.(metadata.DiscoverContentHandler).OnContentDiscovered(root, unknown); err == nil {
+		t.Error("OnContentDiscovered() expected error for unknown parent")
+	}
+}
@@ -16,10 +16,14 @@ limitations under the License.
 package metadata
 
 import (
+	"archive/tar"
 	"time"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/backup"
+	"oras.land/oras/internal/diff"
+	"oras.land/oras/internal/validate"
 )
 
 // Renderer renders metadata information when an operation is complete.
@@ -50,6 +54,35 @@ type DiscoverHandler interface {
 	OnDiscovered(referrer, subject ocispec.Descriptor) error
 }
 
+// DiscoverChildHandler handles metadata output for the child manifests of a
+// discovered index.
+type DiscoverChildHandler interface {
+	// OnChildDiscovered is called after a child manifest of the index is
+	// discovered, before its referrers are discovered.
+	OnChildDiscovered(child, index ocispec.Descriptor) error
+}
+
+// DiscoverContentHandler handles metadata output for the content of the
+// discovered manifests.
+type DiscoverContentHandler interface {
+	// OnContentDiscovered is called after a manifest referenced by an index, or
+	// a layer referenced by a manifest, is discovered.
+	OnContentDiscovered(node, parent ocispec.Descriptor) error
+}
+
+// DiscoverSubjectHandler handles metadata output for discover events walking
+// up the subject chain.
+type DiscoverSubjectHandler interface {
+	Renderer
+
+	// OnSubjectDiscovered is called after the subject of a referrer is
+	// discovered.
+	OnSubjectDiscovered(subject, referrer ocispec.Descriptor) error
+	// OnIndexDiscovered is called after a tagged index containing the manifest
+	// is discovered.
+	OnIndexDiscovered(index ocispec.Descriptor, tags []string, manifest ocispec.Descriptor) error
+}
+
 // ManifestFetchHandler handles metadata output for manifest fetch events.
 type ManifestFetchHandler interface {
 	// OnFetched is called after the manifest content is fetched.
@@ -100,9 +133,23 @@ type ManifestIndexCreateHandler interface {
 // ManifestIndexUpdateHandler handles metadata output for index update events.
 type ManifestIndexUpdateHandler ManifestIndexCreateHandler
 
+// ConflictHandler handles metadata output for destination tags already
+// pointing to different artifacts.
+type ConflictHandler interface {
+	// OnTagConflict is called when a destination tag points to a different
+	// artifact.
+	OnTagConflict(tag string, existing, incoming ocispec.Descriptor) error
+	// OnConflictSkipped is called when an artifact is skipped due to a
+	// conflict.
+	OnConflictSkipped(tag string) error
+	// OnTagOverwritten is called when a conflicting tag is overwritten.
+	OnTagOverwritten(tag string, previous, current ocispec.Descriptor) error
+}
+
 // CopyHandler handles metadata output for cp events.
 type CopyHandler interface {
 	TaggedHandler
+	ConflictHandler
 	Renderer
 
 	OnCopied(target *option.BinaryTarget, desc ocispec.Descriptor) error
@@ -114,13 +161,17 @@ type BackupHandler interface {
 
 	OnTagsFound(tags []string) error
 	OnArtifactPulled(tag string, referrerCount int) error
+	OnArtifactUnchanged(tag string) error
+	OnTagPruned(tag string) error
 	OnTarExporting(path string) error
+	OnVolumeExported(path string, size int64) error
 	OnTarExported(path string, size int64) error
 	OnBackupCompleted(tagsCount int, path string, duration time.Duration) error
 }
 
 // RestoreHandler handles metadata output for restore events.
 type RestoreHandler interface {
+	ConflictHandler
 	Renderer
 
 	OnTarLoaded(path string, size int64) error
@@ -129,6 +180,83 @@ type RestoreHandler interface {
 	OnRestoreCompleted(tagsCount int, repo string, duration time.Duration) error
 }
 
+// BackupVerifyHandler handles metadata output for backup verify events.
+type BackupVerifyHandler interface {
+	Renderer
+
+	OnVerified(path string, result *backup.VerifyResult) error
+}
+
+// ManifestDiffHandler handles metadata output for manifest diff events.
+type ManifestDiffHandler interface {
+	Renderer
+
+	// OnCompared is called after the manifest from in fromPath is compared
+	// with the manifest to in toPath.
+	OnCompared(fromPath string, from ocispec.Descriptor, toPath string, to ocispec.Descriptor, result *diff.Result) error
+}
+
+// Relations of exported content to their parents.
+const (
+	RelationManifest = "manifest"
+	RelationConfig   = "config"
+	RelationReferrer = "referrer"
+)
+
+// ManifestExportHandler handles metadata output for recursive manifest fetch
+// events.
+type ManifestExportHandler interface {
+	Renderer
+
+	// OnRootExported is called after the content of the root manifest in path
+	// is written to file.
+	OnRootExported(path string, root ocispec.Descriptor, file string) error
+	// OnExported is called after the content of node, which is a manifest,
+	// the config or a referrer of parent as indicated by relation, is
+	// written to file.
+	OnExported(node ocispec.Descriptor, parent ocispec.Descriptor, relation string, file string) error
+}
+
+// ManifestAnnotateHandler handles metadata output for manifest annotate
+// events.
+type ManifestAnnotateHandler interface {
+	TaggedHandler
+	Renderer
+
+	// OnAnnotated is called after the manifest from is edited and pushed as
+	// to.
+	OnAnnotated(from ocispec.Descriptor, to ocispec.Descriptor) error
+	// OnReferrerMigrated is called after the referrer from is re-pushed as to
+	// with an updated subject.
+	OnReferrerMigrated(from ocispec.Descriptor, to ocispec.Descriptor) error
+	// OnAnnotationSkipped is called if the annotations of the manifest desc
+	// are unchanged.
+	OnAnnotationSkipped(desc ocispec.Descriptor) error
+}
+
+// ManifestConvertHandler handles metadata output for manifest convert events.
+type ManifestConvertHandler interface {
+	TaggedHandler
+	Renderer
+
+	// OnConverted is called after the manifest from is converted to the
+	// artifact layout of imageSpec and pushed as to.
+	OnConverted(from ocispec.Descriptor, to ocispec.Descriptor, imageSpec string) error
+	// OnConversionSkipped is called if the manifest desc is already in the
+	// artifact layout of imageSpec.
+	OnConversionSkipped(desc ocispec.Descriptor, imageSpec string) error
+}
+
+// ManifestValidateHandler handles metadata output for manifest validate
+// events.
+type ManifestValidateHandler interface {
+	Renderer
+
+	// OnValidated is called after the manifest desc read from file, or from
+	// path if file is empty, is validated against the target in path.
+	OnValidated(path string, desc ocispec.Descriptor, file string, issues []validate.Issue) error
+}
+
 // BlobPushHandler handles metadata output for blob push events.
 type BlobPushHandler interface {
 	Renderer
@@ -168,3 +296,17 @@ type RepoListHandler interface {
 	// OnRepositoryListed is called for each repository that is listed.
 	OnRepositoryListed(repo string) error
 }
+
+// LayerListHandler handles metadata output for blob ls and manifest ls-files
+// commands.
+type LayerListHandler interface {
+	Renderer
+
+	// OnLayerListing is called before the entries of a layer are listed.
+	OnLayerListing(layer ocispec.Descriptor) error
+	// OnEntryListed is called for each entry of the layer that is listed.
+	OnEntryListed(layer ocispec.Descriptor, entry *tar.Header) error
+	// OnLayerSkipped is called when a layer is skipped as it is not a tar
+	// archive.
+	OnLayerSkipped(layer ocispec.Descriptor) error
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package json
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/backup"
+)
+
+// backupVerifyHandler handles JSON metadata output for backup verify command.
+type backupVerifyHandler struct {
+	out   io.Writer
+	model *model.BackupVerification
+}
+
+// NewBackupVerifyHandler creates a new handler for backup verify events.
+func NewBackupVerifyHandler(out io.Writer) metadata.BackupVerifyHandler {
+	return &backupVerifyHandler{
+		out: out,
+	}
+}
+
+// OnVerified implements metadata.BackupVerifyHandler.
+func (h *backupVerifyHandler) OnVerified(path string, result *backup.VerifyResult) error {
+	h.model = model.NewBackupVerification(path, result)
+	return nil
+}
+
+// Render implements metadata.BackupVerifyHandler.
+func (h *backupVerifyHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
@@ -45,7 +45,69 @@ func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) err
 	return h.model.AddReferrer(referrer, subject)
 }
 
+// OnChildDiscovered implements metadata.DiscoverChildHandler.
+func (h *discoverHandler) OnChildDiscovered(child, index ocispec.Descriptor) error {
+	return h.model.AddChild(child, index)
+}
+
 // Render implements metadata.DiscoverHandler.
 func (h *discoverHandler) Render() error {
 	return output.PrintPrettyJSON(h.out, h.model.Root)
 }
+
+// discoverLinesHandler handles JSON Lines metadata output for discover events.
+// Each referrer is printed as soon as it is discovered.
+type discoverLinesHandler struct {
+	out  io.Writer
+	path string
+}
+
+// NewDiscoverLinesHandler creates a new handler for discover events printing a
+// line of JSON for each referrer.
+func NewDiscoverLinesHandler(out io.Writer, path string) metadata.DiscoverHandler {
+	return &discoverLinesHandler{
+		out:  out,
+		path: path,
+	}
+}
+
+// OnDiscovered implements metadata.DiscoverHandler.
+func (h *discoverLinesHandler) OnDiscovered(referrer, subject ocispec.Descriptor) error {
+	return output.PrintJSONLine(h.out, model.NewReferrer(h.path, referrer, subject))
+}
+
+// Render implements metadata.DiscoverHandler.
+func (h *discoverLinesHandler) Render() error {
+	return nil
+}
+
+// discoverSubjectHandler handles json metadata output for discover events
+// walking up the subject chain.
+type discoverSubjectHandler struct {
+	out   io.Writer
+	model model.DiscoverSubjects
+}
+
+// NewDiscoverSubjectHandler creates a new handler for discover events walking
+// up the subject chain.
+func NewDiscoverSubjectHandler(out io.Writer, referrer ocispec.Descriptor, path string) metadata.DiscoverSubjectHandler {
+	return &discoverSubjectHandler{
+		out:   out,
+		model: model.NewDiscoverSubjects(path, referrer),
+	}
+}
+
+// OnSubjectDiscovered implements metadata.DiscoverSubjectHandler.
+func (h *discoverSubjectHandler) OnSubjectDiscovered(subject, referrer ocispec.Descriptor) error {
+	return h.model.AddSubject(subject, referrer)
+}
+
+// OnIndexDiscovered implements metadata.DiscoverSubjectHandler.
+func (h *discoverSubjectHandler) OnIndexDiscovered(index ocispec.Descriptor, tags []string, manifest ocispec.Descriptor) error {
+	return h.model.AddIndex(index, tags, manifest)
+}
+
+// Render implements metadata.DiscoverSubjectHandler.
+func (h *discoverSubjectHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model.Root)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestDiscoverLinesHandler_OnDiscovered(t *testing.T) {
+	subject := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2",
+		Size:      529,
+	}
+	referrer := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       testDigest,
+		Size:         660,
+		ArtifactType: "test/sbom",
+	}
+
+	buf := &bytes.Buffer{}
+	h := NewDiscoverLinesHandler(buf, "localhost:5000/test")
+	for range 2 {
+		if err := h.OnDiscovered(referrer, subject); err != nil {
+			t.Fatalf("OnDiscovered() error = %v", err)
+		}
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("printed %d lines, want 2: %q", len(lines), buf.String())
+	}
+	var got struct {
+		Reference    string `json:"reference"`
+		Digest       string `json:"digest"`
+		ArtifactType string `json:"artifactType"`
+		Subject      struct {
+			Reference string `json:"reference"`
+		} `json:"subject"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("failed to unmarshal line %q: %v", lines[0], err)
+	}
+	if want := "localhost:5000/test@" + testDigest; got.Reference != want {
+		t.Errorf("reference = %q, want %q", got.Reference, want)
+	}
+	if got.ArtifactType != referrer.ArtifactType {
+		t.Errorf("artifactType = %q, want %q", got.ArtifactType, referrer.ArtifactType)
+	}
+	if want := "localhost:5000/test@" + subject.Digest.String(); got.Subject.Reference != want {
+		t.Errorf("subject reference = %q, want %q", got.Subject.Reference, want)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package json
+
+import (
+	"archive/tar"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// layerListHandler handles JSON metadata output for blob ls and manifest
+// ls-files commands.
+type layerListHandler struct {
+	out   io.Writer
+	model *model.LayerList
+}
+
+// NewLayerListHandler creates a new handler for layer list events.
+func NewLayerListHandler(out io.Writer, name string) metadata.LayerListHandler {
+	return &layerListHandler{
+		out:   out,
+		model: model.NewLayerList(name),
+	}
+}
+
+// OnLayerListing implements metadata.LayerListHandler.
+func (h *layerListHandler) OnLayerListing(layer ocispec.Descriptor) error {
+	h.model.AddLayer(layer)
+	return nil
+}
+
+// OnEntryListed implements metadata.LayerListHandler.
+func (h *layerListHandler) OnEntryListed(_ ocispec.Descriptor, entry *tar.Header) error {
+	h.model.AddEntry(entry)
+	return nil
+}
+
+// OnLayerSkipped implements metadata.LayerListHandler.
+func (h *layerListHandler) OnLayerSkipped(_ ocispec.Descriptor) error {
+	h.model.RemoveLastLayer()
+	return nil
+}
+
+// Render implements metadata.LayerListHandler.
+func (h *layerListHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
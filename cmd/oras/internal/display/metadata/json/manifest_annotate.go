@@ -0,0 +1,77 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package json
+
+import (
+	"fmt"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// manifestAnnotateHandler handles JSON metadata output for manifest annotate
+// events.
+type manifestAnnotateHandler struct {
+	out   io.Writer
+	path  string
+	model *model.ManifestAnnotation
+}
+
+// NewManifestAnnotateHandler creates a new handler for manifest annotate
+// events.
+func NewManifestAnnotateHandler(out io.Writer, path string) metadata.ManifestAnnotateHandler {
+	return &manifestAnnotateHandler{
+		out:  out,
+		path: path,
+	}
+}
+
+// OnAnnotated implements metadata.ManifestAnnotateHandler.
+func (h *manifestAnnotateHandler) OnAnnotated(from ocispec.Descriptor, to ocispec.Descriptor) error {
+	h.model = model.NewManifestAnnotation(h.path, from, to)
+	return nil
+}
+
+// OnReferrerMigrated implements metadata.ManifestAnnotateHandler.
+func (h *manifestAnnotateHandler) OnReferrerMigrated(from ocispec.Descriptor, to ocispec.Descriptor) error {
+	if h.model == nil {
+		return fmt.Errorf("unexpected migrated referrer: %v", from)
+	}
+	h.model.AddMapping(from, to)
+	return nil
+}
+
+// OnAnnotationSkipped implements metadata.ManifestAnnotateHandler.
+func (h *manifestAnnotateHandler) OnAnnotationSkipped(desc ocispec.Descriptor) error {
+	h.model = model.NewManifestAnnotation(h.path, desc, desc)
+	return nil
+}
+
+// OnTagged implements metadata.TaggedHandler.
+func (h *manifestAnnotateHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
+	if h.model == nil {
+		return fmt.Errorf("unexpected tag: %s", tag)
+	}
+	h.model.AddTag(tag)
+	return nil
+}
+
+// Render implements metadata.ManifestAnnotateHandler.
+func (h *manifestAnnotateHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
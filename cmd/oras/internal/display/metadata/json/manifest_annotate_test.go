@@ -0,0 +1,76 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestManifestAnnotateHandler(t *testing.T) {
+	from := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111", Size: 1}
+	to := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:2222222222222222222222222222222222222222222222222222222222222222", Size: 2}
+	tests := []struct {
+		name         string
+		annotate     func(h *manifestAnnotateHandler) error
+		wantDigest   string
+		wantUpdated  bool
+		wantMappings int
+	}{
+		{
+			name: "updated",
+			annotate: func(h *manifestAnnotateHandler) error {
+				return h.OnAnnotated(from, to)
+			},
+			wantDigest:   to.Digest.String(),
+			wantUpdated:  true,
+			wantMappings: 1,
+		},
+		{
+			name: "nothing to update",
+			annotate: func(h *manifestAnnotateHandler) error {
+				return h.OnAnnotationSkipped(from)
+			},
+			wantDigest: from.Digest.String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewManifestAnnotateHandler(buf, "localhost:5000/test").(*manifestAnnotateHandler)
+			if err := tt.annotate(h); err != nil {
+				t.Fatalf("annotate error = %v", err)
+			}
+			if err := h.Render(); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			var got struct {
+				Digest   string            `json:"digest"`
+				Updated  bool              `json:"updated"`
+				Mappings []json.RawMessage `json:"mappings"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON output %q: %v", buf.String(), err)
+			}
+			if got.Digest != tt.wantDigest || got.Updated != tt.wantUpdated || len(got.Mappings) != tt.wantMappings {
+				t.Errorf("output = %s, want digest %s, updated %v and %d mappings", buf.String(), tt.wantDigest, tt.wantUpdated, tt.wantMappings)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package json
+
+import (
+	"fmt"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// manifestConvertHandler handles JSON metadata output for manifest convert
+// events.
+type manifestConvertHandler struct {
+	out   io.Writer
+	path  string
+	model *model.ManifestConversion
+}
+
+// NewManifestConvertHandler creates a new handler for manifest convert
+// events.
+func NewManifestConvertHandler(out io.Writer, path string) metadata.ManifestConvertHandler {
+	return &manifestConvertHandler{
+		out:  out,
+		path: path,
+	}
+}
+
+// OnConverted implements metadata.ManifestConvertHandler.
+func (h *manifestConvertHandler) OnConverted(from ocispec.Descriptor, to ocispec.Descriptor, imageSpec string) error {
+	h.model = model.NewManifestConversion(h.path, from, to, imageSpec)
+	return nil
+}
+
+// OnConversionSkipped implements metadata.ManifestConvertHandler.
+func (h *manifestConvertHandler) OnConversionSkipped(desc ocispec.Descriptor, imageSpec string) error {
+	h.model = model.NewManifestConversion(h.path, desc, desc, imageSpec)
+	return nil
+}
+
+// OnTagged implements metadata.TaggedHandler.
+func (h *manifestConvertHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
+	if h.model == nil {
+		return fmt.Errorf("unexpected tag: %s", tag)
+	}
+	h.model.AddTag(tag)
+	return nil
+}
+
+// Render implements metadata.ManifestConvertHandler.
+func (h *manifestConvertHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestManifestConvertHandler(t *testing.T) {
+	from := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111", Size: 1}
+	to := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:2222222222222222222222222222222222222222222222222222222222222222", Size: 2}
+	tests := []struct {
+		name          string
+		convert       func(h *manifestConvertHandler) error
+		wantDigest    string
+		wantConverted bool
+	}{
+		{
+			name: "converted",
+			convert: func(h *manifestConvertHandler) error {
+				return h.OnConverted(from, to, "v1.0")
+			},
+			wantDigest:    to.Digest.String(),
+			wantConverted: true,
+		},
+		{
+			name: "nothing to convert",
+			convert: func(h *manifestConvertHandler) error {
+				return h.OnConversionSkipped(from, "v1.0")
+			},
+			wantDigest: from.Digest.String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewManifestConvertHandler(buf, "localhost:5000/test").(*manifestConvertHandler)
+			if err := tt.convert(h); err != nil {
+				t.Fatalf("convert error = %v", err)
+			}
+			if err := h.Render(); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			var got struct {
+				Digest    string `json:"digest"`
+				Converted bool   `json:"converted"`
+				From      string `json:"from"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON output %q: %v", buf.String(), err)
+			}
+			if got.Digest != tt.wantDigest || got.Converted != tt.wantConverted || got.From != from.Digest.String() {
+				t.Errorf("output = %+v, want digest %s, converted %v", got, tt.wantDigest, tt.wantConverted)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package json
+
+import (
+	"fmt"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// manifestExportHandler handles JSON metadata output for recursive manifest
+// fetch events.
+type manifestExportHandler struct {
+	out       io.Writer
+	outputDir string
+	model     *model.ManifestExport
+}
+
+// NewManifestExportHandler creates a new handler for recursive manifest fetch
+// events.
+func NewManifestExportHandler(out io.Writer, outputDir string) metadata.ManifestExportHandler {
+	return &manifestExportHandler{
+		out:       out,
+		outputDir: outputDir,
+	}
+}
+
+// OnRootExported implements metadata.ManifestExportHandler.
+func (h *manifestExportHandler) OnRootExported(path string, root ocispec.Descriptor, file string) error {
+	h.model = model.NewManifestExport(h.outputDir, path, root, file)
+	return nil
+}
+
+// OnExported implements metadata.ManifestExportHandler.
+func (h *manifestExportHandler) OnExported(node ocispec.Descriptor, parent ocispec.Descriptor, relation string, file string) error {
+	if h.model == nil {
+		return fmt.Errorf("unexpected exported node: %v", node)
+	}
+	switch relation {
+	case metadata.RelationManifest:
+		return h.model.AddManifest(node, parent, file)
+	case metadata.RelationConfig:
+		return h.model.AddConfig(node, parent, file)
+	case metadata.RelationReferrer:
+		return h.model.AddReferrer(node, parent, file)
+	default:
+		return fmt.Errorf("unknown relation: %q", relation)
+	}
+}
+
+// Render implements metadata.ManifestExportHandler.
+func (h *manifestExportHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package model
+
+import (
+	"time"
+
+	"oras.land/oras/internal/backup"
+)
+
+// BackupReport contains the summary of the integrity report of a backup.
+type BackupReport struct {
+	ORASVersion string    `json:"orasVersion"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Tags        int       `json:"tags"`
+	Blobs       int       `json:"blobs"`
+}
+
+// BackupVerification contains metadata formatted by oras backup verify.
+type BackupVerification struct {
+	Path   string             `json:"path"`
+	Valid  bool               `json:"valid"`
+	Blobs  int                `json:"blobs"`
+	Tags   []backup.TagRecord `json:"tags"`
+	Report *BackupReport      `json:"report,omitempty"`
+	Issues []backup.Issue     `json:"issues"`
+}
+
+// NewBackupVerification creates a new BackupVerification model.
+func NewBackupVerification(path string, result *backup.VerifyResult) *BackupVerification {
+	verification := &BackupVerification{
+		Path:   path,
+		Valid:  len(result.Issues) == 0,
+		Blobs:  result.Blobs,
+		Tags:   result.Tags,
+		Issues: result.Issues,
+	}
+	if verification.Tags == nil {
+		verification.Tags = []backup.TagRecord{}
+	}
+	if verification.Issues == nil {
+		verification.Issues = []backup.Issue{}
+	}
+	if report := result.Report; report != nil {
+		verification.Report = &BackupReport{
+			ORASVersion: report.ORASVersion,
+			CreatedAt:   report.CreatedAt,
+			Tags:        len(report.Tags),
+			Blobs:       len(report.Blobs),
+		}
+	}
+	return verification
+}
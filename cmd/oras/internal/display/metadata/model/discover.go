@@ -33,6 +33,7 @@ type Discover struct {
 type Node struct {
 	Descriptor
 	Referrers []*Node `json:"referrers"`
+	Manifests []*Node `json:"manifests,omitempty"`
 }
 
 // AddReferrer adds a node to the discovered referrers tree.
@@ -47,6 +48,20 @@ func (d *Discover) AddReferrer(referrer, subject ocispec.Descriptor) error {
 	return nil
 }
 
+// AddChild adds a child manifest of an index to the discovered referrers tree,
+// so that the referrers of the child can be added.
+func (d *Discover) AddChild(child, index ocispec.Descriptor) error {
+	parent, ok := d.nodes[index.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected index descriptor: %v", index)
+	}
+	node := NewNode(d.name, child)
+	node.Platform = child.Platform
+	d.nodes[node.Digest] = node
+	parent.Manifests = append(parent.Manifests, node)
+	return nil
+}
+
 // NewDiscover creates a new discover model.
 func NewDiscover(path string, root ocispec.Descriptor) Discover {
 	treeRoot := NewNode(path, root)
@@ -66,3 +81,76 @@ func NewNode(name string, desc ocispec.Descriptor) *Node {
 		Referrers:  []*Node{},
 	}
 }
+
+// Referrer is a model for a discovered referrer along with its subject.
+type Referrer struct {
+	Descriptor
+	Subject DigestReference `json:"subject"`
+}
+
+// NewReferrer creates a new referrer model.
+func NewReferrer(name string, referrer, subject ocispec.Descriptor) Referrer {
+	return Referrer{
+		Descriptor: FromDescriptor(name, referrer),
+		Subject:    NewDigestReference(name, subject.Digest.String()),
+	}
+}
+
+// DiscoverSubjects is a model for the discovered subject chain.
+type DiscoverSubjects struct {
+	name  string
+	nodes map[digest.Digest]*SubjectNode
+	Root  *SubjectNode
+}
+
+// SubjectNode represents a node in the discovered subject chain.
+type SubjectNode struct {
+	Descriptor
+	Subject *SubjectNode `json:"subject,omitempty"`
+	Indexes []*IndexNode `json:"indexes,omitempty"`
+}
+
+// IndexNode represents a tagged index containing a manifest in the discovered
+// subject chain.
+type IndexNode struct {
+	Descriptor
+	Tags []string `json:"tags"`
+}
+
+// NewDiscoverSubjects creates a new discover model for the subject chain.
+func NewDiscoverSubjects(path string, root ocispec.Descriptor) DiscoverSubjects {
+	chainRoot := &SubjectNode{Descriptor: FromDescriptor(path, root)}
+	return DiscoverSubjects{
+		name: path,
+		nodes: map[digest.Digest]*SubjectNode{
+			root.Digest: chainRoot,
+		},
+		Root: chainRoot,
+	}
+}
+
+// AddSubject adds the subject of a referrer to the discovered subject chain.
+func (d *DiscoverSubjects) AddSubject(subject, referrer ocispec.Descriptor) error {
+	from, ok := d.nodes[referrer.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected referrer descriptor: %v", referrer)
+	}
+	to := &SubjectNode{Descriptor: FromDescriptor(d.name, subject)}
+	d.nodes[to.Digest] = to
+	from.Subject = to
+	return nil
+}
+
+// AddIndex adds a tagged index containing the manifest to the discovered
+// subject chain.
+func (d *DiscoverSubjects) AddIndex(index ocispec.Descriptor, tags []string, manifest ocispec.Descriptor) error {
+	node, ok := d.nodes[manifest.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected manifest descriptor: %v", manifest)
+	}
+	node.Indexes = append(node.Indexes, &IndexNode{
+		Descriptor: FromDescriptor(d.name, index),
+		Tags:       tags,
+	})
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package model
+
+import (
+	"archive/tar"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// TarEntry is an entry of a tar layer.
+type TarEntry struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Size       int64  `json:"size"`
+	Mode       string `json:"mode"`
+	LinkTarget string `json:"linkTarget,omitempty"`
+}
+
+// NewTarEntry creates a new tar entry from a tar header.
+func NewTarEntry(hdr *tar.Header) TarEntry {
+	entry := TarEntry{
+		Name: hdr.Name,
+		Type: TarEntryType(hdr.Typeflag),
+		Size: hdr.Size,
+		Mode: hdr.FileInfo().Mode().String(),
+	}
+	if hdr.Typeflag == tar.TypeSymlink || hdr.Typeflag == tar.TypeLink {
+		entry.LinkTarget = hdr.Linkname
+	}
+	return entry
+}
+
+// TarEntryType returns the name of the type of a tar entry.
+func TarEntryType(typeflag byte) string {
+	switch typeflag {
+	case tar.TypeReg:
+		return "file"
+	case tar.TypeDir:
+		return "directory"
+	case tar.TypeSymlink:
+		return "symlink"
+	case tar.TypeLink:
+		return "hardlink"
+	case tar.TypeChar:
+		return "char"
+	case tar.TypeBlock:
+		return "block"
+	case tar.TypeFifo:
+		return "fifo"
+	default:
+		return "other"
+	}
+}
+
+// Layer is a layer with the listed tar entries.
+type Layer struct {
+	Descriptor
+	Entries []TarEntry `json:"entries"`
+}
+
+// LayerList contains metadata formatted by oras blob ls and oras manifest
+// ls-files.
+type LayerList struct {
+	Layers []*Layer `json:"layers"`
+
+	name string
+}
+
+// NewLayerList creates a new LayerList model.
+func NewLayerList(name string) *LayerList {
+	return &LayerList{
+		Layers: []*Layer{},
+		name:   name,
+	}
+}
+
+// AddLayer adds a layer to the metadata.
+func (l *LayerList) AddLayer(desc ocispec.Descriptor) {
+	l.Layers = append(l.Layers, &Layer{
+		Descriptor: FromDescriptor(l.name, desc),
+		Entries:    []TarEntry{},
+	})
+}
+
+// AddEntry adds an entry of the last added layer to the metadata.
+func (l *LayerList) AddEntry(hdr *tar.Header) {
+	if len(l.Layers) == 0 {
+		return
+	}
+	layer := l.Layers[len(l.Layers)-1]
+	layer.Entries = append(layer.Entries, NewTarEntry(hdr))
+}
+
+// RemoveLastLayer removes the last added layer from the metadata.
+func (l *LayerList) RemoveLastLayer() {
+	if len(l.Layers) > 0 {
+		l.Layers = l.Layers[:len(l.Layers)-1]
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package model
+
+import (
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// DigestMapping maps the digest of a manifest to the digest of the manifest
+// re-pushed in place of it.
+type DigestMapping struct {
+	ArtifactType string        `json:"artifactType,omitempty"`
+	From         digest.Digest `json:"from"`
+	To           digest.Digest `json:"to"`
+}
+
+// ManifestAnnotation contains metadata formatted by oras manifest annotate.
+type ManifestAnnotation struct {
+	Descriptor
+	Updated  bool            `json:"updated"`
+	Tags     []string        `json:"tags"`
+	Mappings []DigestMapping `json:"mappings"`
+}
+
+// NewManifestAnnotation creates a new ManifestAnnotation model of the
+// manifest from edited and pushed as to. The manifest is not updated if from
+// and to are the same.
+func NewManifestAnnotation(path string, from ocispec.Descriptor, to ocispec.Descriptor) *ManifestAnnotation {
+	a := &ManifestAnnotation{
+		Descriptor: FromDescriptor(path, to),
+		Updated:    from.Digest != to.Digest,
+		Tags:       []string{},
+		Mappings:   []DigestMapping{},
+	}
+	if a.Updated {
+		a.AddMapping(from, to)
+	}
+	return a
+}
+
+// AddTag adds a tag of the edited manifest.
+func (a *ManifestAnnotation) AddTag(tag string) {
+	a.Tags = append(a.Tags, tag)
+}
+
+// AddMapping adds the mapping of a referrer re-pushed as to.
+func (a *ManifestAnnotation) AddMapping(from ocispec.Descriptor, to ocispec.Descriptor) {
+	a.Mappings = append(a.Mappings, DigestMapping{
+		ArtifactType: to.ArtifactType,
+		From:         from.Digest,
+		To:           to.Digest,
+	})
+}
@@ -0,0 +1,47 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package model
+
+import (
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ManifestConversion contains metadata formatted by oras manifest convert.
+type ManifestConversion struct {
+	Descriptor
+	ImageSpec string        `json:"imageSpec"`
+	Converted bool          `json:"converted"`
+	From      digest.Digest `json:"from"`
+	Tags      []string      `json:"tags"`
+}
+
+// NewManifestConversion creates a new ManifestConversion model of the
+// manifest from converted to the artifact layout of imageSpec and pushed as
+// to.
+func NewManifestConversion(path string, from ocispec.Descriptor, to ocispec.Descriptor, imageSpec string) *ManifestConversion {
+	return &ManifestConversion{
+		Descriptor: FromDescriptor(path, to),
+		ImageSpec:  imageSpec,
+		Converted:  from.Digest != to.Digest,
+		From:       from.Digest,
+		Tags:       []string{},
+	}
+}
+
+// AddTag adds a tag of the converted manifest.
+func (c *ManifestConversion) AddTag(tag string) {
+	c.Tags = append(c.Tags, tag)
+}
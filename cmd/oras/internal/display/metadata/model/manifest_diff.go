@@ -0,0 +1,39 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/internal/diff"
+)
+
+// ManifestDiff contains metadata formatted by oras manifest diff.
+type ManifestDiff struct {
+	From      Descriptor `json:"from"`
+	To        Descriptor `json:"to"`
+	Identical bool       `json:"identical"`
+	diff.Result
+}
+
+// NewManifestDiff creates a new ManifestDiff model.
+func NewManifestDiff(fromPath string, from ocispec.Descriptor, toPath string, to ocispec.Descriptor, result *diff.Result) *ManifestDiff {
+	return &ManifestDiff{
+		From:      FromDescriptor(fromPath, from),
+		To:        FromDescriptor(toPath, to),
+		Identical: result.Identical(),
+		Result:    *result,
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package model
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ExportedNode is a manifest or a config written to a file.
+type ExportedNode struct {
+	Descriptor
+	File      string          `json:"file"`
+	Config    *ExportedNode   `json:"config,omitempty"`
+	Manifests []*ExportedNode `json:"manifests,omitempty"`
+	Referrers []*ExportedNode `json:"referrers,omitempty"`
+}
+
+// ManifestExport contains metadata formatted by oras manifest fetch
+// --recursive.
+type ManifestExport struct {
+	*ExportedNode
+	OutputDir string `json:"outputDir"`
+
+	name  string
+	nodes map[digest.Digest]*ExportedNode
+}
+
+// NewManifestExport creates a new ManifestExport model with the root
+// manifest in path written to file.
+func NewManifestExport(outputDir string, path string, root ocispec.Descriptor, file string) *ManifestExport {
+	node := newExportedNode(path, root, file)
+	return &ManifestExport{
+		ExportedNode: node,
+		OutputDir:    outputDir,
+		name:         path,
+		nodes: map[digest.Digest]*ExportedNode{
+			root.Digest: node,
+		},
+	}
+}
+
+// AddManifest adds a manifest of the index parent, written to file.
+func (e *ManifestExport) AddManifest(manifest ocispec.Descriptor, parent ocispec.Descriptor, file string) error {
+	to, from, err := e.add(manifest, parent, file)
+	if err != nil {
+		return err
+	}
+	to.Manifests = append(to.Manifests, from)
+	return nil
+}
+
+// AddConfig adds the config of the manifest parent, written to file.
+func (e *ManifestExport) AddConfig(config ocispec.Descriptor, parent ocispec.Descriptor, file string) error {
+	to, from, err := e.add(config, parent, file)
+	if err != nil {
+		return err
+	}
+	to.Config = from
+	return nil
+}
+
+// AddReferrer adds a referrer of parent, written to file.
+func (e *ManifestExport) AddReferrer(referrer ocispec.Descriptor, parent ocispec.Descriptor, file string) error {
+	to, from, err := e.add(referrer, parent, file)
+	if err != nil {
+		return err
+	}
+	to.Referrers = append(to.Referrers, from)
+	return nil
+}
+
+// add returns the nodes of parent and desc, creating the node of desc if it
+// is not yet added.
+func (e *ManifestExport) add(desc ocispec.Descriptor, parent ocispec.Descriptor, file string) (to *ExportedNode, from *ExportedNode, err error) {
+	to, ok := e.nodes[parent.Digest]
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected parent descriptor: %v", parent)
+	}
+	from, ok = e.nodes[desc.Digest]
+	if !ok {
+		from = newExportedNode(e.name, desc, file)
+		e.nodes[desc.Digest] = from
+	}
+	return to, from, nil
+}
+
+func newExportedNode(name string, desc ocispec.Descriptor, file string) *ExportedNode {
+	node := &ExportedNode{
+		Descriptor: FromDescriptor(name, desc),
+		File:       file,
+	}
+	node.Platform = desc.Platform
+	return node
+}
@@ -0,0 +1,42 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package model
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/internal/validate"
+)
+
+// ManifestValidation contains metadata formatted by oras manifest validate.
+type ManifestValidation struct {
+	Descriptor
+	File   string           `json:"file,omitempty"`
+	Valid  bool             `json:"valid"`
+	Issues []validate.Issue `json:"issues"`
+}
+
+// NewManifestValidation creates a new ManifestValidation model.
+func NewManifestValidation(path string, desc ocispec.Descriptor, file string, issues []validate.Issue) *ManifestValidation {
+	validation := &ManifestValidation{
+		Descriptor: FromDescriptor(path, desc),
+		File:       file,
+		Valid:      len(issues) == 0,
+		Issues:     issues,
+	}
+	if validation.Issues == nil {
+		validation.Issues = []validate.Issue{}
+	}
+	return validation
+}
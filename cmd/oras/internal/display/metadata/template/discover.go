@@ -47,6 +47,11 @@ func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) err
 	return h.model.AddReferrer(referrer, subject)
 }
 
+// OnChildDiscovered implements metadata.DiscoverChildHandler.
+func (h *discoverHandler) OnChildDiscovered(child, index ocispec.Descriptor) error {
+	return h.model.AddChild(child, index)
+}
+
 // Render implements metadata.DiscoverHandler.
 func (h *discoverHandler) Render() error {
 	return output.ParseAndWrite(h.out, h.model.Root, h.template)
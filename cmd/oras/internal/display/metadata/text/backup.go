@@ -48,6 +48,11 @@ func (bh *BackupHandler) OnTarExported(path string, size int64) error {
 	return bh.printer.Printf("Exported to %s (%s)\n", path, humanize.ToBytes(size))
 }
 
+// OnVolumeExported implements metadata.BackupHandler.
+func (bh *BackupHandler) OnVolumeExported(path string, size int64) error {
+	return bh.printer.Printf("Exported volume %s (%s)\n", path, humanize.ToBytes(size))
+}
+
 // OnTarExporting implements metadata.BackupHandler.
 func (bh *BackupHandler) OnTarExporting(path string) error {
 	return bh.printer.Printf("Exporting to %s\n", path)
@@ -59,6 +64,16 @@ func (bh *BackupHandler) OnArtifactPulled(tag string, referrerCount int) error {
 	return bh.printer.Printf("Pulled tag %s with %d referrer(s)\n", tag, referrerCount)
 }
 
+// OnArtifactUnchanged implements metadata.BackupHandler.
+func (bh *BackupHandler) OnArtifactUnchanged(tag string) error {
+	return bh.printer.Printf("Skipped tag %s (unchanged since last backup)\n", tag)
+}
+
+// OnTagPruned implements metadata.BackupHandler.
+func (bh *BackupHandler) OnTagPruned(tag string) error {
+	return bh.printer.Printf("Pruned tag %s (deleted from %s)\n", tag, bh.repo)
+}
+
 // OnTagsFound implements metadata.BackupHandler.
 func (bh *BackupHandler) OnTagsFound(tags []string) error {
 	if len(tags) == 0 {
@@ -132,6 +132,30 @@ func TestBackupHandler_OnArtifactPulled(t *testing.T) {
 	}
 }
 
+func TestBackupHandler_OnArtifactUnchanged(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := output.NewPrinter(out, os.Stderr)
+	bh := NewBackupHandler("any", printer)
+	if err := bh.OnArtifactUnchanged("v1"); err != nil {
+		t.Fatalf("OnArtifactUnchanged() error = %v", err)
+	}
+	if got, want := out.String(), "Skipped tag v1 (unchanged since last backup)\n"; got != want {
+		t.Errorf("OnArtifactUnchanged() got = %v, want %v", got, want)
+	}
+}
+
+func TestBackupHandler_OnTagPruned(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := output.NewPrinter(out, os.Stderr)
+	bh := NewBackupHandler("localhost:5000/hello", printer)
+	if err := bh.OnTagPruned("v1"); err != nil {
+		t.Fatalf("OnTagPruned() error = %v", err)
+	}
+	if got, want := out.String(), "Pruned tag v1 (deleted from localhost:5000/hello)\n"; got != want {
+		t.Errorf("OnTagPruned() got = %v, want %v", got, want)
+	}
+}
+
 func TestBackupHandler_OnTagsFound(t *testing.T) {
 	repo := "testRepo"
 	tests := []struct {
@@ -241,3 +265,14 @@ func TestBackupHandler_OnBackupCompleted(t *testing.T) {
 		})
 	}
 }
+
+func TestBackupHandler_OnVolumeExported(t *testing.T) {
+	out := &bytes.Buffer{}
+	bh := NewBackupHandler("any", output.NewPrinter(out, os.Stderr))
+	if err := bh.OnVolumeExported("test.tar.001", 2048); err != nil {
+		t.Fatalf("OnVolumeExported() error = %v", err)
+	}
+	if got, want := out.String(), "Exported volume test.tar.001 (2 KB)\n"; got != want {
+		t.Errorf("OnVolumeExported() got = %q, want %q", got, want)
+	}
+}
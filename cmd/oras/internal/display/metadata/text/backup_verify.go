@@ -0,0 +1,77 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	"fmt"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/backup"
+)
+
+// backupVerifyHandler handles text metadata output for backup verify command.
+type backupVerifyHandler struct {
+	printer *output.Printer
+}
+
+// NewBackupVerifyHandler creates a new text handler for backup verify command.
+func NewBackupVerifyHandler(printer *output.Printer) metadata.BackupVerifyHandler {
+	return &backupVerifyHandler{
+		printer: printer,
+	}
+}
+
+// OnVerified implements metadata.BackupVerifyHandler.
+func (h *backupVerifyHandler) OnVerified(path string, result *backup.VerifyResult) error {
+	if err := h.printer.Printf("Verified %d tag(s) and %d blob(s) in %q\n", len(result.Tags), result.Blobs, path); err != nil {
+		return err
+	}
+	if report := result.Report; report != nil {
+		if err := h.printer.Printf("Checked against the integrity report created by oras %s at %s\n", report.ORASVersion, report.CreatedAt.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	} else if err := h.printer.Println("No integrity report found in the backup"); err != nil {
+		return err
+	}
+
+	if len(result.Issues) == 0 {
+		return h.printer.Println("No issues found")
+	}
+	if err := h.printer.Printf("Found %d issue(s):\n", len(result.Issues)); err != nil {
+		return err
+	}
+	for _, issue := range result.Issues {
+		if err := h.printer.Println(formatIssue(issue)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render implements metadata.BackupVerifyHandler.
+func (h *backupVerifyHandler) Render() error {
+	return nil
+}
+
+// formatIssue formats an integrity issue in a single line.
+func formatIssue(issue backup.Issue) string {
+	subject := string(issue.Digest)
+	if issue.Reference != "" {
+		subject = fmt.Sprintf("%s@%s", issue.Reference, issue.Digest)
+	}
+	return fmt.Sprintf("- %s %s: %s", issue.Kind, subject, issue.Detail)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/backup"
+)
+
+func TestBackupVerifyHandler_OnVerified(t *testing.T) {
+	tags := []backup.TagRecord{{Reference: "v1", Digest: "sha256:d5b7c742df27379894518554b73f7a3a03b4440ea435151a8b525a8d2555a0b2"}}
+	tests := []struct {
+		name   string
+		result *backup.VerifyResult
+		want   string
+	}{
+		{
+			name:   "no issue without report",
+			result: &backup.VerifyResult{Tags: tags, Blobs: 3},
+			want: "Verified 1 tag(s) and 3 blob(s) in \"backup.tar\"\n" +
+				"No integrity report found in the backup\n" +
+				"No issues found\n",
+		},
+		{
+			name: "issues with report",
+			result: &backup.VerifyResult{
+				Tags:  tags,
+				Blobs: 2,
+				Report: &backup.Report{
+					ORASVersion: "1.3.0",
+					CreatedAt:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+				},
+				Issues: []backup.Issue{
+					{
+						Kind:      backup.IssueMissing,
+						Reference: "v1",
+						Digest:    "sha256:a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447",
+						Detail:    "blob of size 5 is missing",
+					},
+				},
+			},
+			want: "Verified 1 tag(s) and 2 blob(s) in \"backup.tar\"\n" +
+				"Checked against the integrity report created by oras 1.3.0 at 2025-01-02T03:04:05Z\n" +
+				"Found 1 issue(s):\n" +
+				"- missing v1@sha256:a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447: blob of size 5 is missing\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			handler := NewBackupVerifyHandler(output.NewPrinter(out, os.Stderr))
+			if err := handler.OnVerified("backup.tar", tt.result); err != nil {
+				t.Fatalf("OnVerified() error = %v", err)
+			}
+			if err := handler.Render(); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("OnVerified() output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
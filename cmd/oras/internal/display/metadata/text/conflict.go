@@ -0,0 +1,77 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// overwrittenTag is a destination tag moved to a different artifact.
+type overwrittenTag struct {
+	tag      string
+	previous ocispec.Descriptor
+	current  ocispec.Descriptor
+}
+
+// conflictHandler handles text metadata output for tag conflicts.
+type conflictHandler struct {
+	printer     *output.Printer
+	dryRun      bool
+	overwritten []overwrittenTag
+}
+
+// OnTagConflict implements metadata.ConflictHandler.
+func (h *conflictHandler) OnTagConflict(tag string, existing, incoming ocispec.Descriptor) error {
+	return h.printer.Printf("Conflict: tag %s points to %s instead of %s\n", tag, existing.Digest, incoming.Digest)
+}
+
+// OnConflictSkipped implements metadata.ConflictHandler.
+func (h *conflictHandler) OnConflictSkipped(tag string) error {
+	if h.dryRun {
+		return h.printer.Printf("Dry run: would skip tag %s due to conflict\n", tag)
+	}
+	return h.printer.Printf("Skipped tag %s due to conflict\n", tag)
+}
+
+// OnTagOverwritten implements metadata.ConflictHandler.
+func (h *conflictHandler) OnTagOverwritten(tag string, previous, current ocispec.Descriptor) error {
+	h.overwritten = append(h.overwritten, overwrittenTag{
+		tag:      tag,
+		previous: previous,
+		current:  current,
+	})
+	return nil
+}
+
+// printOverwritten prints the summary of the overwritten tags, if any.
+func (h *conflictHandler) printOverwritten() error {
+	if len(h.overwritten) == 0 {
+		return nil
+	}
+	if h.dryRun {
+		if err := h.printer.Printf("Dry run: would overwrite %d tag(s):\n", len(h.overwritten)); err != nil {
+			return err
+		}
+	} else if err := h.printer.Printf("Overwrote %d tag(s):\n", len(h.overwritten)); err != nil {
+		return err
+	}
+	for _, o := range h.overwritten {
+		if err := h.printer.Printf("  %s: %s -> %s\n", o.tag, o.previous.Digest, o.current.Digest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
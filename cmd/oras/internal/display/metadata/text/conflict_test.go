@@ -0,0 +1,113 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+var (
+	existingDesc = content.NewDescriptorFromBytes("application/vnd.test", []byte("existing"))
+	incomingDesc = content.NewDescriptorFromBytes("application/vnd.test", []byte("incoming"))
+)
+
+func TestConflictHandler_OnTagConflict(t *testing.T) {
+	out := &bytes.Buffer{}
+	handler := &conflictHandler{printer: output.NewPrinter(out, os.Stderr)}
+	if err := handler.OnTagConflict("v1", existingDesc, incomingDesc); err != nil {
+		t.Fatalf("OnTagConflict() error = %v", err)
+	}
+	want := "Conflict: tag v1 points to " + existingDesc.Digest.String() + " instead of " + incomingDesc.Digest.String() + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("OnTagConflict() got = %q, want %q", got, want)
+	}
+}
+
+func TestConflictHandler_OnConflictSkipped(t *testing.T) {
+	tests := []struct {
+		name   string
+		dryRun bool
+		want   string
+	}{
+		{
+			name: "skipped",
+			want: "Skipped tag v1 due to conflict\n",
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			want:   "Dry run: would skip tag v1 due to conflict\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			handler := &conflictHandler{printer: output.NewPrinter(out, os.Stderr), dryRun: tt.dryRun}
+			if err := handler.OnConflictSkipped("v1"); err != nil {
+				t.Fatalf("OnConflictSkipped() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("OnConflictSkipped() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestoreHandler_OnRestoreCompleted_overwritten(t *testing.T) {
+	tests := []struct {
+		name   string
+		dryRun bool
+		want   string
+	}{
+		{
+			name: "overwritten",
+			want: "Overwrote 1 tag(s):\n" +
+				"  v1: " + existingDesc.Digest.String() + " -> " + incomingDesc.Digest.String() + "\n" +
+				"Successfully restored 2 tag(s) to \"example.com/myrepo\" in 1s\n",
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			want: "Dry run: would overwrite 1 tag(s):\n" +
+				"  v1: " + existingDesc.Digest.String() + " -> " + incomingDesc.Digest.String() + "\n" +
+				"Dry run complete: 2 tag(s) would be restored to \"example.com/myrepo\" (no data pushed)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			handler := NewRestoreHandler(output.NewPrinter(out, os.Stderr), tt.dryRun)
+			if err := handler.OnTagOverwritten("v1", existingDesc, incomingDesc); err != nil {
+				t.Fatalf("OnTagOverwritten() error = %v", err)
+			}
+			if out.Len() != 0 {
+				t.Fatalf("OnTagOverwritten() printed %q, want no output until completion", out.String())
+			}
+			if err := handler.OnRestoreCompleted(2, "example.com/myrepo", time.Second); err != nil {
+				t.Fatalf("OnRestoreCompleted() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("OnRestoreCompleted() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
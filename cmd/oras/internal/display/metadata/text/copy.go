@@ -24,6 +24,7 @@ import (
 
 // CopyHandler handles text metadata output for cp events.
 type CopyHandler struct {
+	conflictHandler
 	printer *output.Printer
 	desc    ocispec.Descriptor
 }
@@ -31,7 +32,8 @@ type CopyHandler struct {
 // NewCopyHandler returns a new handler for cp events.
 func NewCopyHandler(printer *output.Printer) metadata.CopyHandler {
 	return &CopyHandler{
-		printer: printer,
+		conflictHandler: conflictHandler{printer: printer},
+		printer:         printer,
 	}
 }
 
@@ -42,6 +44,9 @@ func (h *CopyHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
 
 // Render implements metadata.Renderer.
 func (h *CopyHandler) Render() error {
+	if err := h.printOverwritten(); err != nil {
+		return err
+	}
 	return h.printer.Println("Digest:", h.desc.Digest)
 }
 
@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+)
+
+// discoverHandler handles text metadata output for discover events. Each
+// referrer is printed as soon as it is discovered.
+type discoverHandler struct {
+	out  io.Writer
+	path string
+}
+
+// NewDiscoverHandler creates a new handler for discover events.
+func NewDiscoverHandler(out io.Writer, path string) metadata.DiscoverHandler {
+	return &discoverHandler{
+		out:  out,
+		path: path,
+	}
+}
+
+// OnDiscovered implements metadata.DiscoverHandler.
+func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) error {
+	artifactType := referrer.ArtifactType
+	if artifactType == "" {
+		artifactType = "<unknown>"
+	}
+	_, err := fmt.Fprintf(h.out, "%s %s@%s (subject: %s)\n", artifactType, h.path, referrer.Digest, subject.Digest)
+	return err
+}
+
+// Render implements metadata.DiscoverHandler.
+func (h *discoverHandler) Render() error {
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestDiscoverHandler_OnDiscovered(t *testing.T) {
+	subject := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2",
+	}
+	referrer := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d",
+		ArtifactType: "test/sbom",
+	}
+	unknown := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	}
+
+	buf := &bytes.Buffer{}
+	h := NewDiscoverHandler(buf, "localhost:5000/test")
+	if err := h.OnDiscovered(referrer, subject); err != nil {
+		t.Fatalf("OnDiscovered() error = %v", err)
+	}
+	want := "test/sbom localhost:5000/test@sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d (subject: sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("OnDiscovered() printed %q, want %q", got, want)
+	}
+
+	// the referrer is printed before rendering
+	buf.Reset()
+	if err := h.OnDiscovered(unknown, referrer); err != nil {
+		t.Fatalf("OnDiscovered() error = %v", err)
+	}
+	want = "<unknown> localhost:5000/test@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855 (subject: sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("OnDiscovered() printed %q, want %q", got, want)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("Render() printed %q, want nothing", got[len(want):])
+	}
+}
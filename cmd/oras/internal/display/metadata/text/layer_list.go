@@ -0,0 +1,85 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	"archive/tar"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// layerListHandler handles text metadata output for blob ls and manifest
+// ls-files commands.
+type layerListHandler struct {
+	printer    *output.Printer
+	showLayers bool
+	pending    bool
+}
+
+// NewLayerListHandler creates a new handler for layer list events. The
+// layers are printed before their entries if showLayers is true.
+func NewLayerListHandler(printer *output.Printer, showLayers bool) metadata.LayerListHandler {
+	return &layerListHandler{
+		printer:    printer,
+		showLayers: showLayers,
+	}
+}
+
+// OnLayerListing implements metadata.LayerListHandler.
+func (h *layerListHandler) OnLayerListing(_ ocispec.Descriptor) error {
+	// the layer is printed with its first listed entry
+	h.pending = h.showLayers
+	return nil
+}
+
+// OnEntryListed implements metadata.LayerListHandler.
+func (h *layerListHandler) OnEntryListed(layer ocispec.Descriptor, entry *tar.Header) error {
+	if h.pending {
+		h.pending = false
+		title := layer.Annotations[ocispec.AnnotationTitle]
+		if title == "" {
+			title = layer.MediaType
+		}
+		if err := h.printer.Printf("%s %s\n", layer.Digest, title); err != nil {
+			return err
+		}
+	}
+	name := entry.Name
+	switch entry.Typeflag {
+	case tar.TypeSymlink:
+		name = fmt.Sprintf("%s -> %s", name, entry.Linkname)
+	case tar.TypeLink:
+		name = fmt.Sprintf("%s link to %s", name, entry.Linkname)
+	}
+	indent := ""
+	if h.showLayers {
+		indent = "  "
+	}
+	return h.printer.Printf("%s%s %12d %s\n", indent, entry.FileInfo().Mode(), entry.Size, name)
+}
+
+// OnLayerSkipped implements metadata.LayerListHandler.
+func (h *layerListHandler) OnLayerSkipped(layer ocispec.Descriptor) error {
+	h.pending = false
+	return h.printer.Println("Skipped", layer.Digest, layer.MediaType+": not a tar archive")
+}
+
+// Render implements metadata.LayerListHandler.
+func (h *layerListHandler) Render() error {
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestLayerListHandler(t *testing.T) {
+	layer := ocispec.Descriptor{
+		MediaType:   ocispec.MediaTypeImageLayerGzip,
+		Digest:      "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		Annotations: map[string]string{ocispec.AnnotationTitle: "rootfs.tar.gz"},
+	}
+	empty := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+	}
+	skipped := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    "sha256:3333333333333333333333333333333333333333333333333333333333333333",
+	}
+	entries := []*tar.Header{
+		{Name: "usr/bin/foo", Typeflag: tar.TypeReg, Mode: 0755, Size: 42},
+		{Name: "usr/bin/bar", Typeflag: tar.TypeSymlink, Mode: 0777, Linkname: "foo"},
+		{Name: "usr/bin/baz", Typeflag: tar.TypeLink, Mode: 0755, Linkname: "usr/bin/foo"},
+	}
+
+	tests := []struct {
+		name       string
+		showLayers bool
+		want       string
+	}{
+		{
+			name:       "show layers",
+			showLayers: true,
+			want: `sha256:1111111111111111111111111111111111111111111111111111111111111111 rootfs.tar.gz
+  -rwxr-xr-x           42 usr/bin/foo
+  Lrwxrwxrwx            0 usr/bin/bar -> foo
+  -rwxr-xr-x            0 usr/bin/baz link to usr/bin/foo
+Skipped sha256:3333333333333333333333333333333333333333333333333333333333333333 application/vnd.oci.image.layer.v1.tar: not a tar archive
+`,
+		},
+		{
+			name: "entries only",
+			want: `-rwxr-xr-x           42 usr/bin/foo
+Lrwxrwxrwx            0 usr/bin/bar -> foo
+-rwxr-xr-x            0 usr/bin/baz link to usr/bin/foo
+Skipped sha256:3333333333333333333333333333333333333333333333333333333333333333 application/vnd.oci.image.layer.v1.tar: not a tar archive
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewLayerListHandler(output.NewPrinter(buf, os.Stderr), tt.showLayers)
+			if err := h.OnLayerListing(layer); err != nil {
+				t.Fatalf("OnLayerListing() error = %v", err)
+			}
+			for _, entry := range entries {
+				if err := h.OnEntryListed(layer, entry); err != nil {
+					t.Fatalf("OnEntryListed() error = %v", err)
+				}
+			}
+			// layers without listed entries are not printed
+			if err := h.OnLayerListing(empty); err != nil {
+				t.Fatalf("OnLayerListing() error = %v", err)
+			}
+			if err := h.OnLayerListing(skipped); err != nil {
+				t.Fatalf("OnLayerListing() error = %v", err)
+			}
+			if err := h.OnLayerSkipped(skipped); err != nil {
+				t.Fatalf("OnLayerSkipped() error = %v", err)
+			}
+			if err := h.Render(); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
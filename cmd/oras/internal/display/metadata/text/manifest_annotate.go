@@ -0,0 +1,67 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// manifestAnnotateHandler handles text metadata output for manifest annotate
+// events.
+type manifestAnnotateHandler struct {
+	printer *output.Printer
+	desc    ocispec.Descriptor
+}
+
+// NewManifestAnnotateHandler creates a new handler for manifest annotate
+// events.
+func NewManifestAnnotateHandler(printer *output.Printer) metadata.ManifestAnnotateHandler {
+	return &manifestAnnotateHandler{
+		printer: printer,
+	}
+}
+
+// OnAnnotated implements metadata.ManifestAnnotateHandler.
+func (h *manifestAnnotateHandler) OnAnnotated(from ocispec.Descriptor, to ocispec.Descriptor) error {
+	h.desc = to
+	return h.printer.Printf("Annotated %s -> %s\n", from.Digest, to.Digest)
+}
+
+// OnReferrerMigrated implements metadata.ManifestAnnotateHandler.
+func (h *manifestAnnotateHandler) OnReferrerMigrated(from ocispec.Descriptor, to ocispec.Descriptor) error {
+	artifactType := to.ArtifactType
+	if artifactType == "" {
+		artifactType = "<unknown>"
+	}
+	return h.printer.Printf("Migrated  %s -> %s %s\n", from.Digest, to.Digest, artifactType)
+}
+
+// OnAnnotationSkipped implements metadata.ManifestAnnotateHandler.
+func (h *manifestAnnotateHandler) OnAnnotationSkipped(desc ocispec.Descriptor) error {
+	h.desc = desc
+	return h.printer.Println("Nothing to update as the annotations are unchanged")
+}
+
+// OnTagged implements metadata.TaggedHandler.
+func (h *manifestAnnotateHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
+	return h.printer.Println("Tagged   ", tag)
+}
+
+// Render implements metadata.ManifestAnnotateHandler.
+func (h *manifestAnnotateHandler) Render() error {
+	return h.printer.Println("Digest:", h.desc.Digest)
+}
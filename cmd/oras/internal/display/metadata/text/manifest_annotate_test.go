@@ -0,0 +1,73 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestManifestAnnotateHandler(t *testing.T) {
+	from := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"}
+	to := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:2222222222222222222222222222222222222222222222222222222222222222"}
+	sigFrom := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:3333333333333333333333333333333333333333333333333333333333333333", ArtifactType: "test/sig"}
+	sigTo := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:4444444444444444444444444444444444444444444444444444444444444444", ArtifactType: "test/sig"}
+
+	buf := &bytes.Buffer{}
+	h := NewManifestAnnotateHandler(output.NewPrinter(buf, os.Stderr))
+	if err := h.OnAnnotated(from, to); err != nil {
+		t.Fatalf("OnAnnotated() error = %v", err)
+	}
+	if err := h.OnReferrerMigrated(sigFrom, sigTo); err != nil {
+		t.Fatalf("OnReferrerMigrated() error = %v", err)
+	}
+	if err := h.OnTagged(to, "v1"); err != nil {
+		t.Fatalf("OnTagged() error = %v", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `Annotated sha256:1111111111111111111111111111111111111111111111111111111111111111 -> sha256:2222222222222222222222222222222222222222222222222222222222222222
+Migrated  sha256:3333333333333333333333333333333333333333333333333333333333333333 -> sha256:4444444444444444444444444444444444444444444444444444444444444444 test/sig
+Tagged    v1
+Digest: sha256:2222222222222222222222222222222222222222222222222222222222222222
+`
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestManifestAnnotateHandler_OnAnnotationSkipped(t *testing.T) {
+	desc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"}
+
+	buf := &bytes.Buffer{}
+	h := NewManifestAnnotateHandler(output.NewPrinter(buf, os.Stderr))
+	if err := h.OnAnnotationSkipped(desc); err != nil {
+		t.Fatalf("OnAnnotationSkipped() error = %v", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `Nothing to update as the annotations are unchanged
+Digest: sha256:1111111111111111111111111111111111111111111111111111111111111111
+`
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// manifestConvertHandler handles text metadata output for manifest convert
+// events.
+type manifestConvertHandler struct {
+	printer *output.Printer
+	desc    ocispec.Descriptor
+}
+
+// NewManifestConvertHandler creates a new handler for manifest convert
+// events.
+func NewManifestConvertHandler(printer *output.Printer) metadata.ManifestConvertHandler {
+	return &manifestConvertHandler{
+		printer: printer,
+	}
+}
+
+// OnConverted implements metadata.ManifestConvertHandler.
+func (h *manifestConvertHandler) OnConverted(from ocispec.Descriptor, to ocispec.Descriptor, imageSpec string) error {
+	h.desc = to
+	return h.printer.Printf("Converted %s -> %s (image-spec %s)\n", from.Digest, to.Digest, imageSpec)
+}
+
+// OnConversionSkipped implements metadata.ManifestConvertHandler.
+func (h *manifestConvertHandler) OnConversionSkipped(desc ocispec.Descriptor, imageSpec string) error {
+	h.desc = desc
+	return h.printer.Printf("Nothing to convert as the artifact is already in the image-spec %s layout\n", imageSpec)
+}
+
+// OnTagged implements metadata.TaggedHandler.
+func (h *manifestConvertHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
+	return h.printer.Println("Tagged   ", tag)
+}
+
+// Render implements metadata.ManifestConvertHandler.
+func (h *manifestConvertHandler) Render() error {
+	return h.printer.Println("Digest:", h.desc.Digest)
+}
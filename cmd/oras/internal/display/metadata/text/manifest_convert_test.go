@@ -0,0 +1,67 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestManifestConvertHandler(t *testing.T) {
+	from := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"}
+	to := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:2222222222222222222222222222222222222222222222222222222222222222"}
+
+	buf := &bytes.Buffer{}
+	h := NewManifestConvertHandler(output.NewPrinter(buf, os.Stderr))
+	if err := h.OnConverted(from, to, "v1.0"); err != nil {
+		t.Fatalf("OnConverted() error = %v", err)
+	}
+	if err := h.OnTagged(to, "v1-legacy"); err != nil {
+		t.Fatalf("OnTagged() error = %v", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `Converted sha256:1111111111111111111111111111111111111111111111111111111111111111 -> sha256:2222222222222222222222222222222222222222222222222222222222222222 (image-spec v1.0)
+Tagged    v1-legacy
+Digest: sha256:2222222222222222222222222222222222222222222222222222222222222222
+`
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestManifestConvertHandler_OnConversionSkipped(t *testing.T) {
+	desc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"}
+
+	buf := &bytes.Buffer{}
+	h := NewManifestConvertHandler(output.NewPrinter(buf, os.Stderr))
+	if err := h.OnConversionSkipped(desc, "v1.1"); err != nil {
+		t.Fatalf("OnConversionSkipped() error = %v", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `Nothing to convert as the artifact is already in the image-spec v1.1 layout
+Digest: sha256:1111111111111111111111111111111111111111111111111111111111111111
+`
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/diff"
+)
+
+// manifestDiffHandler handles text metadata output for manifest diff events.
+type manifestDiffHandler struct {
+	printer *output.Printer
+}
+
+// NewManifestDiffHandler creates a new handler for manifest diff events.
+func NewManifestDiffHandler(printer *output.Printer) metadata.ManifestDiffHandler {
+	return &manifestDiffHandler{
+		printer: printer,
+	}
+}
+
+// OnCompared implements metadata.ManifestDiffHandler.
+func (h *manifestDiffHandler) OnCompared(fromPath string, from ocispec.Descriptor, toPath string, to ocispec.Descriptor, result *diff.Result) error {
+	if err := h.printer.Printf("--- %s@%s\n+++ %s@%s\n", fromPath, from.Digest, toPath, to.Digest); err != nil {
+		return err
+	}
+	if result.Identical() {
+		return h.printer.Println("No differences found")
+	}
+	for _, line := range formatDiff(result, "") {
+		if err := h.printer.Println(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render implements metadata.ManifestDiffHandler.
+func (h *manifestDiffHandler) Render() error {
+	return nil
+}
+
+// formatDiff formats the changes in a result as lines, prefixed with the
+// indent.
+func formatDiff(result *diff.Result, indent string) []string {
+	var lines []string
+	for _, change := range result.Fields {
+		lines = append(lines, indent+formatValueChange(change, "", true))
+	}
+	for _, change := range result.Annotations {
+		lines = append(lines, indent+formatValueChange(change, "annotation ", true))
+	}
+	for _, change := range result.Config {
+		lines = append(lines, indent+formatValueChange(change, "config ", false))
+	}
+	for _, group := range []struct {
+		kind    string
+		changes []diff.DescriptorChange
+	}{
+		{"layer", result.Layers},
+		{"manifest", result.Manifests},
+		{"referrer", result.Referrers},
+	} {
+		for _, change := range group.changes {
+			lines = append(lines, indent+formatDescriptorChange(change, group.kind))
+			if change.Diff != nil {
+				lines = append(lines, formatDiff(change.Diff, indent+"    ")...)
+			}
+		}
+	}
+	return lines
+}
+
+// formatValueChange formats a value change in a single line.
+func formatValueChange(change diff.ValueChange, prefix string, quote bool) string {
+	format := func(value string) string {
+		if quote {
+			return fmt.Sprintf("%q", value)
+		}
+		return value
+	}
+	switch change.Change {
+	case diff.ChangeAdded:
+		return fmt.Sprintf("+ %s%s: %s", prefix, change.Name, format(change.To))
+	case diff.ChangeRemoved:
+		return fmt.Sprintf("- %s%s: %s", prefix, change.Name, format(change.From))
+	default:
+		return fmt.Sprintf("~ %s%s: %s -> %s", prefix, change.Name, format(change.From), format(change.To))
+	}
+}
+
+// formatDescriptorChange formats a descriptor change in a single line.
+func formatDescriptorChange(change diff.DescriptorChange, kind string) string {
+	switch change.Change {
+	case diff.ChangeAdded:
+		return fmt.Sprintf("+ %s %s: %s", kind, change.Key, change.To.Digest)
+	case diff.ChangeRemoved:
+		return fmt.Sprintf("- %s %s: %s", kind, change.Key, change.From.Digest)
+	default:
+		line := fmt.Sprintf("~ %s %s: %s -> %s", kind, change.Key, change.From.Digest, change.To.Digest)
+		if change.From.MediaType != change.To.MediaType {
+			line += fmt.Sprintf(" (%s -> %s)", change.From.MediaType, change.To.MediaType)
+		}
+		return line
+	}
+}
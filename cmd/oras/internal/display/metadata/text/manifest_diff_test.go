@@ -0,0 +1,87 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/diff"
+)
+
+func TestManifestDiffHandler_OnCompared(t *testing.T) {
+	from := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex, Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"}
+	to := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex, Digest: "sha256:2222222222222222222222222222222222222222222222222222222222222222"}
+	amd64V1 := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:3333333333333333333333333333333333333333333333333333333333333333"}
+	amd64V2 := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:4444444444444444444444444444444444444444444444444444444444444444"}
+	layer := ocispec.Descriptor{MediaType: "test/layer", Digest: "sha256:5555555555555555555555555555555555555555555555555555555555555555"}
+
+	tests := []struct {
+		name   string
+		result *diff.Result
+		want   string
+	}{
+		{
+			name:   "identical",
+			result: &diff.Result{},
+			want: `--- localhost:5000/v1@sha256:1111111111111111111111111111111111111111111111111111111111111111
++++ layout@sha256:2222222222222222222222222222222222222222222222222222222222222222
+No differences found
+`,
+		},
+		{
+			name: "changes",
+			result: &diff.Result{
+				Annotations: []diff.ValueChange{{Name: "k", Change: diff.ChangeModified, From: "1", To: "2"}},
+				Manifests: []diff.DescriptorChange{{
+					Key:    "linux/amd64",
+					Change: diff.ChangeModified,
+					From:   &amd64V1,
+					To:     &amd64V2,
+					Diff: &diff.Result{
+						Config: []diff.ValueChange{{Name: "architecture", Change: diff.ChangeAdded, To: `"amd64"`}},
+						Layers: []diff.DescriptorChange{{Key: "a.txt", Change: diff.ChangeRemoved, From: &layer}},
+					},
+				}},
+			},
+			want: `--- localhost:5000/v1@sha256:1111111111111111111111111111111111111111111111111111111111111111
++++ layout@sha256:2222222222222222222222222222222222222222222222222222222222222222
+~ annotation k: "1" -> "2"
+~ manifest linux/amd64: sha256:3333333333333333333333333333333333333333333333333333333333333333 -> sha256:4444444444444444444444444444444444444444444444444444444444444444
+    + config architecture: "amd64"
+    - layer a.txt: sha256:5555555555555555555555555555555555555555555555555555555555555555
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewManifestDiffHandler(output.NewPrinter(buf, os.Stderr))
+			if err := h.OnCompared("localhost:5000/v1", from, "layout", to, tt.result); err != nil {
+				t.Fatalf("OnCompared() error = %v", err)
+			}
+			if err := h.Render(); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("OnCompared() printed %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/validate"
+)
+
+// manifestValidateHandler handles text metadata output for manifest validate
+// events.
+type manifestValidateHandler struct {
+	printer *output.Printer
+}
+
+// NewManifestValidateHandler creates a new handler for manifest validate
+// events.
+func NewManifestValidateHandler(printer *output.Printer) metadata.ManifestValidateHandler {
+	return &manifestValidateHandler{
+		printer: printer,
+	}
+}
+
+// OnValidated implements metadata.ManifestValidateHandler.
+func (h *manifestValidateHandler) OnValidated(path string, desc ocispec.Descriptor, file string, issues []validate.Issue) error {
+	if file != "" {
+		if err := h.printer.Printf("Validated %s %q against %s\n", desc.MediaType, file, path); err != nil {
+			return err
+		}
+	} else if err := h.printer.Printf("Validated %s %s@%s\n", desc.MediaType, path, desc.Digest); err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		return h.printer.Println("No issues found")
+	}
+	if err := h.printer.Printf("Found %d issue(s):\n", len(issues)); err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if err := h.printer.Printf("- %s\n", issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render implements metadata.ManifestValidateHandler.
+func (h *manifestValidateHandler) Render() error {
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/validate"
+)
+
+func TestManifestValidateHandler_OnValidated(t *testing.T) {
+	desc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"}
+	tests := []struct {
+		name   string
+		file   string
+		issues []validate.Issue
+		want   string
+	}{
+		{
+			name: "valid reference",
+			want: `Validated application/vnd.oci.image.manifest.v1+json localhost:5000/hello@sha256:1111111111111111111111111111111111111111111111111111111111111111
+No issues found
+`,
+		},
+		{
+			name: "invalid file",
+			file: "manifest.json",
+			issues: []validate.Issue{
+				{Path: "$.config.digest", Message: "invalid digest"},
+				{Path: "$.layers[0]", Message: "referenced content does not exist"},
+			},
+			want: `Validated application/vnd.oci.image.manifest.v1+json "manifest.json" against localhost:5000/hello
+Found 2 issue(s):
+- $.config.digest: invalid digest
+- $.layers[0]: referenced content does not exist
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewManifestValidateHandler(output.NewPrinter(buf, os.Stderr))
+			if err := h.OnValidated("localhost:5000/hello", desc, tt.file, tt.issues); err != nil {
+				t.Fatalf("OnValidated() error = %v", err)
+			}
+			if err := h.Render(); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("OnValidated() output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
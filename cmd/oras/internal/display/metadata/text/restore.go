@@ -25,6 +25,7 @@ import (
 
 // RestoreHandler handles text metadata output for restore command.
 type RestoreHandler struct {
+	conflictHandler
 	printer *output.Printer
 	dryRun  bool
 }
@@ -32,8 +33,9 @@ type RestoreHandler struct {
 // NewRestoreHandler creates a new RestoreHandler.
 func NewRestoreHandler(printer *output.Printer, dryRun bool) *RestoreHandler {
 	return &RestoreHandler{
-		printer: printer,
-		dryRun:  dryRun,
+		conflictHandler: conflictHandler{printer: printer, dryRun: dryRun},
+		printer:         printer,
+		dryRun:          dryRun,
 	}
 }
 
@@ -73,6 +75,9 @@ func (rh *RestoreHandler) OnArtifactPushed(tag string, referrerCount int) error
 
 // OnRestoreCompleted implements metadata.RestoreHandler.
 func (rh *RestoreHandler) OnRestoreCompleted(tagsCount int, repo string, duration time.Duration) error {
+	if err := rh.printOverwritten(); err != nil {
+		return err
+	}
 	if rh.dryRun {
 		return rh.printer.Printf("Dry run complete: %d tag(s) would be restored to %q (no data pushed)\n", tagsCount, repo)
 	}
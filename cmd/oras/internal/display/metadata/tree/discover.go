@@ -26,6 +26,7 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"go.yaml.in/yaml/v4"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/internal/platform"
 	"oras.land/oras/internal/tree"
 )
 
@@ -70,39 +71,129 @@ func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) err
 	if !ok {
 		return fmt.Errorf("unexpected subject descriptor: %v", subject)
 	}
-
-	// add artifact type and digest to the referrer
 	artifactType := referrer.ArtifactType
 	if artifactType == "" {
 		artifactType = "<unknown>"
 	}
-	dgst := referrer.Digest.String()
-	if h.tty != nil {
-		artifactType = artifactTypeColor.Apply(artifactType)
+	referrerNode, err := addNode(node, artifactType, referrer, h.verbose, h.tty)
+	if err != nil {
+		return err
+	}
+	h.nodes[referrer.Digest] = referrerNode
+	return nil
+}
+
+// OnChildDiscovered implements metadata.DiscoverChildHandler.
+func (h *discoverHandler) OnChildDiscovered(child, index ocispec.Descriptor) error {
+	node, ok := h.nodes[index.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected index descriptor: %v", index)
+	}
+	title := "[manifest]"
+	if child.Platform != nil {
+		title = "[platform] " + platform.String(child.Platform)
+	}
+	childNode, err := addNode(node, title, child, h.verbose, h.tty)
+	if err != nil {
+		return err
+	}
+	h.nodes[child.Digest] = childNode
+	return nil
+}
+
+// Render implements metadata.DiscoverHandler.
+func (h *discoverHandler) Render() error {
+	return tree.NewPrinter(h.out).Print(h.root)
+}
+
+// discoverSubjectHandler handles tree metadata output for discover events
+// walking up the subject chain.
+type discoverSubjectHandler struct {
+	out     io.Writer
+	root    *tree.Node
+	nodes   map[digest.Digest]*tree.Node
+	verbose bool
+	tty     *os.File
+}
+
+// NewDiscoverSubjectHandler creates a new handler for discover events walking
+// up the subject chain.
+func NewDiscoverSubjectHandler(out io.Writer, path string, referrer ocispec.Descriptor, verbose bool, tty *os.File) metadata.DiscoverSubjectHandler {
+	rootDigest := fmt.Sprintf("%s@%s", path, referrer.Digest)
+	if tty != nil {
+		rootDigest = digestColor.Apply(rootDigest)
+	}
+	treeRoot := tree.New(rootDigest)
+	return &discoverSubjectHandler{
+		out:  out,
+		root: treeRoot,
+		nodes: map[digest.Digest]*tree.Node{
+			referrer.Digest: treeRoot,
+		},
+		verbose: verbose,
+		tty:     tty,
+	}
+}
+
+// OnSubjectDiscovered implements metadata.DiscoverSubjectHandler.
+func (h *discoverSubjectHandler) OnSubjectDiscovered(subject, referrer ocispec.Descriptor) error {
+	node, ok := h.nodes[referrer.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected referrer descriptor: %v", referrer)
+	}
+	// subjects such as images may have no artifact type
+	artifactType := subject.ArtifactType
+	if artifactType == "" {
+		artifactType = subject.MediaType
+	}
+	subjectNode, err := addNode(node, artifactType, subject, h.verbose, h.tty)
+	if err != nil {
+		return err
+	}
+	h.nodes[subject.Digest] = subjectNode
+	return nil
+}
+
+// OnIndexDiscovered implements metadata.DiscoverSubjectHandler.
+func (h *discoverSubjectHandler) OnIndexDiscovered(index ocispec.Descriptor, tags []string, manifest ocispec.Descriptor) error {
+	node, ok := h.nodes[manifest.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected manifest descriptor: %v", manifest)
+	}
+	title := "[index] " + strings.Join(tags, ", ")
+	_, err := addNode(node, title, index, h.verbose, h.tty)
+	return err
+}
+
+// Render implements metadata.DiscoverSubjectHandler.
+func (h *discoverSubjectHandler) Render() error {
+	return tree.NewPrinter(h.out).Print(h.root)
+}
+
+// addNode adds the descriptor to the parent node as a path of the title and
+// the digest, along with its annotations if verbose.
+func addNode(parent *tree.Node, title string, desc ocispec.Descriptor, verbose bool, tty *os.File) (*tree.Node, error) {
+	dgst := desc.Digest.String()
+	if tty != nil {
+		title = artifactTypeColor.Apply(title)
 		dgst = digestColor.Apply(dgst)
 	}
-	referrerNode := node.AddPath(artifactType, dgst)
+	node := parent.AddPath(title, dgst)
 
-	// add annotations to the referrer
-	if h.verbose && len(referrer.Annotations) > 0 {
+	// add annotations to the node
+	if verbose && len(desc.Annotations) > 0 {
 		annotationsTitle := "[annotations]"
-		if h.tty != nil {
+		if tty != nil {
 			annotationsTitle = annotationsColor.Apply(annotationsTitle)
 		}
-		annotationsNode := referrerNode.Add(annotationsTitle)
-		for k, v := range referrer.Annotations {
+		annotationsNode := node.Add(annotationsTitle)
+		for k, v := range desc.Annotations {
 			bytes, err := yaml.Marshal(map[string]string{k: v})
 			if err != nil {
-				return err
+				return nil, err
 			}
 			annotationsNode.AddPath(strings.TrimSpace(string(bytes)))
 		}
 	}
-	h.nodes[referrer.Digest] = referrerNode
-	return nil
-}
-
-// Render implements metadata.DiscoverHandler.
-func (h *discoverHandler) Render() error {
-	return tree.NewPrinter(h.out).Print(h.root)
+	return node, nil
 }
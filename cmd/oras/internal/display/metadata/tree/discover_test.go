@@ -124,3 +124,104 @@ func TestDiscoverHandler_OnDiscovered(t *testing.T) {
 		}
 	})
 }
+
+func TestDiscoverSubjectHandler(t *testing.T) {
+	path := "localhost:5000/test"
+	signatureDesc := ocispec.Descriptor{
+		MediaType:    "application/vnd.oci.image.manifest.v1+json",
+		Digest:       "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d",
+		Size:         660,
+		ArtifactType: "test/signature",
+	}
+	imageDesc := ocispec.Descriptor{
+		MediaType: "application/vnd.oci.image.manifest.v1+json",
+		Digest:    "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2",
+		Size:      529,
+	}
+	indexDesc := ocispec.Descriptor{
+		MediaType: "application/vnd.oci.image.index.v1+json",
+		Digest:    "sha256:1b5d58b6e7f5e1b3d7c5a4f3e2d1c0b9a8f7e6d5c4b3a2918f7e6d5c4b3a2918",
+		Size:      300,
+	}
+
+	var buf bytes.Buffer
+	h := NewDiscoverSubjectHandler(&buf, path, signatureDesc, true, nil)
+	if err := h.OnSubjectDiscovered(imageDesc, signatureDesc); err != nil {
+		t.Fatalf("OnSubjectDiscovered() error = %v", err)
+	}
+	if err := h.OnIndexDiscovered(indexDesc, []string{"v1", "latest"}, imageDesc); err != nil {
+		t.Fatalf("OnIndexDiscovered() error = %v", err)
+	}
+	if err := h.OnSubjectDiscovered(imageDesc, indexDesc); err == nil {
+		t.Fatal("OnSubjectDiscovered() expects error for an unexpected referrer")
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := fmt.Sprintf(`%s@%s
+└── %s
+    └── %s
+        └── [index] v1, latest
+            └── %s
+`, path, signatureDesc.Digest, imageDesc.MediaType, imageDesc.Digest, indexDesc.Digest)
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestDiscoverHandler_OnChildDiscovered(t *testing.T) {
+	path := "localhost:5000/test"
+	indexDesc := ocispec.Descriptor{
+		MediaType: "application/vnd.oci.image.index.v1+json",
+		Digest:    "sha256:1b5d58b6e7f5e1b3d7c5a4f3e2d1c0b9a8f7e6d5c4b3a2918f7e6d5c4b3a2918",
+		Size:      300,
+	}
+	imageDesc := ocispec.Descriptor{
+		MediaType: "application/vnd.oci.image.manifest.v1+json",
+		Digest:    "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2",
+		Size:      529,
+		Platform:  &ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+	}
+	unknownDesc := ocispec.Descriptor{
+		MediaType: "application/vnd.oci.image.manifest.v1+json",
+		Digest:    "sha256:4f5c5b07d58a1d1c2a8f0c4ab0de1b4d7b4c1a0e5f1bc9e5d7a3c4f1e2b3a4c5",
+		Size:      529,
+	}
+	signatureDesc := ocispec.Descriptor{
+		MediaType:    "application/vnd.oci.image.manifest.v1+json",
+		Digest:       "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d",
+		Size:         660,
+		ArtifactType: "test/signature",
+	}
+
+	var buf bytes.Buffer
+	h := NewDiscoverHandler(&buf, path, indexDesc, true, nil).(*discoverHandler)
+	if err := h.OnChildDiscovered(imageDesc, indexDesc); err != nil {
+		t.Fatalf("OnChildDiscovered() error = %v", err)
+	}
+	if err := h.OnDiscovered(signatureDesc, imageDesc); err != nil {
+		t.Fatalf("OnDiscovered() error = %v", err)
+	}
+	if err := h.OnChildDiscovered(unknownDesc, indexDesc); err != nil {
+		t.Fatalf("OnChildDiscovered() error = %v", err)
+	}
+	if err := h.OnChildDiscovered(imageDesc, ocispec.Descriptor{Digest: digest.FromString("missing")}); err == nil {
+		t.Fatal("OnChildDiscovered() expects error for an unexpected index")
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := fmt.Sprintf(`%s@%s
+├── [platform] linux/arm64/v8
+│   └── %s
+│       └── test/signature
+│           └── %s
+└── [manifest]
+    └── %s
+`, path, indexDesc.Digest, imageDesc.Digest, signatureDesc.Digest, unknownDesc.Digest)
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tree
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/internal/platform"
+	"oras.land/oras/internal/tree"
+)
+
+// manifestExportHandler handles tree metadata output for recursive manifest
+// fetch events.
+type manifestExportHandler struct {
+	out       io.Writer
+	outputDir string
+	root      *tree.Node
+	nodes     map[digest.Digest]*tree.Node
+	files     map[string]bool
+}
+
+// NewManifestExportHandler creates a new handler for recursive manifest fetch
+// events, printing the exported files as a tree.
+func NewManifestExportHandler(out io.Writer, outputDir string) metadata.ManifestExportHandler {
+	return &manifestExportHandler{
+		out:       out,
+		outputDir: outputDir,
+		nodes:     make(map[digest.Digest]*tree.Node),
+		files:     make(map[string]bool),
+	}
+}
+
+// OnRootExported implements metadata.ManifestExportHandler.
+func (h *manifestExportHandler) OnRootExported(path string, root ocispec.Descriptor, file string) error {
+	h.root = tree.New(fmt.Sprintf("%s@%s -> %s", path, root.Digest, file))
+	h.nodes[root.Digest] = h.root
+	h.files[file] = true
+	return nil
+}
+
+// OnExported implements metadata.ManifestExportHandler.
+func (h *manifestExportHandler) OnExported(node ocispec.Descriptor, parent ocispec.Descriptor, relation string, file string) error {
+	parentNode, ok := h.nodes[parent.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected parent descriptor: %v", parent)
+	}
+	var title string
+	switch relation {
+	case metadata.RelationManifest:
+		title = "[manifest]"
+		if node.Platform != nil {
+			title = "[" + platform.String(node.Platform) + "]"
+		}
+	case metadata.RelationConfig:
+		title = "[config]"
+	case metadata.RelationReferrer:
+		artifactType := node.ArtifactType
+		if artifactType == "" {
+			artifactType = "<unknown>"
+		}
+		title = "[referrer] " + artifactType
+	default:
+		return fmt.Errorf("unknown relation: %q", relation)
+	}
+	child := parentNode.Add(fmt.Sprintf("%s %s", title, file))
+	if _, ok := h.nodes[node.Digest]; !ok {
+		h.nodes[node.Digest] = child
+	}
+	h.files[file] = true
+	return nil
+}
+
+// Render implements metadata.ManifestExportHandler.
+func (h *manifestExportHandler) Render() error {
+	if err := tree.NewPrinter(h.out).Print(h.root); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(h.out, "Exported %d file(s) to %s\n", len(h.files), h.outputDir)
+	return err
+}
@@ -0,0 +1,75 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tree
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+)
+
+func TestManifestExportHandler(t *testing.T) {
+	index := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex, Digest: digest.FromString("index")}
+	amd64 := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("amd64"),
+		Platform:  &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+	}
+	unknown := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("unknown")}
+	config := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig, Digest: digest.FromString("config")}
+	sig := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("sig"), ArtifactType: "test/sig"}
+
+	out := &bytes.Buffer{}
+	h := NewManifestExportHandler(out, "out")
+	if err := h.OnRootExported("localhost:5000/hello", index, "index.json"); err != nil {
+		t.Fatalf("OnRootExported() error = %v", err)
+	}
+	for _, e := range []struct {
+		node     ocispec.Descriptor
+		parent   ocispec.Descriptor
+		relation string
+		file     string
+	}{
+		{amd64, index, metadata.RelationManifest, "amd64.json"},
+		{config, amd64, metadata.RelationConfig, "config.json"},
+		{sig, amd64, metadata.RelationReferrer, "sig.json"},
+		{unknown, index, metadata.RelationManifest, "unknown.json"},
+		{config, unknown, metadata.RelationConfig, "config.json"},
+	} {
+		if err := h.OnExported(e.node, e.parent, e.relation, e.file); err != nil {
+			t.Fatalf("OnExported() error = %v", err)
+		}
+	}
+	if err := h.OnExported(config, ocispec.Descriptor{Digest: digest.FromString("missing")}, metadata.RelationConfig, "config.json"); err == nil {
+		t.Error("OnExported() error = nil, want error for unknown parent")
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `localhost:5000/hello@` + index.Digest.String() + ` -> index.json
+├── [linux/amd64] amd64.json
+│   ├── [config] config.json
+│   └── [referrer] test/sig sig.json
+└── [manifest] unknown.json
+    └── [config] config.json
+Exported 5 file(s) to out
+`
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
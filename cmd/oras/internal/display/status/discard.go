@@ -115,6 +115,21 @@ func (DiscardHandler) OnManifestPushed() error {
 	return nil
 }
 
+// OnContentExists implements ManifestPushHandler.
+func (DiscardHandler) OnContentExists(ocispec.Descriptor) error {
+	return nil
+}
+
+// OnContentUploading implements ManifestPushHandler.
+func (DiscardHandler) OnContentUploading(ocispec.Descriptor) error {
+	return nil
+}
+
+// OnContentUploaded implements ManifestPushHandler.
+func (DiscardHandler) OnContentUploaded(ocispec.Descriptor) error {
+	return nil
+}
+
 // OnManifestRemoved implements ManifestIndexUpdateHandler.
 func (DiscardHandler) OnManifestRemoved(digest.Digest) error {
 	return nil
@@ -125,6 +140,11 @@ func (DiscardHandler) OnManifestAdded(string, ocispec.Descriptor) error {
 	return nil
 }
 
+// OnManifestReplaced implements ManifestIndexCreateHandler.
+func (DiscardHandler) OnManifestReplaced(string, ocispec.Descriptor, ocispec.Descriptor) error {
+	return nil
+}
+
 // OnIndexMerged implements ManifestIndexUpdateHandler.
 func (DiscardHandler) OnIndexMerged(string, ocispec.Descriptor) error {
 	return nil
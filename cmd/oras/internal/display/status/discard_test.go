@@ -35,6 +35,13 @@ func TestDiscardHandler_OnManifestRemoved(t *testing.T) {
 	}
 }
 
+func TestDiscardHandler_OnManifestReplaced(t *testing.T) {
+	testDiscard := NewDiscardHandler()
+	if err := testDiscard.OnManifestReplaced("test", v1.Descriptor{}, v1.Descriptor{}); err != nil {
+		t.Errorf("DiscardHandler.OnManifestReplaced() error = %v, wantErr nil", err)
+	}
+}
+
 func TestDiscardHandler_OnIndexMerged(t *testing.T) {
 	testDiscard := NewDiscardHandler()
 	if err := testDiscard.OnIndexMerged("test", v1.Descriptor{}); err != nil {
@@ -89,12 +89,16 @@ type ManifestPushHandler interface {
 	OnManifestPushSkipped() error
 	OnManifestPushing() error
 	OnManifestPushed() error
+	OnContentExists(desc ocispec.Descriptor) error
+	OnContentUploading(desc ocispec.Descriptor) error
+	OnContentUploaded(desc ocispec.Descriptor) error
 }
 
 // ManifestIndexCreateHandler handles status output for manifest index create command.
 type ManifestIndexCreateHandler interface {
 	OnFetching(manifestRef string) error
 	OnFetched(manifestRef string, desc ocispec.Descriptor) error
+	OnManifestReplaced(manifestRef string, old ocispec.Descriptor, desc ocispec.Descriptor) error
 	OnIndexPacked(desc ocispec.Descriptor) error
 	OnIndexPushed(path string) error
 }
@@ -22,6 +22,7 @@ import (
 	"oras.land/oras/internal/contentutil"
 	"oras.land/oras/internal/descriptor"
 	"oras.land/oras/internal/graph"
+	"oras.land/oras/internal/platform"
 
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -323,6 +324,21 @@ func (mph *TextManifestPushHandler) OnManifestPushed() error {
 	return mph.printer.PrintStatus(mph.desc, PushPromptUploaded)
 }
 
+// OnContentExists implements ManifestPushHandler.
+func (mph *TextManifestPushHandler) OnContentExists(desc ocispec.Descriptor) error {
+	return mph.printer.PrintStatus(desc, PushPromptExists)
+}
+
+// OnContentUploading implements ManifestPushHandler.
+func (mph *TextManifestPushHandler) OnContentUploading(desc ocispec.Descriptor) error {
+	return mph.printer.PrintStatus(desc, PushPromptUploading)
+}
+
+// OnContentUploaded implements ManifestPushHandler.
+func (mph *TextManifestPushHandler) OnContentUploaded(desc ocispec.Descriptor) error {
+	return mph.printer.PrintStatus(desc, PushPromptUploaded)
+}
+
 // TextManifestIndexCreateHandler handles text status output for manifest index create events.
 type TextManifestIndexCreateHandler struct {
 	printer *output.Printer
@@ -349,6 +365,11 @@ func (mich *TextManifestIndexCreateHandler) OnFetched(ref string, desc ocispec.D
 	return mich.printer.Println(IndexPromptFetched, desc.Digest, ref)
 }
 
+// OnManifestReplaced implements ManifestIndexCreateHandler.
+func (mich *TextManifestIndexCreateHandler) OnManifestReplaced(ref string, old ocispec.Descriptor, desc ocispec.Descriptor) error {
+	return printReplaced(mich.printer, ref, old, desc)
+}
+
 // OnIndexPacked implements ManifestIndexCreateHandler.
 func (mich *TextManifestIndexCreateHandler) OnIndexPacked(desc ocispec.Descriptor) error {
 	return mich.printer.Println(IndexPromptPacked, descriptor.ShortDigest(desc), ocispec.MediaTypeImageIndex)
@@ -398,6 +419,11 @@ func (miuh *TextManifestIndexUpdateHandler) OnManifestAdded(ref string, desc oci
 	return miuh.printer.Println(IndexPromptAdded, desc.Digest, ref)
 }
 
+// OnManifestReplaced implements ManifestIndexUpdateHandler.
+func (miuh *TextManifestIndexUpdateHandler) OnManifestReplaced(ref string, old ocispec.Descriptor, desc ocispec.Descriptor) error {
+	return printReplaced(miuh.printer, ref, old, desc)
+}
+
 // OnIndexMerged implements ManifestIndexUpdateHandler.
 func (miuh *TextManifestIndexUpdateHandler) OnIndexMerged(ref string, desc ocispec.Descriptor) error {
 	if contentutil.IsDigest(ref) {
@@ -416,6 +442,19 @@ func (miuh *TextManifestIndexUpdateHandler) OnIndexPushed(indexRef string) error
 	return miuh.printer.Println(IndexPromptPushed, indexRef)
 }
 
+// printReplaced prints that the manifest old in an index is replaced with the
+// manifest desc, which is resolved from ref.
+func printReplaced(printer *output.Printer, ref string, old ocispec.Descriptor, desc ocispec.Descriptor) error {
+	args := []any{IndexPromptReplaced, old.Digest, "with", desc.Digest}
+	if !contentutil.IsDigest(ref) {
+		args = append(args, ref)
+	}
+	if desc.Platform != nil {
+		args = append(args, "for", platform.String(desc.Platform))
+	}
+	return printer.Println(args...)
+}
+
 // TextBlobPushHandler handles text status output for blob push events.
 type TextBlobPushHandler struct {
 	desc    ocispec.Descriptor
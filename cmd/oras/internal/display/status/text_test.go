@@ -273,6 +273,51 @@ func TestTextManifestIndexUpdateHandler_OnIndexMerged(t *testing.T) {
 	}
 }
 
+func TestTextManifestIndexUpdateHandler_OnManifestReplaced(t *testing.T) {
+	old := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:58efe73e78fe043ca31b89007a025c594ce12aa7e6da27d21c7b14b50112e255", Size: 16}
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:fd6ed2f36b5465244d5dc86cb4e7df0ab8a9d24adc57825099f522fe009a22bb",
+		Size:      25,
+		Platform:  &ocispec.Platform{OS: "linux", Architecture: "arm64"},
+	}
+	tests := []struct {
+		name string
+		ref  string
+		desc ocispec.Descriptor
+		want string
+	}{
+		{
+			name: "ref is a digest",
+			ref:  string(desc.Digest),
+			desc: desc,
+			want: "Replaced  sha256:58efe73e78fe043ca31b89007a025c594ce12aa7e6da27d21c7b14b50112e255 with sha256:fd6ed2f36b5465244d5dc86cb4e7df0ab8a9d24adc57825099f522fe009a22bb for linux/arm64",
+		},
+		{
+			name: "ref is not a digest",
+			ref:  "v1",
+			desc: desc,
+			want: "Replaced  sha256:58efe73e78fe043ca31b89007a025c594ce12aa7e6da27d21c7b14b50112e255 with sha256:fd6ed2f36b5465244d5dc86cb4e7df0ab8a9d24adc57825099f522fe009a22bb v1 for linux/arm64",
+		},
+		{
+			name: "no platform",
+			ref:  "v1",
+			desc: ocispec.Descriptor{MediaType: desc.MediaType, Digest: desc.Digest, Size: desc.Size},
+			want: "Replaced  sha256:58efe73e78fe043ca31b89007a025c594ce12aa7e6da27d21c7b14b50112e255 with sha256:fd6ed2f36b5465244d5dc86cb4e7df0ab8a9d24adc57825099f522fe009a22bb v1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder.Reset()
+			miuh := NewTextManifestIndexUpdateHandler(printer)
+			if err := miuh.OnManifestReplaced(tt.ref, old, tt.desc); err != nil {
+				t.Fatalf("TextManifestIndexUpdateHandler.OnManifestReplaced() error = %v", err)
+			}
+			validatePrinted(t, tt.want)
+		})
+	}
+}
+
 func TestTextBackupHandler(t *testing.T) {
 	t.Run("OnCopySkipped", func(t *testing.T) {
 		builder.Reset()
@@ -70,6 +70,7 @@ const (
 	IndexPromptAdded    = "Added    "
 	IndexPromptMerged   = "Merged   "
 	IndexPromptRemoved  = "Removed  "
+	IndexPromptReplaced = "Replaced "
 	IndexPromptPacked   = "Packed   "
 	IndexPromptPushed   = "Pushed   "
 	IndexPromptUpdated  = "Updated  "
@@ -0,0 +1,119 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/sync/errgroup"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/internal/docker"
+	"oras.land/oras/internal/graph"
+)
+
+// mediaTypeForeignLayer is the media type of docker layers which are not
+// uploaded to registries.
+const mediaTypeForeignLayer = "application/vnd.docker.image.rootfs.foreign.diff.tar.gzip"
+
+// References returns the descriptors of the config, layers, blobs or
+// manifests referenced by the content of a manifest or an index of the given
+// media type, which are required to exist before the manifest is pushed.
+// The subject and non-distributable layers are excluded. Nil is returned for
+// unknown media types.
+func References(mediaType string, contentBytes []byte) ([]ocispec.Descriptor, error) {
+	switch mediaType {
+	case ocispec.MediaTypeImageManifest, docker.MediaTypeManifest,
+		ocispec.MediaTypeImageIndex, docker.MediaTypeManifestList,
+		graph.MediaTypeArtifactManifest:
+	default:
+		return nil, nil
+	}
+	var manifest struct {
+		Config    *ocispec.Descriptor  `json:"config"`
+		Layers    []ocispec.Descriptor `json:"layers"`
+		Blobs     []ocispec.Descriptor `json:"blobs"`
+		Manifests []ocispec.Descriptor `json:"manifests"`
+	}
+	if err := json.Unmarshal(contentBytes, &manifest); err != nil {
+		return nil, ErrInvalidJSON
+	}
+	var refs []ocispec.Descriptor
+	if manifest.Config != nil {
+		refs = append(refs, *manifest.Config)
+	}
+	for _, layer := range manifest.Layers {
+		if !isNonDistributable(layer.MediaType) {
+			refs = append(refs, layer)
+		}
+	}
+	refs = append(refs, manifest.Blobs...)
+	refs = append(refs, manifest.Manifests...)
+	return refs, nil
+}
+
+// isNonDistributable returns true if the layer of mediaType is not expected
+// to be uploaded to registries.
+func isNonDistributable(mediaType string) bool {
+	return mediaType == mediaTypeForeignLayer ||
+		strings.HasPrefix(mediaType, "application/vnd.oci.image.layer.nondistributable.")
+}
+
+// FindMissing checks concurrently whether the content of descs exists in
+// storage, and returns the missing descriptors in the order of descs.
+func FindMissing(ctx context.Context, storage content.ReadOnlyStorage, descs []ocispec.Descriptor, concurrency int) ([]ocispec.Descriptor, error) {
+	exists := make([]bool, len(descs))
+	eg, egCtx := errgroup.WithContext(ctx)
+	if concurrency > 0 {
+		eg.SetLimit(concurrency)
+	}
+	for i, desc := range descs {
+		eg.Go(func() error {
+			ok, err := storage.Exists(egCtx, desc)
+			if err != nil {
+				return fmt.Errorf("failed to check the existence of %s: %w", desc.Digest, err)
+			}
+			exists[i] = ok
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	var missing []ocispec.Descriptor
+	for i, desc := range descs {
+		if !exists[i] {
+			missing = append(missing, desc)
+		}
+	}
+	return missing, nil
+}
+
+// MissingContentError is returned when the content referenced by a manifest
+// does not exist in the target.
+type MissingContentError []ocispec.Descriptor
+
+// Error implements the error interface.
+func (e MissingContentError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d referenced content(s) not found in the target:", len(e))
+	for _, desc := range e {
+		fmt.Fprintf(&sb, "\n  %s %s", desc.Digest, desc.MediaType)
+	}
+	return sb.String()
+}
@@ -0,0 +1,131 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestReferences(t *testing.T) {
+	config := `{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2}`
+	layer := `{"mediaType":"application/vnd.oci.image.layer.v1.tar","digest":"sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824","size":5}`
+	foreign := `{"mediaType":"application/vnd.docker.image.rootfs.foreign.diff.tar.gzip","digest":"sha256:b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c","size":4}`
+	manifest := `{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c","size":4}`
+	tests := []struct {
+		name      string
+		mediaType string
+		content   string
+		want      []digest.Digest
+		wantErr   bool
+	}{
+		{
+			name:      "image manifest",
+			mediaType: ocispec.MediaTypeImageManifest,
+			content:   `{"config":` + config + `,"layers":[` + layer + `,` + foreign + `],"subject":` + manifest + `}`,
+			want:      []digest.Digest{"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		},
+		{
+			name:      "image index",
+			mediaType: ocispec.MediaTypeImageIndex,
+			content:   `{"manifests":[` + manifest + `]}`,
+			want:      []digest.Digest{"sha256:b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c"},
+		},
+		{
+			name:      "artifact manifest",
+			mediaType: "application/vnd.oci.artifact.manifest.v1+json",
+			content:   `{"blobs":[` + layer + `]}`,
+			want:      []digest.Digest{"sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		},
+		{
+			name:      "unknown media type",
+			mediaType: "application/vnd.unknown",
+			content:   `not JSON`,
+		},
+		{
+			name:      "invalid JSON",
+			mediaType: ocispec.MediaTypeImageManifest,
+			content:   `not JSON`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs, err := References(tt.mediaType, []byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("References() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []digest.Digest
+			for _, ref := range refs {
+				got = append(got, ref.Digest)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("References() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindMissing(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	blob := func(content string) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType: "application/octet-stream",
+			Digest:    digest.FromString(content),
+			Size:      int64(len(content)),
+		}
+	}
+	foo, bar, baz := blob("foo"), blob("bar"), blob("baz")
+	if err := store.Push(ctx, bar, bytes.NewReader([]byte("bar"))); err != nil {
+		t.Fatal(err)
+	}
+	got, err := FindMissing(ctx, store, []ocispec.Descriptor{foo, bar, baz}, 2)
+	if err != nil {
+		t.Fatalf("FindMissing() error = %v", err)
+	}
+	if want := []ocispec.Descriptor{foo, baz}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindMissing() = %v, want %v", got, want)
+	}
+
+	_, err = FindMissing(ctx, &errorStorage{}, []ocispec.Descriptor{foo}, 1)
+	if err == nil || !strings.Contains(err.Error(), foo.Digest.String()) {
+		t.Errorf("FindMissing() error = %v, want an error of %s", err, foo.Digest)
+	}
+}
+
+type errorStorage struct {
+	memory.Store
+}
+
+func (*errorStorage) Exists(context.Context, ocispec.Descriptor) (bool, error) {
+	return false, errors.New("exists error")
+}
+
+func TestMissingContentError(t *testing.T) {
+	err := MissingContentError{{MediaType: "application/vnd.oci.image.config.v1+json", Digest: "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"}}
+	want := "1 referenced content(s) not found in the target:\n  sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a application/vnd.oci.image.config.v1+json"
+	if got := err.Error(); got != want {
+		t.Errorf("MissingContentError.Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+
+	"oras.land/oras-go/v2/content"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/validate"
+)
+
+// Validate validates the content of a manifest before it is pushed to
+// storage, returning an error listing the issues if any is found.
+func Validate(ctx context.Context, storage content.ReadOnlyStorage, mediaType string, contentBytes []byte) error {
+	issues, err := validate.Manifest(ctx, storage, mediaType, contentBytes)
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		return &oerrors.Error{
+			Err:            validate.IssuesError(issues),
+			Recommendation: `Fix the issues above, or push without the "--validate" flag`,
+		}
+	}
+	return nil
+}
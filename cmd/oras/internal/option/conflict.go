@@ -0,0 +1,53 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package option
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Policies applied when a destination tag already points to a different
+// artifact.
+const (
+	// ConflictPolicyOverwrite moves the conflicting tag to the new artifact.
+	ConflictPolicyOverwrite = "overwrite"
+	// ConflictPolicySkip skips the artifact with a conflicting tag.
+	ConflictPolicySkip = "skip"
+	// ConflictPolicyFail fails before copying anything if any tag conflicts.
+	ConflictPolicyFail = "fail"
+)
+
+// Conflict option struct.
+type Conflict struct {
+	OnConflict string
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Conflict) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&opts.OnConflict, "on-conflict", "", ConflictPolicyOverwrite, "policy when a destination tag already points to a different artifact, options: overwrite, skip, fail")
+}
+
+// Parse validates the conflict policy.
+func (opts *Conflict) Parse(*cobra.Command) error {
+	switch opts.OnConflict {
+	case ConflictPolicyOverwrite, ConflictPolicySkip, ConflictPolicyFail:
+		return nil
+	default:
+		return fmt.Errorf("invalid value %q for --on-conflict, supported values are %s, %s and %s", opts.OnConflict, ConflictPolicyOverwrite, ConflictPolicySkip, ConflictPolicyFail)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package option
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestConflict_ApplyFlags(t *testing.T) {
+	var test struct{ Conflict }
+	ApplyFlags(&test, pflag.NewFlagSet("oras-test", pflag.ExitOnError))
+	if test.OnConflict != ConflictPolicyOverwrite {
+		t.Fatalf("expecting OnConflict to be %q but got: %q", ConflictPolicyOverwrite, test.OnConflict)
+	}
+}
+
+func TestConflict_Parse(t *testing.T) {
+	tests := []struct {
+		policy  string
+		wantErr bool
+	}{
+		{policy: ConflictPolicyOverwrite},
+		{policy: ConflictPolicySkip},
+		{policy: ConflictPolicyFail},
+		{policy: "ignore", wantErr: true},
+		{policy: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			opts := &Conflict{OnConflict: tt.policy}
+			if err := opts.Parse(nil); (err != nil) != tt.wantErr {
+				t.Errorf("Conflict.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
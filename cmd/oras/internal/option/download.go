@@ -0,0 +1,67 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package option
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/internal/registryutil"
+)
+
+const (
+	// defaultDownloadParts is the default number of concurrent ranged requests
+	// to download a large blob. Downloading in parts is disabled by default
+	// while the feature is in preview.
+	defaultDownloadParts = 1
+	// defaultDownloadThreshold is the default minimum size of a blob to be
+	// downloaded in parts.
+	defaultDownloadThreshold = 64 * 1024 * 1024 // 64 MiB
+)
+
+// Download option struct.
+type Download struct {
+	Parts     int
+	Threshold int64
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Download) ApplyFlags(fs *pflag.FlagSet) {
+	fs.IntVarP(&opts.Parts, "download-parts", "", defaultDownloadParts, "[Preview] number of concurrent ranged requests to download a large blob, 1 to download in a single request")
+	fs.Int64VarP(&opts.Threshold, "download-threshold", "", defaultDownloadThreshold, "[Preview] minimum size in bytes of a blob to be downloaded in parts")
+}
+
+// Parse validates the download flags.
+func (opts *Download) Parse(*cobra.Command) error {
+	if opts.Parts < 1 {
+		return fmt.Errorf("invalid number of download parts %d: must be positive", opts.Parts)
+	}
+	if opts.Threshold < 0 {
+		return fmt.Errorf("invalid download threshold %d: must not be negative", opts.Threshold)
+	}
+	return nil
+}
+
+// ParallelTarget returns a target downloading the large blobs of repo in
+// parts, or src if downloading in parts is disabled.
+func (opts *Download) ParallelTarget(src oras.ReadOnlyTarget, repo *remote.Repository) oras.ReadOnlyTarget {
+	if opts.Parts <= 1 {
+		return src
+	}
+	return registryutil.NewParallelTarget(src, repo, opts.Parts, opts.Threshold)
+}
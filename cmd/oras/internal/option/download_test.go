@@ -0,0 +1,58 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package option
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestDownload_ApplyFlags(t *testing.T) {
+	var test struct{ Download }
+	ApplyFlags(&test, pflag.NewFlagSet("oras-test", pflag.ExitOnError))
+	if test.Parts != defaultDownloadParts {
+		t.Fatalf("expecting parts to be %d but got: %d", defaultDownloadParts, test.Parts)
+	}
+	if test.Threshold != defaultDownloadThreshold {
+		t.Fatalf("expecting threshold to be %d but got: %d", defaultDownloadThreshold, test.Threshold)
+	}
+	// downloading in parts is opt-in
+	src := memory.New()
+	if got := test.ParallelTarget(src, nil); got != src {
+		t.Fatalf("expecting the source target to be used by default but got: %T", got)
+	}
+}
+
+func TestDownload_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Download
+		wantErr bool
+	}{
+		{name: "valid", opts: Download{Parts: 4, Threshold: 1024}},
+		{name: "disabled", opts: Download{Parts: 1}},
+		{name: "invalid parts", opts: Download{Parts: 0}, wantErr: true},
+		{name: "invalid threshold", opts: Download{Parts: 4, Threshold: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Parse(nil); (err != nil) != tt.wantErr {
+				t.Errorf("Download.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+const (
+	encryptRecipientFlag       = "encrypt-recipient"
+	encryptPassphraseStdinFlag = "encrypt-passphrase-stdin"
+	decryptIdentityFlag        = "decrypt-identity"
+	decryptPassphraseStdinFlag = "decrypt-passphrase-stdin"
+)
+
+// Encryption option struct.
+type Encryption struct {
+	RecipientFiles      []string
+	PassphraseFromStdin bool
+
+	// Recipients are the parsed recipients to encrypt to, empty if the
+	// encryption is not enabled.
+	Recipients []age.Recipient
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Encryption) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVarP(&opts.RecipientFiles, encryptRecipientFlag, "", nil, "encrypt to the age public keys in the `file`, one key per line, can be specified multiple times")
+	fs.BoolVarP(&opts.PassphraseFromStdin, encryptPassphraseStdinFlag, "", false, "encrypt with a passphrase read from stdin")
+}
+
+// Parse reads the recipients from the files or the passphrase from stdin.
+func (opts *Encryption) Parse(cmd *cobra.Command) error {
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), encryptRecipientFlag, encryptPassphraseStdinFlag); err != nil {
+		return err
+	}
+	if err := checkPassphraseStdinConflict(cmd.Flags(), encryptPassphraseStdinFlag); err != nil {
+		return err
+	}
+	for _, path := range opts.RecipientFiles {
+		recipients, err := parseKeyFile(path, age.ParseRecipients)
+		if err != nil {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("failed to read recipients from %q: %w", path, err),
+				Recommendation: `The file must contain age public keys starting with "age1", one key per line.`,
+			}
+		}
+		opts.Recipients = append(opts.Recipients, recipients...)
+	}
+	if opts.PassphraseFromStdin {
+		passphrase, err := readPassphrase(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		recipient, err := age.NewScryptRecipient(passphrase)
+		if err != nil {
+			return fmt.Errorf("invalid passphrase: %w", err)
+		}
+		opts.Recipients = []age.Recipient{recipient}
+	}
+	return nil
+}
+
+// Enabled returns true if the encryption is enabled.
+func (opts *Encryption) Enabled() bool {
+	return len(opts.Recipients) > 0
+}
+
+// Decryption option struct.
+type Decryption struct {
+	IdentityFiles       []string
+	PassphraseFromStdin bool
+
+	// Identities are the parsed identities to decrypt with.
+	Identities []age.Identity
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Decryption) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVarP(&opts.IdentityFiles, decryptIdentityFlag, "", nil, "decrypt with the age private keys in the `file`, one key per line, can be specified multiple times")
+	fs.BoolVarP(&opts.PassphraseFromStdin, decryptPassphraseStdinFlag, "", false, "decrypt with a passphrase read from stdin")
+}
+
+// Parse reads the identities from the files or the passphrase from stdin.
+func (opts *Decryption) Parse(cmd *cobra.Command) error {
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), decryptIdentityFlag, decryptPassphraseStdinFlag); err != nil {
+		return err
+	}
+	if err := checkPassphraseStdinConflict(cmd.Flags(), decryptPassphraseStdinFlag); err != nil {
+		return err
+	}
+	for _, path := range opts.IdentityFiles {
+		identities, err := parseKeyFile(path, age.ParseIdentities)
+		if err != nil {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("failed to read identities from %q: %w", path, err),
+				Recommendation: `The file must contain age private keys starting with "AGE-SECRET-KEY-1", one key per line.`,
+			}
+		}
+		opts.Identities = append(opts.Identities, identities...)
+	}
+	if opts.PassphraseFromStdin {
+		passphrase, err := readPassphrase(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		identity, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return fmt.Errorf("invalid passphrase: %w", err)
+		}
+		opts.Identities = []age.Identity{identity}
+	}
+	return nil
+}
+
+// checkPassphraseStdinConflict checks if the passphrase flag conflicts with
+// other flags reading from stdin.
+func checkPassphraseStdinConflict(fs *pflag.FlagSet, passphraseFlag string) error {
+	if err := oerrors.CheckMutuallyExclusiveFlags(fs, passphraseFlag, passwordFromStdinFlag, identityTokenFromStdinFlag); err != nil {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: "Only one of the flags reading from stdin can be used.",
+		}
+	}
+	return nil
+}
+
+// parseKeyFile parses the keys in the file at path. Parse errors are not
+// returned as is, since they may quote a private key in the file.
+func parseKeyFile[T any](path string, parse func(io.Reader) ([]T, error)) ([]T, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = fp.Close()
+	}()
+	keys, err := parse(fp)
+	if err != nil {
+		return nil, errors.New("invalid key file")
+	}
+	return keys, nil
+}
+
+// readPassphrase reads a non-empty passphrase from r.
+func readPassphrase(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase from stdin: %w", err)
+	}
+	passphrase := strings.TrimSuffix(string(data), "\n")
+	passphrase = strings.TrimSuffix(passphrase, "\r")
+	if passphrase == "" {
+		return "", errors.New("the passphrase read from stdin is empty")
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/spf13/cobra"
+)
+
+// newKeyFiles generates an age key pair and writes the public key and the
+// private key into files.
+func newKeyFiles(t *testing.T) (recipientFile string, identityFile string, identity *age.X25519Identity) {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("failed to generate identity: %v", err)
+	}
+	dir := t.TempDir()
+	recipientFile = filepath.Join(dir, "recipients.txt")
+	identityFile = filepath.Join(dir, "key.txt")
+	if err := os.WriteFile(recipientFile, []byte("# public key\n"+identity.Recipient().String()+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return recipientFile, identityFile, identity
+}
+
+// newEncryptionCommand returns a command with the encryption flags and the
+// stdin flags of the remote options.
+func newEncryptionCommand(opts FlagApplier, stdin string, args ...string) (*cobra.Command, error) {
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	var remote Remote
+	remote.applyStdinFlags(cmd.Flags())
+	cmd.SetIn(strings.NewReader(stdin))
+	return cmd, cmd.ParseFlags(args)
+}
+
+func TestEncryption_Parse(t *testing.T) {
+	recipientFile, identityFile, identity := newKeyFiles(t)
+	tests := []struct {
+		name        string
+		args        []string
+		stdin       string
+		wantEnabled bool
+		wantErr     bool
+	}{
+		{name: "no encryption"},
+		{name: "recipient file", args: []string{"--encrypt-recipient", recipientFile}, wantEnabled: true},
+		{name: "passphrase", args: []string{"--encrypt-passphrase-stdin"}, stdin: "secret\n", wantEnabled: true},
+		{name: "empty passphrase", args: []string{"--encrypt-passphrase-stdin"}, stdin: "\n", wantErr: true},
+		{name: "missing recipient file", args: []string{"--encrypt-recipient", filepath.Join(t.TempDir(), "missing")}, wantErr: true},
+		{name: "private key as recipient", args: []string{"--encrypt-recipient", identityFile}, wantErr: true},
+		{name: "recipient and passphrase", args: []string{"--encrypt-recipient", recipientFile, "--encrypt-passphrase-stdin"}, wantErr: true},
+		{name: "passphrase and password", args: []string{"--encrypt-passphrase-stdin", "--password-stdin"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts Encryption
+			cmd, err := newEncryptionCommand(&opts, tt.stdin, tt.args...)
+			if err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+			err = opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encryption.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && strings.Contains(err.Error(), identity.String()) {
+				t.Fatalf("Encryption.Parse() error = %v, which leaks the private key", err)
+			}
+			if opts.Enabled() != tt.wantEnabled {
+				t.Errorf("Encryption.Enabled() = %v, want %v", opts.Enabled(), tt.wantEnabled)
+			}
+		})
+	}
+}
+
+func TestDecryption_Parse(t *testing.T) {
+	recipientFile, identityFile, _ := newKeyFiles(t)
+	tests := []struct {
+		name           string
+		args           []string
+		stdin          string
+		wantIdentities int
+		wantErr        bool
+	}{
+		{name: "no decryption"},
+		{name: "identity file", args: []string{"--decrypt-identity", identityFile}, wantIdentities: 1},
+		{name: "multiple identity files", args: []string{"--decrypt-identity", identityFile, "--decrypt-identity", identityFile}, wantIdentities: 2},
+		{name: "passphrase", args: []string{"--decrypt-passphrase-stdin"}, stdin: "secret", wantIdentities: 1},
+		{name: "public key as identity", args: []string{"--decrypt-identity", recipientFile}, wantErr: true},
+		{name: "identity and passphrase", args: []string{"--decrypt-identity", identityFile, "--decrypt-passphrase-stdin"}, wantErr: true},
+		{name: "passphrase and identity token", args: []string{"--decrypt-passphrase-stdin", "--identity-token-stdin"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts Decryption
+			cmd, err := newEncryptionCommand(&opts, tt.stdin, tt.args...)
+			if err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+			err = opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decryption.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(opts.Identities) != tt.wantIdentities {
+				t.Errorf("Decryption.Parse() identities = %d, want %d", len(opts.Identities), tt.wantIdentities)
+			}
+		})
+	}
+}
@@ -65,10 +65,26 @@ var (
 		Name:  "tree",
 		Usage: "Get referrers and print in tree format",
 	}
+	FormatTypeJSONLines = &FormatType{
+		Name:  "jsonl",
+		Usage: "Print in JSON Lines format",
+	}
 	FormatTypeText = &FormatType{
 		Name:  "text",
 		Usage: "Print in text format",
 	}
+	FormatTypeDOT = &FormatType{
+		Name:  "dot",
+		Usage: "Print in Graphviz DOT format",
+	}
+	FormatTypeMermaid = &FormatType{
+		Name:  "mermaid",
+		Usage: "Print in Mermaid flowchart format",
+	}
+	FormatTypePlantUML = &FormatType{
+		Name:  "plantuml",
+		Usage: "Print in PlantUML format",
+	}
 )
 
 // Format contains input and parsed options for formatted output flags.
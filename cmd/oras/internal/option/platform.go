@@ -45,12 +45,22 @@ func (opts *Platform) Parse(*cobra.Command) error {
 	if opts.platform == "" {
 		return nil
 	}
+	p, err := ParsePlatform(opts.platform)
+	if err != nil {
+		return err
+	}
+	opts.Platform = p
+	return nil
+}
 
+// ParsePlatform parses a platform in the form of
+// os[/arch][/variant][:os_version] to an oci platform type.
+func ParsePlatform(platform string) (*ocispec.Platform, error) {
 	// OS[/Arch[/Variant]][:OSVersion]
 	// If Arch is not provided, will use GOARCH instead
 	var platformStr string
 	var p ocispec.Platform
-	platformStr, p.OSVersion, _ = strings.Cut(opts.platform, ":")
+	platformStr, p.OSVersion, _ = strings.Cut(platform, ":")
 	parts := strings.Split(platformStr, "/")
 	switch len(parts) {
 	case 3:
@@ -61,17 +71,31 @@ func (opts *Platform) Parse(*cobra.Command) error {
 	case 1:
 		p.Architecture = runtime.GOARCH
 	default:
-		return fmt.Errorf("failed to parse platform %q: expected format os[/arch[/variant]]", opts.platform)
+		return nil, fmt.Errorf("failed to parse platform %q: expected format os[/arch[/variant]]", platform)
 	}
 	p.OS = parts[0]
 	if p.OS == "" {
-		return fmt.Errorf("invalid platform: OS cannot be empty")
+		return nil, fmt.Errorf("invalid platform: OS cannot be empty")
 	}
 	if p.Architecture == "" {
-		return fmt.Errorf("invalid platform: Architecture cannot be empty")
+		return nil, fmt.Errorf("invalid platform: Architecture cannot be empty")
 	}
-	opts.Platform = &p
-	return nil
+	return &p, nil
+}
+
+// ParsePlatformSelector parses a platform selector in the form of
+// os[/arch][/variant][:os_version]. Unlike ParsePlatform, the architecture is
+// left empty if not provided, so that the selector matches any architecture
+// instead of the architecture of the host.
+func ParsePlatformSelector(platform string) (*ocispec.Platform, error) {
+	p, err := ParsePlatform(platform)
+	if err != nil {
+		return nil, err
+	}
+	if platformStr, _, _ := strings.Cut(platform, ":"); !strings.Contains(platformStr, "/") {
+		p.Architecture = ""
+	}
+	return p, nil
 }
 
 // ArtifactPlatform option struct.
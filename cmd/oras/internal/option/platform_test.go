@@ -80,3 +80,29 @@ func TestPlatform_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePlatformSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		want     *ocispec.Platform
+		wantErr  bool
+	}{
+		{name: "any arch", platform: "os", want: &ocispec.Platform{OS: "os"}},
+		{name: "any arch with os version", platform: "os:osversion", want: &ocispec.Platform{OS: "os", OSVersion: "osversion"}},
+		{name: "os&arch", platform: "os/aRcH", want: &ocispec.Platform{OS: "os", Architecture: "aRcH"}},
+		{name: "os&arch&variant", platform: "os/aRcH/vAriAnt", want: &ocispec.Platform{OS: "os", Architecture: "aRcH", Variant: "vAriAnt"}},
+		{name: "invalid", platform: "/aRcH", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePlatformSelector(tt.platform)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePlatformSelector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePlatformSelector() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package option
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	orasio "oras.land/oras/internal/io"
+)
+
+// TarEntryFilter option struct.
+type TarEntryFilter struct {
+	Patterns []string
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *TarEntryFilter) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVarP(&opts.Patterns, "match", "", nil, "only list entries matching the shell `pattern`, matched against the base name if the pattern has no slash, can be used multiple times")
+}
+
+// Parse validates the patterns.
+func (opts *TarEntryFilter) Parse(*cobra.Command) error {
+	_, err := orasio.MatchPath("", opts.Patterns)
+	return err
+}
+
+// Match reports whether the name of a tar entry matches any of the patterns.
+// All entries match if no pattern is provided.
+func (opts *TarEntryFilter) Match(name string) bool {
+	// the patterns are validated on parsing
+	matched, _ := orasio.MatchPath(name, opts.Patterns)
+	return matched
+}
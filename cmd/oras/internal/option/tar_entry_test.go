@@ -0,0 +1,45 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package option
+
+import "testing"
+
+func TestTarEntryFilter_Parse(t *testing.T) {
+	opts := TarEntryFilter{Patterns: []string{"*.so", "usr/bin/*"}}
+	if err := opts.Parse(nil); err != nil {
+		t.Fatalf("TarEntryFilter.Parse() error = %v", err)
+	}
+	opts.Patterns = append(opts.Patterns, "[")
+	if err := opts.Parse(nil); err == nil {
+		t.Fatal("TarEntryFilter.Parse() error = nil, want error for invalid pattern")
+	}
+}
+
+func TestTarEntryFilter_Match(t *testing.T) {
+	opts := TarEntryFilter{Patterns: []string{"*.so", "usr/bin/*"}}
+	for name, want := range map[string]bool{
+		"lib/libc.so":  true,
+		"./usr/bin/ls": true,
+		"usr/lib/foo":  false,
+	} {
+		if got := opts.Match(name); got != want {
+			t.Errorf("TarEntryFilter.Match(%q) = %v, want %v", name, got, want)
+		}
+	}
+	var all TarEntryFilter
+	if !all.Match("any") {
+		t.Error("TarEntryFilter.Match() = false, want true without patterns")
+	}
+}
@@ -29,6 +29,12 @@ func PrintPrettyJSON(out io.Writer, object any) error {
 	return encoder.Encode(object)
 }
 
+// PrintJSONLine writes the object to the output stream as a single line of
+// JSON.
+func PrintJSONLine(out io.Writer, object any) error {
+	return json.NewEncoder(out).Encode(object)
+}
+
 // PrintJSON writes the data to the output stream, optionally prettifying it.
 func PrintJSON(out io.Writer, data []byte, pretty bool) error {
 	if pretty {
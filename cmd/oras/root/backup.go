@@ -17,15 +17,23 @@ package root
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
+	"maps"
+	"math"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"filippo.io/age"
+	"github.com/Masterminds/semver/v3"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -38,10 +46,14 @@ import (
 	"oras.land/oras/cmd/oras/internal/display"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/fileref"
 	"oras.land/oras/cmd/oras/internal/option"
-	"oras.land/oras/internal/docker"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/backup"
+	"oras.land/oras/internal/descriptor"
 	"oras.land/oras/internal/graph"
 	orasio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/repository"
 )
 
 // outputFormat defines the format of the backup output.
@@ -61,25 +73,65 @@ type backupOptions struct {
 	option.Common
 	option.Remote
 	option.Terminal
+	option.Encryption
 
 	// flags
 	output           string
 	includeReferrers bool
 	concurrency      int
+	incremental      bool
+	prune            bool
+	referenceFile    string
+	namespace        string
+	fullReference    bool
+	compress         string
+	tagRegex         string
+	semver           string
+	latest           int
+	since            string
+	volumeSize       string
+	ociLayout        bool
 
 	// derived options
 	outputFormat outputFormat
-	repository   string
-	tags         []string
+	compression  orasio.Compression
+	volumeBytes  int64
+	registry     string
+	sources      []backupSource
+	tagFilter    *backup.TagFilter
+}
+
+// backupSource is a repository, or an OCI image layout, to back up with the
+// specified tags. All tags in the source are backed up if no tag is specified.
+type backupSource struct {
+	repository string
+	tags       []string
+}
+
+// backupItem is a tagged artifact to back up.
+type backupItem struct {
+	src        oras.ReadOnlyGraphTarget
+	repository string
+	tag        string
+	// ref is the reference of the artifact in the OCI image layout, which is
+	// either the tag or the full reference of the artifact.
+	ref  string
+	root ocispec.Descriptor
 }
 
 func backupCmd() *cobra.Command {
 	var opts backupOptions
 	cmd := &cobra.Command{
-		Use:   "backup [flags] --output <path> <registry>/<repository>[:<ref1>[,<ref2>...]]",
-		Short: "[Experimental] Back up artifacts from a registry into an OCI image layout",
-		Long: `[Experimental] Back up artifacts from a registry into an OCI image layout, saved either as a directory or a tar archive.
-The output format is determined by the file extension of the specified output path: if it ends with ".tar", the output will be a tar archive; otherwise, it will be a directory.
+		Use:   "backup [flags] --output <path> {<registry>/<repository> | <path>}[:<ref1>[,<ref2>...]] [...]",
+		Short: "[Experimental] Back up artifacts from a registry or an OCI image layout into an OCI image layout",
+		Long: `[Experimental] Back up artifacts from a registry or an OCI image layout into an OCI image layout, saved either as a directory or a tar archive.
+The "--oci-layout" flag backs up from OCI image layouts instead of a registry, each specified as a directory or a tar archive in the form of <path>[:<ref1>[,<ref2>...]]. Backing up multiple OCI image layouts consolidates their tags into a single backup.
+The output format is determined by the file extension of the specified output path: if it ends with ".tar", the output will be a tar archive; if it ends with ".tar.gz" or ".tgz", a gzip-compressed tar archive; if it ends with ".tar.zst", a zstd-compressed tar archive; otherwise, it will be a directory.
+The "--compress" flag outputs a compressed tar archive regardless of the file extension.
+Tar archives are written as a stream without staging the backup on disk, except for incremental backups. Use "--output -" to write the tar archive to stdout.
+The "--encrypt-recipient" and "--encrypt-passphrase-stdin" flags encrypt the tar archive with age (https://age-encryption.org). The encrypted archive is authenticated, so any modification or truncation is detected on restore. Encrypted backups are always tar archives, and the ".age" extension is recommended.
+The "--volume-size" flag splits the tar archive into volumes of the specified size, named after the output path with a numeric suffix (e.g. hello.tar.001, hello.tar.002), along with an index file describing the volumes (e.g. hello.tar.index.json). Pass the first volume to "oras restore --input" to restore from all volumes.
+An integrity report is embedded in every backup, which can be checked with "oras backup verify".
 
 Example - Back up a single artifact to a directory:
   oras backup --output hello localhost:5000/hello:v1
@@ -87,6 +139,15 @@ Example - Back up a single artifact to a directory:
 Example - Back up to a tar archive:
   oras backup --output hello.tar localhost:5000/hello:v1
 
+Example - Back up to a gzip-compressed tar archive:
+  oras backup --output hello.tar.gz localhost:5000/hello:v1
+
+Example - Back up to a zstd-compressed tar archive:
+  oras backup --output hello.backup --compress zstd localhost:5000/hello:v1
+
+Example - Stream a gzip-compressed tar archive to stdout:
+  oras backup --output - --compress gzip localhost:5000/hello:v1 > hello.tar.gz
+
 Example - Back up an artifact along with its referrers (e.g. attestations, SBOMs):
   oras backup --output hello --include-referrers localhost:5000/hello:v1
 
@@ -110,28 +171,137 @@ Example - Back up from a registry using plain HTTP (no TLS):
 
 Example - Set custom concurrency level:
   oras backup --output hello --concurrency 6 localhost:5000/hello:v1
+
+Example - Incrementally update an existing backup, only fetching artifacts whose digests changed:
+  oras backup --output hello.tar --incremental localhost:5000/hello
+
+Example - Incrementally update an existing backup and prune tags deleted from the repository:
+  oras backup --output hello --incremental --prune localhost:5000/hello
+
+Example - Back up multiple repositories from the same registry:
+  oras backup --output apps.tar localhost:5000/hello:v1 localhost:5000/world
+
+Example - Back up the repositories listed in a file, one reference per line:
+  oras backup --output apps.tar --reference-file repositories.txt
+
+Example - Back up all repositories under a namespace:
+  oras backup --output apps.tar --namespace localhost:5000/apps
+
+Example - Store the full reference of each artifact in a single repository backup:
+  oras backup --output hello --full-reference localhost:5000/hello:v1
+
+Example - Back up the tags matching a regular expression:
+  oras backup --output hello --tag-regex '^release-' localhost:5000/hello
+
+Example - Back up the latest 3 versions within a semantic version range:
+  oras backup --output hello --semver '>=1.2 <2' --latest 3 localhost:5000/hello
+
+Example - Back up the artifacts created since a date:
+  oras backup --output hello --since 2025-01-01 localhost:5000/hello
+
+Example - Encrypt the backup to the age public keys in a file:
+  oras backup --output hello.tar.gz.age --encrypt-recipient recipients.txt localhost:5000/hello
+
+Example - Encrypt the backup with a passphrase read from stdin:
+  oras backup --output hello.tar.age --encrypt-passphrase-stdin localhost:5000/hello < passphrase.txt
+
+Example - Split the tar archive into volumes of at most 4 GiB:
+  oras backup --output hello.tar --volume-size 4GiB localhost:5000/hello
+
+Example - Back up specific tags from an OCI image layout:
+  oras backup --output hello.tar --oci-layout layout-dir:v1,v2
+
+Example - Consolidate multiple OCI image layouts into a single backup:
+  oras backup --output all --oci-layout layout-dir hello.tar
 `,
-		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifacts to back up"),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.referenceFile != "" || opts.namespace != "" {
+				return nil
+			}
+			return oerrors.CheckArgs(argument.AtLeast(1), "the artifacts to back up")(cmd, args)
+		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := option.Parse(cmd, &opts); err != nil {
 				return err
 			}
 
-			// parse repo and references
+			// parse repositories and references
+			refs := args
+			if opts.referenceFile != "" {
+				fileRefs, err := readReferenceFile(opts.referenceFile)
+				if err != nil {
+					return err
+				}
+				refs = append(refs, fileRefs...)
+			}
 			var err error
-			opts.repository, opts.tags, err = parseArtifactReferences(args[0])
+			if opts.ociLayout {
+				if err := opts.checkLayoutSourceFlags(); err != nil {
+					return err
+				}
+				opts.sources, err = parseLayoutSources(refs)
+			} else {
+				opts.registry, opts.sources, err = parseBackupSources(refs)
+			}
 			if err != nil {
 				return err
 			}
+			if opts.namespace != "" {
+				if err := opts.parseNamespace(); err != nil {
+					return err
+				}
+			}
+			if !opts.ociLayout && (len(opts.sources) > 1 || opts.namespace != "") {
+				// multi-repository backups always use full references
+				opts.fullReference = true
+			}
+			if opts.ociLayout {
+				for _, source := range opts.sources {
+					if filepath.Clean(source.repository) == filepath.Clean(opts.output) {
+						return fmt.Errorf("the OCI image layout %q cannot be backed up to itself", source.repository)
+					}
+				}
+			}
+			if opts.prune {
+				if !opts.incremental {
+					return errors.New("--prune must be used in conjunction with --incremental")
+				}
+				for _, source := range opts.sources {
+					if len(source.tags) > 0 {
+						return &oerrors.Error{
+							Err:            errors.New("--prune cannot be used when tags are specified"),
+							Recommendation: fmt.Sprintf("Remove the tags of %q to back up and prune all tags in the repository.", source.repository),
+						}
+					}
+				}
+			}
+
+			if err := opts.parseTagFilter(); err != nil {
+				return err
+			}
 
 			// parse output format
-			if strings.EqualFold(filepath.Ext(opts.output), ".tar") {
-				opts.outputFormat = outputFormatTar
-			} else {
-				opts.outputFormat = outputFormatDir
+			if err := opts.parseOutputFormat(); err != nil {
+				return err
+			}
+			if err := opts.parseVolumeSize(); err != nil {
+				return err
+			}
+			if opts.incremental && opts.Encryption.Enabled() {
+				return &oerrors.Error{
+					Err:            errors.New("--incremental cannot be used when the backup is encrypted"),
+					Recommendation: "Remove the --incremental flag to create a new encrypted backup.",
+				}
+			}
+			if opts.output == "-" {
+				if opts.incremental {
+					return errors.New("--incremental cannot be used when the output is stdout")
+				}
+				// stdout is reserved for the tar archive
+				opts.Printer = output.NewPrinter(cmd.ErrOrStderr(), cmd.ErrOrStderr())
 			}
 
-			opts.DisableTTY(opts.Debug, false)
+			opts.DisableTTY(opts.Debug, opts.output == "-")
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -141,14 +311,27 @@ Example - Set custom concurrency level:
 	}
 
 	// required flags
-	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "path to the target output, either a tar archive (*.tar) or a directory")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "path to the target output, either a tar archive (*.tar, *.tar.gz, *.tgz, *.tar.zst) or a directory, use - for a tar archive to stdout")
 	_ = cmd.MarkFlagRequired("output")
 	// optional flags
 	cmd.Flags().BoolVarP(&opts.includeReferrers, "include-referrers", "", false, "back up the artifact with its referrers (e.g., attestations, SBOMs)")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	cmd.Flags().BoolVarP(&opts.incremental, "incremental", "", false, "reuse the existing backup at the output path and only fetch artifacts whose digests changed")
+	cmd.Flags().BoolVarP(&opts.prune, "prune", "", false, "remove tags from the existing backup that no longer exist in the repository or no longer match the tag filters, requires --incremental")
+	cmd.Flags().StringVarP(&opts.referenceFile, "reference-file", "", "", "path to a file listing the artifacts to back up, one reference per line")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "", "", "back up all repositories under the namespace, in the form of <registry>/<namespace>")
+	cmd.Flags().StringVarP(&opts.compress, "compress", "", "", "compress the output tar archive, options: gzip, zstd, none")
+	cmd.Flags().StringVarP(&opts.tagRegex, "tag-regex", "", "", "only back up the tags matching the regular expression")
+	cmd.Flags().StringVarP(&opts.semver, "semver", "", "", "only back up the tags which are semantic versions satisfying the constraint, e.g. '>=1.2 <2'")
+	cmd.Flags().IntVarP(&opts.latest, "latest", "", 0, "only back up the latest N tags (0 for no limit), ordered by semantic version if --semver is set, or by the created annotation otherwise")
+	cmd.Flags().StringVarP(&opts.since, "since", "", "", "only back up the artifacts created at or after the date (RFC 3339 or YYYY-MM-DD), according to the created annotation")
+	cmd.Flags().StringVarP(&opts.volumeSize, "volume-size", "", "", "split the output tar archive into volumes of the size, e.g. 4GiB, 500MB")
+	cmd.Flags().BoolVarP(&opts.fullReference, "full-reference", "", false, "store the full reference of each artifact in the OCI image layout, always enabled for multiple repositories")
+	cmd.Flags().BoolVarP(&opts.ociLayout, "oci-layout", "", false, "set the sources as OCI image layouts")
 	opts.EnableDistributionSpecFlag()
 	// apply flags
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.AddCommand(backupVerifyCmd())
 	return oerrors.Command(cmd, &opts.Remote)
 }
 
@@ -159,24 +342,43 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 	startTime := time.Now() // start timing the backup process
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 
-	var dstRoot string
-	switch opts.outputFormat {
-	case outputFormatDir:
+	var dstRoot string // the root of the OCI layout, empty if streaming
+	switch {
+	case opts.outputFormat == outputFormatDir:
 		dstRoot = opts.output
-	case outputFormatTar:
+	case opts.output == "-":
+		// stream the backup to stdout
+	case opts.outputFormat == outputFormatTar:
+		// check if there is a previous backup to update before the output file is touched
+		// the first volume is the entry of a split archive
+		outputFile := opts.output
+		if opts.volumeBytes > 0 {
+			outputFile = backup.VolumePath(opts.output, 1)
+		}
+		var previousBackup bool
+		if opts.incremental {
+			if fi, err := os.Stat(outputFile); err == nil && fi.Mode().IsRegular() && fi.Size() > 0 {
+				previousBackup = true
+			}
+		}
+
 		// test if the output file can be created and fail early if there is an issue
-		fp, err := os.OpenFile(opts.output, os.O_CREATE|os.O_WRONLY, 0666)
+		fp, err := os.OpenFile(outputFile, os.O_CREATE|os.O_WRONLY, 0666)
 		if err != nil {
-			if fi, statErr := os.Stat(opts.output); statErr == nil && fi.IsDir() {
+			if fi, statErr := os.Stat(outputFile); statErr == nil && fi.IsDir() {
 				return &oerrors.Error{
-					Err:            fmt.Errorf("the output path %q already exists and is a directory", opts.output),
+					Err:            fmt.Errorf("the output path %q already exists and is a directory", outputFile),
 					Recommendation: "To back up to a tar archive, please specify a different output file name or remove the existing directory.",
 				}
 			}
-			return fmt.Errorf("unable to create output file %s: %w", opts.output, err)
+			return fmt.Errorf("unable to create output file %s: %w", outputFile, err)
 		}
 		if err := fp.Close(); err != nil {
-			return fmt.Errorf("unable to close output file %s: %w", opts.output, err)
+			return fmt.Errorf("unable to close output file %s: %w", outputFile, err)
+		}
+		if !opts.incremental {
+			// stream the backup to the output file
+			break
 		}
 
 		// create a temporary directory as the working directory for OCI store
@@ -190,36 +392,149 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 			}
 		}()
 		dstRoot = tempDir
+		if previousBackup {
+			if err := extractArchive(outputFile, dstRoot, nil); err != nil {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("failed to extract the existing backup: %w", err),
+					Recommendation: "To create a new backup, please specify a different output path or remove the --incremental flag.",
+				}
+			}
+		}
 	default:
 		// this should not happen, just a safeguard
 		return fmt.Errorf("unsupported output format")
 	}
 
-	// Prepare copy source and destination
-	srcRepo, err := opts.NewRepository(opts.repository, opts.Common, logger)
-	if err != nil {
-		return fmt.Errorf("failed to prepare repository %s for backup: %w", opts.repository, err)
+	// Prepare copy sources and destination
+	sources := opts.sources
+	if opts.namespace != "" {
+		reg, err := opts.NewRegistry(opts.registry, opts.Common, logger)
+		if err != nil {
+			return fmt.Errorf("failed to prepare registry %s for backup: %w", opts.registry, err)
+		}
+		if sources, err = appendNamespaceSources(ctx, reg, opts.registry, opts.namespace, sources); err != nil {
+			return err
+		}
 	}
-	dstOCI, err := oci.New(dstRoot)
-	if err != nil {
-		return fmt.Errorf("failed to prepare OCI store for backup: %w", err)
+	source := opts.registry // the name of the backup source for display
+	if len(sources) == 1 {
+		source = sources[0].repository
+	} else if opts.ociLayout {
+		paths := make([]string, len(sources))
+		for i, src := range sources {
+			paths[i] = src.repository
+		}
+		source = strings.Join(paths, ", ")
 	}
-	statusHandler, metadataHandler := display.NewBackupHandler(opts.Printer, opts.TTY, opts.repository, dstOCI)
 
 	// Resolve tags to back up
-	tags, roots, err := resolveTags(ctx, srcRepo, opts.tags)
-	if err != nil {
-		return err
+	var items []backupItem
+	sourceOfRef := make(map[string]string)
+	for _, src := range sources {
+		srcTarget, err := opts.newSourceTarget(ctx, src, logger)
+		if err != nil {
+			return fmt.Errorf("failed to prepare %s for backup: %w", src.repository, err)
+		}
+		tags, roots, err := resolveTags(ctx, srcTarget, src.tags, opts.tagFilter)
+		if err != nil {
+			if len(sources) > 1 {
+				return fmt.Errorf("failed to back up %q: %w", src.repository, err)
+			}
+			return err
+		}
+		for i, tag := range tags {
+			item := backupItem{
+				src:        srcTarget,
+				repository: src.repository,
+				tag:        tag,
+				ref:        tag,
+				root:       roots[i],
+			}
+			if opts.fullReference {
+				item.ref = src.repository + ":" + tag
+			}
+			// tags of OCI image layouts are backed up as is, so they must be
+			// unique across the layouts
+			if previous, ok := sourceOfRef[item.ref]; ok {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("tag %q is found in both %q and %q", item.ref, previous, src.repository),
+					Recommendation: "Specify the tags to back up from each OCI image layout, or back up the OCI image layouts separately.",
+				}
+			}
+			sourceOfRef[item.ref] = src.repository
+			items = append(items, item)
+		}
 	}
-	if len(tags) == 0 {
+	if len(items) == 0 {
+		listCommand := "oras repo tags"
+		if opts.ociLayout {
+			listCommand = "oras repo tags --oci-layout"
+		}
+		if !opts.tagFilter.IsEmpty() {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("no tags matching the filters found in %q", source),
+				Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "%s"`, source, listCommand),
+			}
+		}
 		return &oerrors.Error{
-			Err:            fmt.Errorf("no tags found in repository %q", opts.repository),
-			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags"`, opts.repository),
+			Err:            fmt.Errorf("no tags found in %q", source),
+			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "%s"`, source, listCommand),
+		}
+	}
+
+	// Prepare the destination
+	var dst oras.GraphTarget
+	var dstOCI *oci.Store
+	var stream *backupStream
+	var err error
+	if dstRoot != "" {
+		dstOCI, err = oci.New(dstRoot)
+		if err != nil {
+			return fmt.Errorf("failed to prepare OCI store for backup: %w", err)
 		}
+		dst = dstOCI
+	} else {
+		stream, err = newBackupStream(opts.output, cmd.OutOrStdout(), opts.compression, opts.volumeBytes, opts.Recipients)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if !stream.closed {
+				stream.abort(logger)
+			}
+		}()
+		dst = stream
 	}
-	if err := metadataHandler.OnTagsFound(tags); err != nil {
+	statusHandler, metadataHandler := display.NewBackupHandler(opts.Printer, opts.TTY, source, dst)
+	if stream != nil {
+		stream.onVolumeCompleted(metadataHandler.OnVolumeExported)
+	}
+
+	refs := make([]string, len(items))
+	for i, item := range items {
+		refs[i] = item.ref
+	}
+	if err := metadataHandler.OnTagsFound(refs); err != nil {
 		return err
 	}
+	if stream != nil {
+		if err := metadataHandler.OnTarExporting(opts.outputName()); err != nil {
+			return err
+		}
+	}
+
+	// Snapshot the tags of the previous backup
+	journal := backup.JournalEntry{
+		Repository: source,
+		StartedAt:  startTime.UTC(),
+	}
+	previousTags := make(map[string]ocispec.Descriptor)
+	if opts.incremental {
+		previousTags, err = listLayoutTags(ctx, dstOCI)
+		if err != nil {
+			return fmt.Errorf("failed to read tags of the existing backup at %q: %w", opts.output, err)
+		}
+	}
 
 	// Prepare copy options
 	copyGraphOpts := oras.DefaultCopyGraphOptions
@@ -234,9 +549,30 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 		},
 	}
 
-	for i, tag := range tags {
+	for _, item := range items {
+		change := backup.TagChange{Tag: item.ref, Digest: item.root.Digest}
+		previous, backedUp := previousTags[item.ref]
+		switch {
+		case !backedUp:
+			journal.Added = append(journal.Added, change)
+		case previous.Digest == item.root.Digest:
+			journal.Unchanged = append(journal.Unchanged, change)
+			if !opts.includeReferrers {
+				// the artifact is already backed up, nothing to fetch
+				if err := metadataHandler.OnArtifactUnchanged(item.ref); err != nil {
+					return err
+				}
+				continue
+			}
+			// referrers may have been attached since the last backup; content
+			// that is already backed up is skipped during copy
+		default:
+			change.PreviousDigest = previous.Digest
+			journal.Updated = append(journal.Updated, change)
+		}
+
 		referrerCount, err := func() (referrerCount int, retErr error) {
-			trackedDst, err := statusHandler.StartTracking(dstOCI)
+			trackedDst, err := statusHandler.StartTracking(dst)
 			if err != nil {
 				return 0, err
 			}
@@ -248,23 +584,451 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 			}()
 
 			if opts.includeReferrers {
-				return backupTagWithReferrers(ctx, srcRepo, trackedDst, tag, roots[i], extCopyGraphOpts)
+				return backupTagWithReferrers(ctx, item.src, trackedDst, item.ref, item.root, extCopyGraphOpts)
 			}
-			return 0, backupTag(ctx, srcRepo, trackedDst, tag, roots[i], copyGraphOpts)
+			return 0, backupTag(ctx, item.src, trackedDst, item.ref, item.root, copyGraphOpts)
 		}()
 		if err != nil {
-			return fmt.Errorf("failed to back up tag %q from %q to %q: %w", tag, opts.repository, dstRoot, oerrors.UnwrapCopyError(err))
+			return fmt.Errorf("failed to back up tag %q from %q to %q: %w", item.tag, item.repository, opts.outputName(), oerrors.UnwrapCopyError(err))
+		}
+		if err := metadataHandler.OnArtifactPulled(item.ref, referrerCount); err != nil {
+			return err
+		}
+	}
+
+	if opts.incremental {
+		if opts.prune {
+			pruned, err := pruneTags(ctx, dstOCI, previousTags, refs)
+			if err != nil {
+				return fmt.Errorf("failed to prune tags from %q: %w", opts.output, err)
+			}
+			for _, change := range pruned {
+				if err := metadataHandler.OnTagPruned(change.Tag); err != nil {
+					return err
+				}
+			}
+			journal.Pruned = pruned
 		}
-		if err := metadataHandler.OnArtifactPulled(tag, referrerCount); err != nil {
+		journal.CompletedAt = time.Now().UTC()
+		if err := backup.AppendJournal(dstRoot, journal); err != nil {
 			return err
 		}
 	}
 
-	if err := finalizeBackupOutput(dstRoot, opts, logger, metadataHandler); err != nil {
+	// Embed the integrity report
+	if err := writeBackupReport(ctx, dstRoot, dstOCI, stream, items); err != nil {
+		return err
+	}
+
+	if stream != nil {
+		size, err := stream.Close()
+		if err != nil {
+			return fmt.Errorf("failed to create tar archive at %s: %w", opts.outputName(), err)
+		}
+		if err := metadataHandler.OnTarExported(opts.outputName(), size); err != nil {
+			return err
+		}
+	} else if err := finalizeBackupOutput(dstRoot, opts, logger, metadataHandler); err != nil {
 		return err
 	}
 	duration := time.Since(startTime)
-	return metadataHandler.OnBackupCompleted(len(tags), opts.output, duration)
+	return metadataHandler.OnBackupCompleted(len(items), opts.outputName(), duration)
+}
+
+// writeBackupReport writes the integrity report of all tags in the OCI image
+// layout, or of the backed up tags if the backup is streamed.
+func writeBackupReport(ctx context.Context, dstRoot string, dstOCI *oci.Store, stream *backupStream, items []backupItem) error {
+	if stream != nil {
+		tags := make(map[string]ocispec.Descriptor, len(items))
+		for _, item := range items {
+			tags[item.ref] = item.root
+		}
+		report, err := backup.NewReport(ctx, stream, tags)
+		if err != nil {
+			return fmt.Errorf("failed to create backup report: %w", err)
+		}
+		data, err := report.Marshal()
+		if err != nil {
+			return err
+		}
+		return stream.AddFile(backup.ReportFileName, data)
+	}
+
+	tags, err := listLayoutTags(ctx, dstOCI)
+	if err != nil {
+		return fmt.Errorf("failed to create backup report: %w", err)
+	}
+	report, err := backup.NewReport(ctx, dstOCI, tags)
+	if err != nil {
+		return fmt.Errorf("failed to create backup report: %w", err)
+	}
+	return backup.WriteReport(dstRoot, report)
+}
+
+// outputName returns the name of the output for display.
+func (opts *backupOptions) outputName() string {
+	if opts.output == "-" {
+		return "stdout"
+	}
+	return opts.output
+}
+
+// backupStream streams a backup as a tar archive to a file or stdout.
+type backupStream struct {
+	*backup.TarTarget
+
+	output     *archiveOutput // nil if streaming to stdout
+	compressor io.WriteCloser
+	encryptor  io.WriteCloser // nil if not encrypted
+	written    *countWriter
+	closed     bool
+}
+
+// newBackupStream creates a backup stream writing to the file at path, or to
+// stdout if path is "-". The archive is split into volumes if volumeSize is
+// positive, and encrypted to the recipients if any.
+func newBackupStream(path string, stdout io.Writer, compression orasio.Compression, volumeSize int64, recipients []age.Recipient) (*backupStream, error) {
+	stream := &backupStream{}
+	out := stdout
+	if path != "-" {
+		output, err := createArchiveOutput(path, volumeSize)
+		if err != nil {
+			return nil, err
+		}
+		stream.output = output
+		out = output
+	}
+	stream.written = &countWriter{w: out}
+	var archive io.Writer = stream.written
+	if len(recipients) > 0 {
+		encryptor, err := backup.NewEncryptWriter(stream.written, recipients)
+		if err != nil {
+			stream.abort(nil)
+			return nil, err
+		}
+		stream.encryptor = encryptor
+		archive = encryptor
+	}
+	compressor, err := orasio.NewCompressWriter(archive, compression)
+	if err != nil {
+		stream.abort(nil)
+		return nil, err
+	}
+	stream.compressor = compressor
+	stream.TarTarget = backup.NewTarTarget(compressor)
+	return stream, nil
+}
+
+// Close completes the tar archive and returns the number of bytes written.
+func (s *backupStream) Close() (int64, error) {
+	s.closed = true
+	if err := s.TarTarget.Close(); err != nil {
+		s.abort(nil)
+		return 0, err
+	}
+	if err := s.compressor.Close(); err != nil {
+		s.abort(nil)
+		return 0, err
+	}
+	if s.encryptor != nil {
+		if err := s.encryptor.Close(); err != nil {
+			s.abort(nil)
+			return 0, err
+		}
+	}
+	if s.output != nil {
+		if err := s.output.Close(); err != nil {
+			return 0, err
+		}
+	}
+	return s.written.n, nil
+}
+
+// abort closes and removes the incomplete output file.
+func (s *backupStream) abort(logger logrus.FieldLogger) {
+	s.closed = true
+	if s.output == nil {
+		return
+	}
+	s.output.abort(logger)
+}
+
+// onVolumeCompleted sets the callback invoked when a volume of the split
+// archive is completely written.
+func (s *backupStream) onVolumeCompleted(fn func(path string, size int64) error) {
+	if s.output != nil && s.output.volumes != nil {
+		s.output.volumes.OnVolumeCompleted = fn
+	}
+}
+
+// archiveOutput is the output file of a tar archive, or the volumes of a
+// split tar archive. The output is staged next to the output path and moved
+// to the output path only when closed, so that a failed backup does not
+// overwrite a previous archive.
+type archiveOutput struct {
+	io.WriteCloser
+
+	path    string
+	staging string               // the directory staging the output file
+	volumes *backup.VolumeWriter // nil if the archive is not split
+}
+
+// createArchiveOutput creates the output file staged for path, or the volume
+// writer splitting the archive at path if volumeSize is positive.
+func createArchiveOutput(path string, volumeSize int64) (*archiveOutput, error) {
+	if volumeSize > 0 {
+		volumes, err := backup.NewVolumeWriter(path, volumeSize)
+		if err != nil {
+			return nil, err
+		}
+		return &archiveOutput{WriteCloser: volumes, path: path, volumes: volumes}, nil
+	}
+	// stage in a directory on the same file system so that the output file
+	// can be renamed, and is created with the permissions of os.Create
+	staging, err := os.MkdirTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file %s: %w", path, err)
+	}
+	fp, err := os.Create(filepath.Join(staging, filepath.Base(path)))
+	if err != nil {
+		_ = os.RemoveAll(staging)
+		return nil, fmt.Errorf("failed to create output file %s: %w", path, err)
+	}
+	return &archiveOutput{WriteCloser: fp, path: path, staging: staging}, nil
+}
+
+// Close completes the output and moves it to the output path.
+func (o *archiveOutput) Close() (closeErr error) {
+	if o.volumes != nil {
+		return o.volumes.Close()
+	}
+	defer func() {
+		if err := os.RemoveAll(o.staging); closeErr == nil {
+			closeErr = err
+		}
+	}()
+	if err := o.WriteCloser.Close(); err != nil {
+		return err
+	}
+	return os.Rename(filepath.Join(o.staging, filepath.Base(o.path)), o.path)
+}
+
+// abort closes and removes the incomplete output, leaving the file at the
+// output path intact.
+func (o *archiveOutput) abort(logger logrus.FieldLogger) {
+	if o.volumes != nil {
+		if err := o.volumes.Abort(); err != nil && logger != nil {
+			logger.Debugf("failed to remove volumes of %s: %v", o.path, err)
+		}
+		return
+	}
+	_ = o.WriteCloser.Close()
+	if err := os.RemoveAll(o.staging); err != nil && logger != nil {
+		logger.Debugf("failed to remove incomplete output file of %s: %v", o.path, err)
+	}
+}
+
+// countWriter counts the bytes written to the underlying writer.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write implements io.Writer.
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// parseOutputFormat parses the output format and the compression of the
+// output tar archive.
+func (opts *backupOptions) parseOutputFormat() error {
+	output := opts.output
+	if opts.Encryption.Enabled() {
+		// encrypted backups are always tar archives
+		output = strings.TrimSuffix(output, backup.EncryptedExtension)
+	}
+	compression, isTar := orasio.CompressionFromPath(output)
+	if opts.output == "-" || opts.Encryption.Enabled() {
+		// stream a tar archive to stdout, or encrypt the tar archive
+		isTar = true
+	}
+	if opts.compress != "" {
+		flagCompression, err := orasio.ParseCompression(opts.compress)
+		if err != nil {
+			return err
+		}
+		if compression != orasio.CompressionNone && compression != flagCompression {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("the compression %q conflicts with the extension of the output path %q", opts.compress, opts.output),
+				Recommendation: "Remove the --compress flag or change the extension of the output path.",
+			}
+		}
+		compression = flagCompression
+		isTar = true
+	}
+	if isTar {
+		opts.outputFormat = outputFormatTar
+		opts.compression = compression
+	} else {
+		opts.outputFormat = outputFormatDir
+	}
+	return nil
+}
+
+// parseVolumeSize parses the size of the volumes to split the output tar
+// archive into.
+func (opts *backupOptions) parseVolumeSize() error {
+	if opts.volumeSize == "" {
+		return nil
+	}
+	if opts.outputFormat != outputFormatTar || opts.output == "-" {
+		return &oerrors.Error{
+			Err:            errors.New("--volume-size can only be used when the output is a tar archive file"),
+			Recommendation: `Specify an output path ending with ".tar", ".tar.gz", ".tgz" or ".tar.zst", or use the --compress flag.`,
+		}
+	}
+	size, err := parseByteSize(opts.volumeSize)
+	if err != nil {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid volume size %q: %w", opts.volumeSize, err),
+			Recommendation: "Specify the size in bytes or with a unit, e.g. 4GiB or 500MB.",
+		}
+	}
+	opts.volumeBytes = size
+	return nil
+}
+
+// byteSizeUnits maps the units of a byte size to their multipliers.
+var byteSizeUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"kb":  1000,
+	"mb":  1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"k":   1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gib": 1 << 30,
+	"t":   1 << 40,
+	"tib": 1 << 40,
+}
+
+// parseByteSize parses a positive byte size with an optional unit, e.g.
+// "4GiB" or "500MB".
+func parseByteSize(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	i := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(value)
+	}
+	number, unit := value[:i], strings.ToLower(strings.TrimSpace(value[i:]))
+	multiplier, ok := byteSizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", value[i:])
+	}
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", number)
+	}
+	size := n * float64(multiplier)
+	if size < 1 {
+		return 0, errors.New("the size must be at least 1 byte")
+	}
+	if size >= math.MaxInt64 {
+		return 0, errors.New("the size is too large")
+	}
+	return int64(size), nil
+}
+
+// parseNamespace parses the namespace to back up and checks that it is in the
+// same registry as the other repositories to back up.
+func (opts *backupOptions) parseNamespace() error {
+	hostname, namespace, err := repository.ParseRemoteRepository(opts.namespace)
+	if err != nil {
+		return fmt.Errorf("invalid namespace %q: %w", opts.namespace, err)
+	}
+	if namespace == "" {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("backing up all repositories in registry %q is not supported", hostname),
+			Recommendation: fmt.Sprintf("Specify a namespace in the form of %s/<namespace> or list the repositories to back up.", hostname),
+		}
+	}
+	if opts.registry != "" && opts.registry != hostname {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("namespace %q is not in registry %q", opts.namespace, opts.registry),
+			Recommendation: "Create a separate backup for each registry.",
+		}
+	}
+	opts.registry = hostname
+	opts.namespace = namespace
+	return nil
+}
+
+// appendNamespaceSources appends the repositories under namespace in the
+// registry reg named hostname to sources, skipping the repositories already in
+// sources.
+func appendNamespaceSources(ctx context.Context, reg registry.Registry, hostname, namespace string, sources []backupSource) ([]backupSource, error) {
+	listed := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		listed[source.repository] = true
+	}
+	err := reg.Repositories(ctx, "", func(repos []string) error {
+		for _, repo := range repos {
+			if repo != namespace && !strings.HasPrefix(repo, namespace+"/") {
+				continue
+			}
+			repository := hostname + "/" + repo
+			if !listed[repository] {
+				listed[repository] = true
+				sources = append(sources, backupSource{repository: repository})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories under namespace %q: %w", namespace, err)
+	}
+	return sources, nil
+}
+
+// checkLayoutSourceFlags checks the flags which are not applicable to OCI
+// image layout sources.
+func (opts *backupOptions) checkLayoutSourceFlags() error {
+	if opts.namespace != "" {
+		return &oerrors.Error{
+			Err:            errors.New("--namespace cannot be used with --oci-layout"),
+			Recommendation: "Specify the paths of the OCI image layouts to back up.",
+		}
+	}
+	if opts.fullReference {
+		return &oerrors.Error{
+			Err:            errors.New("--full-reference cannot be used with --oci-layout"),
+			Recommendation: "The references in OCI image layouts are backed up as is.",
+		}
+	}
+	return nil
+}
+
+// newSourceTarget returns the target to back up src from, which is either a
+// repository or an OCI image layout.
+func (opts *backupOptions) newSourceTarget(ctx context.Context, src backupSource, logger logrus.FieldLogger) (option.ReadOnlyGraphTagFinderTarget, error) {
+	target := option.Target{
+		Remote:       opts.Remote,
+		RawReference: src.repository,
+		Type:         option.TargetTypeRemote,
+		Path:         src.repository,
+	}
+	if opts.ociLayout {
+		target.Type = option.TargetTypeOCILayout
+	}
+	return target.NewReadonlyTarget(ctx, opts.Common, logger)
 }
 
 // backupTag copies the artifact identified by the tag from src to dst.
@@ -294,21 +1058,17 @@ func countReferrers(ctx context.Context, target oras.ReadOnlyGraphTarget, tag st
 		return 0, fmt.Errorf("failed to count referrers for tag %q, digest %q: %w", tag, root.Digest.String(), err)
 	}
 	referrerCount := len(referrers)
-	if root.MediaType != ocispec.MediaTypeImageIndex && root.MediaType != docker.MediaTypeManifestList {
-		// If the root is not an image index or manifest list, we have counted all referrers
-		return referrerCount, nil
-	}
 
-	// count referrers of children manifests
-	manifestBytes, err := content.FetchAll(ctx, target, root)
+	// count referrers of children manifests if the root is an image index or
+	// manifest list
+	children, err := graph.IndexChildren(ctx, target, root)
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch content of tag %q, digest %q: %w", tag, root.Digest.String(), err)
+		return 0, fmt.Errorf("failed to fetch children manifests of tag %q, digest %q: %w", tag, root.Digest.String(), err)
 	}
-	var index ocispec.Index
-	if err = json.Unmarshal(manifestBytes, &index); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal index for tag %q, digest %q: %w", tag, root.Digest.String(), err)
+	if len(children) == 0 {
+		return referrerCount, nil
 	}
-	childrenReferrers, err := graph.RecursiveFindReferrers(ctx, target, index.Manifests, extCopyGraphOpts)
+	childrenReferrers, err := graph.RecursiveFindReferrers(ctx, target, children, extCopyGraphOpts)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count referrers for children manifests of tag %q, digest %q: %w", tag, root.Digest.String(), err)
 	}
@@ -316,6 +1076,115 @@ func countReferrers(ctx context.Context, target oras.ReadOnlyGraphTarget, tag st
 	return referrerCount, nil
 }
 
+// extractArchive extracts the tar archive at path, which may be compressed,
+// encrypted or split into volumes, into dir. An encrypted archive is
+// decrypted with the identities as a stream, so that the plaintext is only
+// written into dir.
+func extractArchive(path string, dir string, identities []age.Identity) error {
+	fp, err := openArchive(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = fp.Close()
+	}()
+	dr, err := backup.NewDecryptReader(fp, identities)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	rc, err := orasio.NewDecompressReader(dr)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+	if err := orasio.ExtractTarDirectory(rc, dir); err != nil {
+		return fmt.Errorf("failed to extract %s: %w", path, err)
+	}
+	return nil
+}
+
+// openArchive opens the tar archive at path. If path is a volume of a split
+// archive, all volumes are read in sequence.
+func openArchive(path string) (io.ReadCloser, error) {
+	if base, ok := backup.VolumeBase(path); ok {
+		return backup.OpenVolumes(base)
+	}
+	return os.Open(path)
+}
+
+// listLayoutTags returns all tags in the OCI image layout and the descriptors
+// they point to.
+func listLayoutTags(ctx context.Context, store *oci.Store) (map[string]ocispec.Descriptor, error) {
+	tagged := make(map[string]ocispec.Descriptor)
+	if err := store.Tags(ctx, "", func(tags []string) error {
+		for _, tag := range tags {
+			desc, err := store.Resolve(ctx, tag)
+			if err != nil {
+				return fmt.Errorf("failed to resolve tag %q: %w", tag, err)
+			}
+			tagged[tag] = desc
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return tagged, nil
+}
+
+// pruneTags removes the tags of the previous backup that are not in
+// currentTags. The content previously pointed by pruned or moved tags is
+// deleted unless it is still referenced by another tag or an index.
+func pruneTags(ctx context.Context, store *oci.Store, previousTags map[string]ocispec.Descriptor, currentTags []string) ([]backup.TagChange, error) {
+	// digests referenced by the current tags
+	inUse := make(map[digest.Digest]bool)
+	current := make(map[string]bool, len(currentTags))
+	for _, tag := range currentTags {
+		current[tag] = true
+		if desc, err := store.Resolve(ctx, tag); err == nil {
+			inUse[desc.Digest] = true
+		}
+	}
+
+	var stale []string
+	for tag := range previousTags {
+		if !current[tag] {
+			stale = append(stale, tag)
+		}
+	}
+	slices.Sort(stale)
+	pruned := make([]backup.TagChange, 0, len(stale))
+	for _, tag := range stale {
+		if err := store.Untag(ctx, tag); err != nil {
+			return nil, err
+		}
+		pruned = append(pruned, backup.TagChange{Tag: tag, PreviousDigest: previousTags[tag].Digest})
+	}
+
+	// delete the content no longer referenced by any tag
+	for _, tag := range slices.Sorted(maps.Keys(previousTags)) {
+		desc := previousTags[tag]
+		if inUse[desc.Digest] {
+			continue
+		}
+		inUse[desc.Digest] = true // delete once even if tagged multiple times
+		predecessors, err := store.Predecessors(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(predecessors, descriptor.IsIndex) {
+			// the manifest is a child of an index which is still backed up
+			continue
+		}
+		// deleting the manifest also removes its referrers and dangling blobs
+		if err := store.Delete(ctx, descriptor.Plain(desc)); err != nil {
+			return nil, fmt.Errorf("failed to delete content of tag %q: %w", tag, err)
+		}
+	}
+	return pruned, nil
+}
+
 // finalizeBackupOutput finalizes the backup output by removing temporary directories and exporting to a tar archive if needed.
 func finalizeBackupOutput(dstRoot string, opts *backupOptions, logger logrus.FieldLogger, metadataHandler metadata.BackupHandler) (returnErr error) {
 	// Remove ingest dir for a cleaner output
@@ -332,33 +1201,45 @@ func finalizeBackupOutput(dstRoot string, opts *backupOptions, logger logrus.Fie
 	if err := metadataHandler.OnTarExporting(opts.output); err != nil {
 		return err
 	}
-	tarFile, err := os.Create(opts.output)
+	output, err := createArchiveOutput(opts.output, opts.volumeBytes)
 	if err != nil {
-		return fmt.Errorf("failed to create output file %s: %w", opts.output, err)
+		return err
 	}
-	defer func() {
-		err := tarFile.Close()
-		if returnErr == nil {
-			returnErr = err
-		}
-	}()
-	if err := orasio.TarDirectory(tarFile, dstRoot); err != nil {
+	if output.volumes != nil {
+		output.volumes.OnVolumeCompleted = metadataHandler.OnVolumeExported
+	}
+	written := &countWriter{w: output}
+	if err := writeBackupArchive(written, dstRoot, opts.compression); err != nil {
 		// remove the output file in case of error
-		if err := os.Remove(opts.output); err != nil && !errors.Is(err, fs.ErrNotExist) {
-			logger.Debugf("failed to remove output file %s: %v", opts.output, err)
-		}
+		output.abort(logger)
+		return fmt.Errorf("failed to create tar archive at %s: %w", opts.output, err)
+	}
+	if err := output.Close(); err != nil {
+		output.abort(logger)
 		return fmt.Errorf("failed to create tar archive at %s: %w", opts.output, err)
 	}
-	fi, err := os.Stat(opts.output)
+	return metadataHandler.OnTarExported(opts.output, written.n)
+}
+
+// writeBackupArchive writes the tar archive of dir to w with the compression.
+func writeBackupArchive(w io.Writer, dir string, compression orasio.Compression) (returnErr error) {
+	cw, err := orasio.NewCompressWriter(w, compression)
 	if err != nil {
-		return fmt.Errorf("failed to stat output file %s: %w", opts.output, err)
+		return err
 	}
-	return metadataHandler.OnTarExported(opts.output, fi.Size())
+	defer func() {
+		if err := cw.Close(); returnErr == nil {
+			returnErr = err
+		}
+	}()
+	return orasio.TarDirectory(cw, dir)
 }
 
 // resolveTags resolves tags to their descriptors.
+// If no tag is specified, all tags in the repository selected by the filter
+// are resolved.
 // It returns the resolved tags and their corresponding descriptors.
-func resolveTags(ctx context.Context, target oras.ReadOnlyTarget, specifiedTags []string) ([]string, []ocispec.Descriptor, error) {
+func resolveTags(ctx context.Context, target oras.ReadOnlyTarget, specifiedTags []string, filter *backup.TagFilter) ([]string, []ocispec.Descriptor, error) {
 	var descs []ocispec.Descriptor
 	resolve := func(tags []string) error {
 		for _, tag := range tags {
@@ -386,6 +1267,9 @@ func resolveTags(ctx context.Context, target oras.ReadOnlyTarget, specifiedTags
 		return nil, nil, errTagListNotSupported
 	}
 	if err := tagLister.Tags(ctx, "", func(gotTags []string) error {
+		gotTags = slices.DeleteFunc(gotTags, func(tag string) bool {
+			return !filter.MatchName(tag)
+		})
 		if err := resolve(gotTags); err != nil {
 			return err
 		}
@@ -394,7 +1278,169 @@ func resolveTags(ctx context.Context, target oras.ReadOnlyTarget, specifiedTags
 	}); err != nil {
 		return nil, nil, fmt.Errorf("failed to find tags: %w", err)
 	}
-	return tags, descs, nil
+	return filter.Select(ctx, target, tags, descs)
+}
+
+// parseTagFilter parses the tag filter flags.
+func (opts *backupOptions) parseTagFilter() error {
+	if opts.latest < 0 {
+		return fmt.Errorf("invalid value %d for --latest, it must not be negative", opts.latest)
+	}
+	filter := &backup.TagFilter{
+		Latest: opts.latest,
+	}
+	if opts.tagRegex != "" {
+		pattern, err := regexp.Compile(opts.tagRegex)
+		if err != nil {
+			return fmt.Errorf("invalid regular expression %q for --tag-regex: %w", opts.tagRegex, err)
+		}
+		filter.Pattern = pattern
+	}
+	if opts.semver != "" {
+		constraint, err := semver.NewConstraint(opts.semver)
+		if err != nil {
+			return fmt.Errorf("invalid semantic version constraint %q for --semver: %w", opts.semver, err)
+		}
+		filter.Constraint = constraint
+	}
+	if opts.since != "" {
+		since, err := parseSince(opts.since)
+		if err != nil {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("invalid date %q for --since: %w", opts.since, err),
+				Recommendation: `Use a date in the format of RFC 3339, e.g. "2025-01-02T15:04:05Z", or YYYY-MM-DD, e.g. "2025-01-02".`,
+			}
+		}
+		filter.Since = since
+	}
+	if filter.IsEmpty() {
+		return nil
+	}
+
+	// tag filters only apply to the tags listed from the repositories
+	for _, source := range opts.sources {
+		if len(source.tags) > 0 {
+			return &oerrors.Error{
+				Err:            errors.New("tag filters cannot be used when tags are specified"),
+				Recommendation: fmt.Sprintf("Remove the tags of %q to back up the tags selected by the filters.", source.repository),
+			}
+		}
+	}
+	opts.tagFilter = filter
+	return nil
+}
+
+// parseSince parses a date in the format of RFC 3339 or YYYY-MM-DD.
+func parseSince(value string) (time.Time, error) {
+	if len(value) == len(time.DateOnly) {
+		return time.Parse(time.DateOnly, value)
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// readReferenceFile reads the artifact references listed in the file at path,
+// one reference per line. Empty lines and lines starting with "#" are ignored.
+func readReferenceFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reference file: %w", err)
+	}
+	var refs []string
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		refs = append(refs, line)
+	}
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("no artifact references found in %q", path)
+	}
+	return refs, nil
+}
+
+// parseBackupSources parses the artifact references into the repositories to
+// back up. All repositories must be in the same registry, which is returned
+// along with the repositories.
+func parseBackupSources(artifactRefs []string) (string, []backupSource, error) {
+	var registryName string
+	sources := make([]backupSource, 0, len(artifactRefs))
+	seen := make(map[string]bool, len(artifactRefs))
+	for _, artifactRef := range artifactRefs {
+		repo, tags, err := parseArtifactReferences(artifactRef)
+		if err != nil {
+			return "", nil, err
+		}
+		if seen[repo] {
+			return "", nil, &oerrors.Error{
+				Err:            fmt.Errorf("repository %q is specified more than once", repo),
+				Recommendation: fmt.Sprintf("Specify the tags of %q in a single reference, e.g. %s:<tag1>,<tag2>", repo, repo),
+			}
+		}
+		seen[repo] = true
+		ref, err := registry.ParseReference(repo)
+		if err != nil {
+			return "", nil, err
+		}
+		if registryName == "" {
+			registryName = ref.Registry
+		} else if ref.Registry != registryName {
+			return "", nil, &oerrors.Error{
+				Err:            fmt.Errorf("repository %q is not in registry %q", repo, registryName),
+				Recommendation: "Create a separate backup for each registry.",
+			}
+		}
+		sources = append(sources, backupSource{repository: repo, tags: tags})
+	}
+	return registryName, sources, nil
+}
+
+// parseLayoutSources parses the OCI image layout references in the form of
+// <path>[:<ref1>[,<ref2>...]] into the OCI image layouts to back up.
+func parseLayoutSources(layoutRefs []string) ([]backupSource, error) {
+	sources := make([]backupSource, 0, len(layoutRefs))
+	seen := make(map[string]bool, len(layoutRefs))
+	for _, layoutRef := range layoutRefs {
+		path, tags, err := parseLayoutReferences(layoutRef)
+		if err != nil {
+			return nil, err
+		}
+		if seen[path] {
+			return nil, &oerrors.Error{
+				Err:            fmt.Errorf("OCI image layout %q is specified more than once", path),
+				Recommendation: fmt.Sprintf("Specify the tags of %q in a single reference, e.g. %s:<tag1>,<tag2>", path, path),
+			}
+		}
+		seen[path] = true
+		sources = append(sources, backupSource{repository: path, tags: tags})
+	}
+	return sources, nil
+}
+
+// parseLayoutReferences parses the input string into the path of an OCI image
+// layout and a slice of tags.
+func parseLayoutReferences(layoutRefs string) (string, []string, error) {
+	if layoutRefs == "" {
+		return "", nil, errors.New("OCI image layout reference cannot be empty")
+	}
+	if strings.ContainsRune(layoutRefs, '@') {
+		return "", nil, fmt.Errorf("digest references are not supported: %q", layoutRefs)
+	}
+	path, ref, err := fileref.Parse(layoutRefs, "")
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid reference %q: %w", layoutRefs, err)
+	}
+	if ref == "" {
+		if strings.HasSuffix(layoutRefs, ":") {
+			return "", nil, fmt.Errorf("empty tag in reference %q", layoutRefs)
+		}
+		return path, nil, nil
+	}
+	tags := strings.Split(ref, ",")
+	if slices.Contains(tags, "") {
+		return "", nil, fmt.Errorf("empty tag in reference %q", layoutRefs)
+	}
+	return path, tags, nil
 }
 
 // parseArtifactReferences parses the input string into a repository
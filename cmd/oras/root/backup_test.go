@@ -16,6 +16,7 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -29,14 +30,19 @@ import (
 	"testing"
 	"time"
 
+	"filippo.io/age"
+	"github.com/opencontainers/go-digest"
 	"github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sirupsen/logrus"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/internal/backup"
+	orasio "oras.land/oras/internal/io"
 )
 
 func TestParseArtifactReferences(t *testing.T) {
@@ -405,7 +411,7 @@ func Test_resolveTags(t *testing.T) {
 		}
 		repo.PlainHTTP = true
 
-		tags, descs, err := resolveTags(ctx, repo, []string{"v1", "v2"})
+		tags, descs, err := resolveTags(ctx, repo, []string{"v1", "v2"}, nil)
 		if err != nil {
 			t.Fatalf("resolveTags() error = %v, wantErr nil", err)
 		}
@@ -437,7 +443,7 @@ func Test_resolveTags(t *testing.T) {
 		}
 		repo.PlainHTTP = true
 
-		_, _, err = resolveTags(ctx, repo, []string{"non-existent"})
+		_, _, err = resolveTags(ctx, repo, []string{"non-existent"}, nil)
 		if wantErr := errdef.ErrNotFound; !errors.Is(err, wantErr) {
 			t.Errorf("resolveTags() error = %v, wantErr %v", err, wantErr)
 		}
@@ -460,7 +466,7 @@ func Test_resolveTags(t *testing.T) {
 		}
 		repo.PlainHTTP = true
 
-		tags, descs, err := resolveTags(ctx, repo, nil)
+		tags, descs, err := resolveTags(ctx, repo, nil, nil)
 		if err != nil {
 			t.Fatalf("resolveTags() error = %v, wantErr nil", err)
 		}
@@ -493,7 +499,7 @@ func Test_resolveTags(t *testing.T) {
 		}
 		repo.PlainHTTP = true
 
-		_, _, err = resolveTags(ctx, repo, nil)
+		_, _, err = resolveTags(ctx, repo, nil, nil)
 		if err == nil {
 			t.Error("resolveTags() error = nil, wantErr not nil")
 		}
@@ -518,7 +524,7 @@ func Test_resolveTags(t *testing.T) {
 		}
 		repo.PlainHTTP = true
 
-		_, _, err = resolveTags(ctx, repo, nil)
+		_, _, err = resolveTags(ctx, repo, nil, nil)
 		if wantErr := errdef.ErrNotFound; !errors.Is(err, wantErr) {
 			t.Errorf("resolveTags() error = %v, wantErr %v", err, wantErr)
 		}
@@ -539,7 +545,7 @@ func Test_resolveTags(t *testing.T) {
 		}
 		repo.PlainHTTP = true
 
-		tags, descs, err := resolveTags(ctx, repo, nil)
+		tags, descs, err := resolveTags(ctx, repo, nil, nil)
 		if err != nil {
 			t.Fatalf("resolveTags() error = %v, wantErr nil", err)
 		}
@@ -554,7 +560,7 @@ func Test_resolveTags(t *testing.T) {
 	t.Run("target does not support tag listing", func(t *testing.T) {
 		// Use a simple mock that doesn't implement registry.TagLister
 		target := memory.New()
-		_, _, err := resolveTags(ctx, target, nil)
+		_, _, err := resolveTags(ctx, target, nil, nil)
 		if wantErr := errTagListNotSupported; !errors.Is(err, wantErr) {
 			t.Errorf("resolveTags() error = %v, wantErr %v", err, wantErr)
 		}
@@ -676,6 +682,667 @@ func Test_countReferrers(t *testing.T) {
 	})
 }
 
+func Test_parseOutputFormat(t *testing.T) {
+	tests := []struct {
+		name            string
+		output          string
+		compress        string
+		wantFormat      outputFormat
+		wantCompression orasio.Compression
+		wantErr         bool
+	}{
+		{name: "directory", output: "backup", wantFormat: outputFormatDir},
+		{name: "tar archive", output: "backup.tar", wantFormat: outputFormatTar},
+		{name: "gzip archive", output: "backup.tar.gz", wantFormat: outputFormatTar, wantCompression: orasio.CompressionGzip},
+		{name: "tgz archive", output: "backup.tgz", wantFormat: outputFormatTar, wantCompression: orasio.CompressionGzip},
+		{name: "zstd archive", output: "backup.tar.zst", wantFormat: outputFormatTar, wantCompression: orasio.CompressionZstd},
+		{name: "compress flag", output: "backup", compress: "zstd", wantFormat: outputFormatTar, wantCompression: orasio.CompressionZstd},
+		{name: "compress flag with tar extension", output: "backup.tar", compress: "gzip", wantFormat: outputFormatTar, wantCompression: orasio.CompressionGzip},
+		{name: "compress flag matching extension", output: "backup.tgz", compress: "gzip", wantFormat: outputFormatTar, wantCompression: orasio.CompressionGzip},
+		{name: "compress flag conflicting extension", output: "backup.tar.gz", compress: "zstd", wantErr: true},
+		{name: "unsupported compression", output: "backup.tar", compress: "bzip2", wantErr: true},
+		{name: "stdout", output: "-", wantFormat: outputFormatTar},
+		{name: "stdout with compression", output: "-", compress: "gzip", wantFormat: outputFormatTar, wantCompression: orasio.CompressionGzip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &backupOptions{output: tt.output, compress: tt.compress}
+			err := opts.parseOutputFormat()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOutputFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.outputFormat != tt.wantFormat {
+				t.Errorf("parseOutputFormat() format = %v, want %v", opts.outputFormat, tt.wantFormat)
+			}
+			if opts.compression != tt.wantCompression {
+				t.Errorf("parseOutputFormat() compression = %v, want %v", opts.compression, tt.wantCompression)
+			}
+		})
+	}
+}
+
+func Test_parseVolumeSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		volumeSize string
+		want       int64
+		wantErr    bool
+	}{
+		{name: "not split", output: "backup.tar"},
+		{name: "bytes", output: "backup.tar", volumeSize: "1024", want: 1024},
+		{name: "binary unit", output: "backup.tar", volumeSize: "4GiB", want: 4 << 30},
+		{name: "decimal unit", output: "backup.tar.gz", volumeSize: "500MB", want: 500 * 1000 * 1000},
+		{name: "short unit", output: "backup.tar", volumeSize: "2k", want: 2048},
+		{name: "fractional size", output: "backup.tar", volumeSize: "1.5 KiB", want: 1536},
+		{name: "unknown unit", output: "backup.tar", volumeSize: "1XB", wantErr: true},
+		{name: "invalid number", output: "backup.tar", volumeSize: "GiB", wantErr: true},
+		{name: "zero size", output: "backup.tar", volumeSize: "0", wantErr: true},
+		{name: "too large size", output: "backup.tar", volumeSize: "8388608TiB", wantErr: true},
+		{name: "directory output", output: "backup", volumeSize: "1GiB", wantErr: true},
+		{name: "stdout output", output: "-", volumeSize: "1GiB", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &backupOptions{output: tt.output, volumeSize: tt.volumeSize}
+			if err := opts.parseOutputFormat(); err != nil {
+				t.Fatalf("parseOutputFormat() error = %v", err)
+			}
+			err := opts.parseVolumeSize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseVolumeSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if opts.volumeBytes != tt.want {
+				t.Errorf("parseVolumeSize() volume size = %d, want %d", opts.volumeBytes, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseByteSize_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "zero size", value: "0", wantErr: "the size must be at least 1 byte"},
+		{name: "fraction of a byte", value: "0.5", wantErr: "the size must be at least 1 byte"},
+		{name: "too large size", value: "8388608TiB", wantErr: "the size is too large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseByteSize(tt.value)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("parseByteSize() error = %v, wantErr %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_finalizeBackupOutput_volumes(t *testing.T) {
+	tempDir := t.TempDir()
+	dstRoot := filepath.Join(tempDir, "root")
+	if err := os.MkdirAll(dstRoot, 0755); err != nil {
+		t.Fatalf("Failed to create root dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dstRoot, "index.json"), bytes.Repeat([]byte(" "), 4096), 0644); err != nil {
+		t.Fatalf("Failed to create index.json: %v", err)
+	}
+	outputPath := filepath.Join(tempDir, "output.tar")
+	opts := &backupOptions{
+		outputFormat: outputFormatTar,
+		output:       outputPath,
+		volumeBytes:  1024,
+	}
+	if err := finalizeBackupOutput(dstRoot, opts, &mockLogger{}, &mockBackupHandler{}); err != nil {
+		t.Fatalf("finalizeBackupOutput() error = %v", err)
+	}
+	if _, err := os.Stat(outputPath); !os.IsNotExist(err) {
+		t.Errorf("Expected no single tar file at %s", outputPath)
+	}
+	index, err := backup.ReadVolumeIndex(outputPath)
+	if err != nil || index == nil {
+		t.Fatalf("ReadVolumeIndex() = %v, %v", index, err)
+	}
+	if len(index.Volumes) < 5 {
+		t.Errorf("Expected at least 5 volumes, got %d", len(index.Volumes))
+	}
+
+	extracted := t.TempDir()
+	if err := extractArchive(backup.VolumePath(outputPath, 1), extracted, nil); err != nil {
+		t.Fatalf("extractArchive() error = %v", err)
+	}
+	if fi, err := os.Stat(filepath.Join(extracted, "index.json")); err != nil || fi.Size() != 4096 {
+		t.Errorf("Expected index.json of 4096 bytes to be extracted, got %v, %v", fi, err)
+	}
+}
+
+func Test_parseOutputFormat_encrypted(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("failed to generate identity: %v", err)
+	}
+	tests := []struct {
+		name            string
+		output          string
+		wantCompression orasio.Compression
+	}{
+		{name: "age extension", output: "backup.tar.age"},
+		{name: "compressed with age extension", output: "backup.tar.gz.age", wantCompression: orasio.CompressionGzip},
+		{name: "no extension", output: "backup"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &backupOptions{output: tt.output}
+			opts.Recipients = []age.Recipient{identity.Recipient()}
+			if err := opts.parseOutputFormat(); err != nil {
+				t.Fatalf("parseOutputFormat() error = %v", err)
+			}
+			if opts.outputFormat != outputFormatTar {
+				t.Errorf("parseOutputFormat() format = %v, want %v", opts.outputFormat, outputFormatTar)
+			}
+			if opts.compression != tt.wantCompression {
+				t.Errorf("parseOutputFormat() compression = %v, want %v", opts.compression, tt.wantCompression)
+			}
+		})
+	}
+}
+
+func Test_extractArchive_encrypted(t *testing.T) {
+	ctx := context.Background()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("failed to generate identity: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "backup.tar.gz.age")
+	stream, err := newBackupStream(path, nil, orasio.CompressionGzip, 0, []age.Recipient{identity.Recipient()})
+	if err != nil {
+		t.Fatalf("newBackupStream() error = %v", err)
+	}
+	blob := []byte("hello world")
+	desc := content.NewDescriptorFromBytes("application/vnd.test", blob)
+	if err := stream.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if _, err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, blob) {
+		t.Fatal("the encrypted archive contains the plaintext blob")
+	}
+
+	if err := extractArchive(path, t.TempDir(), nil); !errors.Is(err, backup.ErrEncrypted) {
+		t.Errorf("extractArchive() error = %v, want %v", err, backup.ErrEncrypted)
+	}
+	dir := t.TempDir()
+	if err := extractArchive(path, dir, []age.Identity{identity}); err != nil {
+		t.Fatalf("extractArchive() error = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded()))
+	if err != nil || !bytes.Equal(got, blob) {
+		t.Errorf("extracted blob = %q, %v, want %q", got, err, blob)
+	}
+}
+
+func Test_backupStream_abort(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backup.tar")
+	previous := []byte("previous backup")
+	if err := os.WriteFile(path, previous, 0666); err != nil {
+		t.Fatal(err)
+	}
+	stream, err := newBackupStream(path, nil, orasio.CompressionNone, 0, nil)
+	if err != nil {
+		t.Fatalf("newBackupStream() error = %v", err)
+	}
+	blob := []byte("hello world")
+	desc := content.NewDescriptorFromBytes("application/vnd.test", blob)
+	if err := stream.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	stream.abort(nil)
+
+	// the previous archive is kept and no staged file is left
+	if got, err := os.ReadFile(path); err != nil || !bytes.Equal(got, previous) {
+		t.Errorf("output file = %q, %v, want %q", got, err, previous)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("files left after abort = %v, want the output file only", entries)
+	}
+}
+
+func TestParseBackupSources(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        []string
+		wantRegistry string
+		wantSources  []backupSource
+		wantErr      bool
+	}{
+		{
+			name:         "single repository",
+			input:        []string{"localhost:5000/repo:v1,v2"},
+			wantRegistry: "localhost:5000",
+			wantSources: []backupSource{
+				{repository: "localhost:5000/repo", tags: []string{"v1", "v2"}},
+			},
+		},
+		{
+			name:         "multiple repositories",
+			input:        []string{"localhost:5000/ns/repo1:v1", "localhost:5000/ns/repo2"},
+			wantRegistry: "localhost:5000",
+			wantSources: []backupSource{
+				{repository: "localhost:5000/ns/repo1", tags: []string{"v1"}},
+				{repository: "localhost:5000/ns/repo2"},
+			},
+		},
+		{
+			name:        "no repository",
+			input:       nil,
+			wantSources: []backupSource{},
+		},
+		{
+			name:    "different registries",
+			input:   []string{"localhost:5000/repo1", "localhost:6000/repo2"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate repositories",
+			input:   []string{"localhost:5000/repo:v1", "localhost:5000/repo:v2"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid reference",
+			input:   []string{"localhost:5000/repo@sha256:abc"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRegistry, gotSources, err := parseBackupSources(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBackupSources() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotRegistry != tt.wantRegistry {
+				t.Errorf("parseBackupSources() registry = %q, want %q", gotRegistry, tt.wantRegistry)
+			}
+			if !reflect.DeepEqual(gotSources, tt.wantSources) {
+				t.Errorf("parseBackupSources() sources = %v, want %v", gotSources, tt.wantSources)
+			}
+		})
+	}
+}
+
+func Test_parseLayoutSources(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       []string
+		wantSources []backupSource
+		wantErr     bool
+	}{
+		{
+			name:  "multiple layouts",
+			input: []string{"layout1:v1,v2", "layout2.tar", "dir/layout3:v3"},
+			wantSources: []backupSource{
+				{repository: "layout1", tags: []string{"v1", "v2"}},
+				{repository: "layout2.tar"},
+				{repository: "dir/layout3", tags: []string{"v3"}},
+			},
+		},
+		{
+			name:    "duplicate layouts",
+			input:   []string{"layout:v1", "layout:v2"},
+			wantErr: true,
+		},
+		{
+			name:    "empty tag",
+			input:   []string{"layout:v1,"},
+			wantErr: true,
+		},
+		{
+			name:    "empty tag after colon",
+			input:   []string{"layout:"},
+			wantErr: true,
+		},
+		{
+			name:    "empty path",
+			input:   []string{":v1"},
+			wantErr: true,
+		},
+		{
+			name:    "digest reference",
+			input:   []string{"layout@sha256:abc"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLayoutSources(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLayoutSources() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.wantSources) {
+				t.Errorf("parseLayoutSources() = %v, want %v", got, tt.wantSources)
+			}
+		})
+	}
+}
+
+func Test_backupOptions_newSourceTarget(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+	store, err := oci.New(path)
+	if err != nil {
+		t.Fatalf("failed to create OCI store: %v", err)
+	}
+	desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "test/artifact", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatalf("failed to pack manifest: %v", err)
+	}
+	for _, tag := range []string{"v1", "v2"} {
+		if err := store.Tag(ctx, desc, tag); err != nil {
+			t.Fatalf("failed to tag %q: %v", tag, err)
+		}
+	}
+
+	opts := &backupOptions{ociLayout: true}
+	target, err := opts.newSourceTarget(ctx, backupSource{repository: path}, logrus.New())
+	if err != nil {
+		t.Fatalf("newSourceTarget() error = %v", err)
+	}
+	tags, roots, err := resolveTags(ctx, target, nil, nil)
+	if err != nil {
+		t.Fatalf("resolveTags() error = %v", err)
+	}
+	if want := []string{"v1", "v2"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("resolveTags() tags = %v, want %v", tags, want)
+	}
+	for _, root := range roots {
+		if root.Digest != desc.Digest {
+			t.Errorf("resolveTags() root = %v, want %v", root.Digest, desc.Digest)
+		}
+	}
+
+	if _, err := opts.newSourceTarget(ctx, backupSource{repository: filepath.Join(path, "missing")}, logrus.New()); err == nil {
+		t.Error("newSourceTarget() expects error for a missing OCI layout")
+	}
+}
+
+func Test_parseTagFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      backupOptions
+		wantEmpty bool
+		wantSince time.Time
+		wantErr   bool
+	}{
+		{name: "no filter", wantEmpty: true},
+		{name: "regex", opts: backupOptions{tagRegex: "^v1"}},
+		{name: "invalid regex", opts: backupOptions{tagRegex: "("}, wantErr: true},
+		{name: "semver", opts: backupOptions{semver: ">=1.2 <2"}},
+		{name: "invalid semver", opts: backupOptions{semver: "not a range"}, wantErr: true},
+		{name: "latest", opts: backupOptions{latest: 3}},
+		{name: "no latest limit", opts: backupOptions{latest: 0}, wantEmpty: true},
+		{name: "negative latest", opts: backupOptions{latest: -1}, wantErr: true},
+		{name: "since date", opts: backupOptions{since: "2025-01-02"}, wantSince: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "since RFC 3339", opts: backupOptions{since: "2025-01-02T03:04:05Z"}, wantSince: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{name: "invalid since", opts: backupOptions{since: "yesterday"}, wantErr: true},
+		{
+			name: "filter with specified tags",
+			opts: backupOptions{
+				tagRegex: "^v1",
+				sources:  []backupSource{{repository: "localhost:5000/repo", tags: []string{"v1"}}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			err := opts.parseTagFilter()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTagFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := opts.tagFilter.IsEmpty(); got != tt.wantEmpty {
+				t.Fatalf("parseTagFilter() empty filter = %v, want %v", got, tt.wantEmpty)
+			}
+			if !tt.wantSince.IsZero() && !opts.tagFilter.Since.Equal(tt.wantSince) {
+				t.Errorf("parseTagFilter() since = %v, want %v", opts.tagFilter.Since, tt.wantSince)
+			}
+		})
+	}
+}
+
+func Test_parseTagFilter_invalidSince(t *testing.T) {
+	for _, since := range []string{"yesterday", "2025-13-02", "2025-01-02T25:00:00Z"} {
+		opts := backupOptions{since: since}
+		err := opts.parseTagFilter()
+		// the reason of the parse failure is reported
+		var parseErr *time.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("parseTagFilter() error = %v, want wrapping %T", err, parseErr)
+		}
+	}
+}
+
+func Test_resolveTags_filter(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create OCI store: %v", err)
+	}
+	manifest := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[]}`)
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(manifest),
+		Size:      int64(len(manifest)),
+	}
+	if err := store.Push(ctx, desc, bytes.NewReader(manifest)); err != nil {
+		t.Fatalf("failed to push manifest: %v", err)
+	}
+	for _, tag := range []string{"v1.0.0", "v1.2.0", "v2.0.0", "latest"} {
+		if err := store.Tag(ctx, desc, tag); err != nil {
+			t.Fatalf("failed to tag manifest: %v", err)
+		}
+	}
+
+	opts := backupOptions{semver: ">=1.2"}
+	if err := opts.parseTagFilter(); err != nil {
+		t.Fatalf("parseTagFilter() error = %v", err)
+	}
+	tags, descs, err := resolveTags(ctx, store, nil, opts.tagFilter)
+	if err != nil {
+		t.Fatalf("resolveTags() error = %v", err)
+	}
+	if want := []string{"v1.2.0", "v2.0.0"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("resolveTags() tags = %v, want %v", tags, want)
+	}
+	if len(descs) != len(tags) {
+		t.Errorf("resolveTags() got %d descriptors, want %d", len(descs), len(tags))
+	}
+}
+
+func Test_readReferenceFile(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "refs.txt")
+		content := "# repositories to back up\nlocalhost:5000/repo1:v1\n\n  localhost:5000/repo2  \n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write reference file: %v", err)
+		}
+		got, err := readReferenceFile(path)
+		if err != nil {
+			t.Fatalf("readReferenceFile() error = %v", err)
+		}
+		want := []string{"localhost:5000/repo1:v1", "localhost:5000/repo2"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("readReferenceFile() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("no reference", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "refs.txt")
+		if err := os.WriteFile(path, []byte("# nothing\n\n"), 0644); err != nil {
+			t.Fatalf("failed to write reference file: %v", err)
+		}
+		if _, err := readReferenceFile(path); err == nil {
+			t.Error("readReferenceFile() expected error for empty file")
+		}
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		if _, err := readReferenceFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+			t.Error("readReferenceFile() expected error for missing file")
+		}
+	})
+}
+
+func Test_appendNamespaceSources(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/_catalog" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string][]string{
+			"repositories": {"apps", "apps/repo1", "apps/repo2", "apps2/repo", "apps-legacy/repo", "other/repo"},
+		})
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	reg, err := remote.NewRegistry(host)
+	if err != nil {
+		t.Fatalf("failed to create remote registry: %v", err)
+	}
+	reg.PlainHTTP = true
+
+	sources := []backupSource{
+		{repository: host + "/apps/repo1", tags: []string{"v1"}},
+		{repository: host + "/other/repo"},
+	}
+	got, err := appendNamespaceSources(ctx, reg, host, "apps", sources)
+	if err != nil {
+		t.Fatalf("appendNamespaceSources() error = %v", err)
+	}
+	want := []backupSource{
+		{repository: host + "/apps/repo1", tags: []string{"v1"}},
+		{repository: host + "/other/repo"},
+		{repository: host + "/apps"},
+		{repository: host + "/apps/repo2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("appendNamespaceSources() = %v, want %v", got, want)
+	}
+}
+
+func Test_pruneTags(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create OCI store: %v", err)
+	}
+
+	// prepare content
+	manifestDesc1, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "test/manifest1", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatalf("failed to create manifest 1: %v", err)
+	}
+	manifestDesc2, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "test/manifest2", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatalf("failed to create manifest 2: %v", err)
+	}
+	referrerDesc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "test/referrer", oras.PackManifestOptions{Subject: &manifestDesc1})
+	if err != nil {
+		t.Fatalf("failed to create referrer: %v", err)
+	}
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{manifestDesc2},
+	}
+	indexBytes, err := json.Marshal(index)
+	if err != nil {
+		t.Fatalf("failed to marshal index: %v", err)
+	}
+	indexDesc, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageIndex, indexBytes)
+	if err != nil {
+		t.Fatalf("failed to push index: %v", err)
+	}
+	for tag, desc := range map[string]ocispec.Descriptor{
+		"v1":     manifestDesc1,
+		"v1-dup": manifestDesc1,
+		"v2":     manifestDesc2,
+		"index":  indexDesc,
+		"old":    manifestDesc1,
+	} {
+		if err := store.Tag(ctx, desc, tag); err != nil {
+			t.Fatalf("failed to tag %s: %v", tag, err)
+		}
+	}
+	previousTags, err := listLayoutTags(ctx, store)
+	if err != nil {
+		t.Fatalf("listLayoutTags() error = %v", err)
+	}
+	if len(previousTags) != 5 {
+		t.Fatalf("listLayoutTags() got %d tags, want 5", len(previousTags))
+	}
+
+	t.Run("prune tag still referenced by another tag", func(t *testing.T) {
+		pruned, err := pruneTags(ctx, store, previousTags, []string{"v1", "v2", "index", "v1-dup"})
+		if err != nil {
+			t.Fatalf("pruneTags() error = %v", err)
+		}
+		want := []backup.TagChange{{Tag: "old", PreviousDigest: manifestDesc1.Digest}}
+		if !reflect.DeepEqual(pruned, want) {
+			t.Errorf("pruneTags() = %v, want %v", pruned, want)
+		}
+		if _, err := store.Resolve(ctx, "old"); !errors.Is(err, errdef.ErrNotFound) {
+			t.Errorf("tag old should be removed, got error %v", err)
+		}
+		if exists, err := store.Exists(ctx, manifestDesc1); err != nil || !exists {
+			t.Errorf("manifest 1 should be kept, exists = %v, error = %v", exists, err)
+		}
+	})
+
+	t.Run("prune tags and delete unreferenced content", func(t *testing.T) {
+		previousTags, err := listLayoutTags(ctx, store)
+		if err != nil {
+			t.Fatalf("listLayoutTags() error = %v", err)
+		}
+		pruned, err := pruneTags(ctx, store, previousTags, []string{"index"})
+		if err != nil {
+			t.Fatalf("pruneTags() error = %v", err)
+		}
+		if len(pruned) != 3 {
+			t.Fatalf("pruneTags() pruned %d tags, want 3", len(pruned))
+		}
+		// manifest 1 and its referrer are no longer referenced
+		for _, desc := range []ocispec.Descriptor{manifestDesc1, referrerDesc} {
+			if exists, err := store.Exists(ctx, desc); err != nil || exists {
+				t.Errorf("%s should be deleted, exists = %v, error = %v", desc.Digest, exists, err)
+			}
+		}
+		// manifest 2 is still referenced by the index
+		if exists, err := store.Exists(ctx, manifestDesc2); err != nil || !exists {
+			t.Errorf("manifest 2 should be kept, exists = %v, error = %v", exists, err)
+		}
+	})
+}
+
 // Mock implementations
 type mockLogger struct {
 	debugMessages []string
@@ -740,6 +1407,10 @@ func (m *mockBackupHandler) OnTarExported(_ string, _ int64) error {
 	return m.tarExportedResult
 }
 
+func (m *mockBackupHandler) OnVolumeExported(_ string, _ int64) error {
+	return nil
+}
+
 func (m *mockBackupHandler) OnTagsFound(_ []string) error {
 	return nil
 }
@@ -748,6 +1419,14 @@ func (m *mockBackupHandler) OnArtifactPulled(_ string, _ int) error {
 	return nil
 }
 
+func (m *mockBackupHandler) OnArtifactUnchanged(_ string) error {
+	return nil
+}
+
+func (m *mockBackupHandler) OnTagPruned(_ string) error {
+	return nil
+}
+
 func (m *mockBackupHandler) OnBackupCompleted(_ int, _ string, _ time.Duration) error {
 	return nil
 }
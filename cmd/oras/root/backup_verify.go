@@ -0,0 +1,127 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package root
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/backup"
+)
+
+type backupVerifyOptions struct {
+	option.Common
+	option.Format
+	option.Decryption
+
+	path string
+}
+
+func backupVerifyCmd() *cobra.Command {
+	var opts backupVerifyOptions
+	cmd := &cobra.Command{
+		Use:   "verify [flags] <path>",
+		Short: "[Experimental] Verify the integrity of a backup",
+		Long: `[Experimental] Verify the integrity of a backup, which can be either a directory or a tar archive. For a tar archive split into volumes, pass its first volume. Encrypted tar archives are decrypted with "--decrypt-identity" or "--decrypt-passphrase-stdin".
+Every blob in the backup is re-hashed, and the graph of every tag, including its referrers, is checked to be complete.
+If the backup embeds an integrity report, the tags and blobs are also checked against the report.
+The command fails if any content is missing or corrupted.
+
+Example - Verify a backup directory:
+  oras backup verify hello
+
+Example - Verify a backup tar archive:
+  oras backup verify hello.tar.gz
+
+Example - Verify an encrypted backup tar archive:
+  oras backup verify --decrypt-identity key.txt hello.tar.age
+
+Example - Verify a backup and output the result in JSON format:
+  oras backup verify --format json hello.tar
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the backup to verify"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.path = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBackupVerify(cmd, &opts)
+		},
+	}
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+func runBackupVerify(cmd *cobra.Command, opts *backupVerifyOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewBackupVerifyHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	fi, err := os.Stat(opts.path)
+	if err != nil {
+		return fmt.Errorf("failed to access backup %q: %w", opts.path, err)
+	}
+	root := opts.path
+	if !fi.IsDir() {
+		// extract the tar archive to re-hash every blob in it
+		tempDir, err := os.MkdirTemp("", "oras-backup-verify-*")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory for verification: %w", err)
+		}
+		defer func() {
+			if err := os.RemoveAll(tempDir); err != nil {
+				logger.Debugf("failed to remove temporary directory %s: %v", tempDir, err)
+			}
+		}()
+		if err := extractArchive(opts.path, tempDir, opts.Identities); err != nil {
+			if errors.Is(err, backup.ErrEncrypted) {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("failed to read backup %q: %w", opts.path, err),
+					Recommendation: "Use --decrypt-identity or --decrypt-passphrase-stdin to decrypt the archive.",
+				}
+			}
+			return &oerrors.Error{
+				Err:            fmt.Errorf("failed to read backup %q: %w", opts.path, err),
+				Recommendation: "The archive may be truncated or corrupted. Please back up the artifacts again.",
+			}
+		}
+		root = tempDir
+	}
+
+	result, err := backup.Verify(ctx, root)
+	if err != nil {
+		return fmt.Errorf("failed to verify backup %q: %w", opts.path, err)
+	}
+	if err := handler.OnVerified(opts.path, result); err != nil {
+		return err
+	}
+	if err := handler.Render(); err != nil {
+		return err
+	}
+	if len(result.Issues) > 0 {
+		return fmt.Errorf("found %d integrity issue(s) in backup %q", len(result.Issues), opts.path)
+	}
+	return nil
+}
@@ -17,7 +17,9 @@ package blob
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 
@@ -31,18 +33,40 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/status/track"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
 	"oras.land/oras/internal/progress"
+	"oras.land/oras/internal/registryutil"
 )
 
 type fetchBlobOptions struct {
 	option.Cache
 	option.Common
 	option.Descriptor
+	option.Download
 	option.Pretty
 	option.Target
 	option.Terminal
 
 	outputPath string
+	offset     int64
+	length     int64
+}
+
+// byteRange is a range of bytes of a blob.
+type byteRange struct {
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+}
+
+// partialDescriptor is the descriptor of a blob with the range fetched.
+type partialDescriptor struct {
+	ocispec.Descriptor
+	Partial byteRange `json:"partial"`
+}
+
+// isPartial returns true if only a range of the blob is fetched.
+func (opts *fetchBlobOptions) isPartial() bool {
+	return opts.offset > 0 || opts.length > 0
 }
 
 func fetchCmd() *cobra.Command {
@@ -69,6 +93,15 @@ Example - Fetch and print a blob from OCI image layout folder 'layout-dir':
 
 Example - Fetch and print a blob from OCI image layout archive file 'layout.tar':
   oras blob fetch --oci-layout --output - layout.tar@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - [Preview] Fetch a large blob in 8 concurrent ranged requests and save it to a local file:
+  oras blob fetch --download-parts 8 --output blob.tar.gz localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - Fetch 1024 bytes of a blob from offset 4096 and save it to a local file:
+  oras blob fetch --offset 4096 --length 1024 --output part.bin localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - Fetch a blob from offset 4096 to the end and print the descriptor of the partial fetch:
+  oras blob fetch --offset 4096 --output part.bin --descriptor localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the target blob to fetch"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -79,6 +112,16 @@ Example - Fetch and print a blob from OCI image layout archive file 'layout.tar'
 			if opts.outputPath == "-" && opts.OutputDescriptor {
 				return errors.New("`--output -` cannot be used with `--descriptor` at the same time")
 			}
+
+			if opts.offset < 0 {
+				return fmt.Errorf("invalid offset %d: must not be negative", opts.offset)
+			}
+			if cmd.Flags().Changed("length") && opts.length <= 0 {
+				return fmt.Errorf("invalid length %d: must be positive", opts.length)
+			}
+			if opts.isPartial() && opts.outputPath == "" {
+				return errors.New("`--offset` and `--length` require `--output`")
+			}
 			opts.RawReference = args[0]
 			if err := option.Parse(cmd, &opts); err != nil {
 				return err
@@ -93,6 +136,8 @@ Example - Fetch and print a blob from OCI image layout archive file 'layout.tar'
 	}
 
 	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "output file `path`, use - for stdout")
+	cmd.Flags().Int64Var(&opts.offset, "offset", 0, "fetch the blob content starting from the byte `offset`, the content is not verified against the digest")
+	cmd.Flags().Int64Var(&opts.length, "length", 0, "fetch at most `length` bytes of the blob content, defaults to the end of the blob")
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
@@ -110,8 +155,12 @@ func fetchBlob(cmd *cobra.Command, opts *fetchBlobOptions) (fetchErr error) {
 		return err
 	}
 
+	if opts.isPartial() {
+		return opts.fetchPartial(ctx, target, cmd.ErrOrStderr())
+	}
+
 	if repo, ok := target.(*remote.Repository); ok {
-		target = repo.Blobs()
+		target = opts.ParallelTarget(repo.Blobs(), repo)
 	}
 	src, err := opts.CachedTarget(target)
 	if err != nil {
@@ -149,48 +198,123 @@ func (opts *fetchBlobOptions) doFetch(ctx context.Context, src oras.ReadOnlyTarg
 		return ocispec.Descriptor{}, err
 	}
 	defer func() { _ = rc.Close() }()
-	vr := content.NewVerifyReader(rc, desc)
+	if err := opts.writeContent(rc, desc, true); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// fetchPartial fetches a range of the blob content. The content is fetched
+// with a range request from registries, or read at the offset from OCI image
+// layouts. The partial content cannot be verified against the blob digest.
+func (opts *fetchBlobOptions) fetchPartial(ctx context.Context, target oras.ReadOnlyTarget, stderr io.Writer) error {
+	repo, isRepo := target.(*remote.Repository)
+	src := target
+	if isRepo {
+		src = repo.Blobs()
+	}
+	desc, err := oras.Resolve(ctx, src, opts.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return err
+	}
+	if opts.offset >= desc.Size {
+		return fmt.Errorf("invalid offset %d: out of the range of blob %s with size %d", opts.offset, desc.Digest, desc.Size)
+	}
+	length := desc.Size - opts.offset
+	if opts.length > 0 && opts.length < length {
+		length = opts.length
+	}
+
+	var rc io.ReadCloser
+	if isRepo {
+		rc, err = registryutil.FetchBlobRange(ctx, repo, desc, opts.offset, length)
+		if errors.Is(err, registryutil.ErrRangeNotSupported) {
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: "Fetch the whole blob without `--offset` and `--length`",
+			}
+		}
+	} else {
+		rc, err = contentutil.FetchRange(ctx, src, desc, opts.offset, length)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+
+	// track the progress of the fetched range only
+	rangeDesc := desc
+	rangeDesc.Size = length
+	if err := opts.writeContent(rc, rangeDesc, false); err != nil {
+		return err
+	}
+
+	if !opts.OutputDescriptor {
+		_, err = fmt.Fprintf(stderr, "Fetched %d of %d bytes from offset %d of %s: partial content is not verified against the digest\n", length, desc.Size, opts.offset, desc.Digest)
+		return err
+	}
+	descJSON, err := json.Marshal(partialDescriptor{
+		Descriptor: desc,
+		Partial: byteRange{
+			Offset: opts.offset,
+			Length: length,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal descriptor: %w", err)
+	}
+	return opts.Output(os.Stdout, descJSON)
+}
 
+// writeContent writes the content read from r to the output, tracking the
+// progress of desc if the output is a terminal. The content is verified
+// against desc if verify is true.
+func (opts *fetchBlobOptions) writeContent(r io.Reader, desc ocispec.Descriptor, verify bool) (writeErr error) {
 	// outputs blob content if "--output -" is used
 	writer := os.Stdout
 	if opts.outputPath != "-" {
 		// save blob content into the local file if the output path is provided
 		file, err := os.Create(opts.outputPath)
 		if err != nil {
-			return ocispec.Descriptor{}, err
+			return err
 		}
 		defer func() {
-			if err := file.Close(); fetchErr == nil {
-				fetchErr = err
+			if err := file.Close(); writeErr == nil {
+				writeErr = err
 			}
 		}()
 		writer = file
 	}
 
-	if opts.TTY == nil {
-		// none TTY output
-		if _, err = io.Copy(writer, vr); err != nil {
-			return ocispec.Descriptor{}, err
-		}
-	} else {
+	var tracker progress.Tracker
+	if opts.TTY != nil {
 		// TTY output
-		trackedReader, err := track.NewReader(vr, desc, "Downloading", "Downloaded ", opts.TTY)
+		trackedReader, err := track.NewReader(r, desc, "Downloading", "Downloaded ", opts.TTY)
 		if err != nil {
-			return ocispec.Descriptor{}, err
+			return err
 		}
 		defer trackedReader.StopManager()
-		if err := progress.Start(trackedReader.Tracker()); err != nil {
-			return ocispec.Descriptor{}, err
-		}
-		if _, err = io.Copy(writer, trackedReader); err != nil {
-			return ocispec.Descriptor{}, err
+		tracker = trackedReader.Tracker()
+		if err := progress.Start(tracker); err != nil {
+			return err
 		}
-		if err := progress.Done(trackedReader.Tracker()); err != nil {
-			return ocispec.Descriptor{}, err
+		r = trackedReader
+	}
+	var vr *content.VerifyReader
+	if verify {
+		vr = content.NewVerifyReader(r, desc)
+		r = vr
+	}
+	if _, err := io.Copy(writer, r); err != nil {
+		return err
+	}
+	if tracker != nil {
+		if err := progress.Done(tracker); err != nil {
+			return err
 		}
 	}
-	if err := vr.Verify(); err != nil {
-		return ocispec.Descriptor{}, err
+	if verify {
+		return vr.Verify()
 	}
-	return desc, nil
+	return nil
 }
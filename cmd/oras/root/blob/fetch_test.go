@@ -18,6 +18,9 @@ package blob
 import (
 	"bytes"
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/opencontainers/go-digest"
@@ -63,3 +66,60 @@ func Test_fetchBlobOptions_doFetch(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func Test_fetchBlobOptions_fetchPartial(t *testing.T) {
+	src := memory.New()
+	content := []byte("hello world")
+	desc := ocispec.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	ctx := context.Background()
+	if err := src.Push(ctx, desc, bytes.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+	tag := "blob"
+	if err := src.Tag(ctx, desc, tag); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		offset  int64
+		length  int64
+		want    string
+		wantErr bool
+	}{
+		{name: "range", offset: 6, length: 3, want: "wor"},
+		{name: "to the end", offset: 6, want: "world"},
+		{name: "length exceeds the end", offset: 6, length: 100, want: "world"},
+		{name: "offset out of range", offset: 11, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts fetchBlobOptions
+			opts.Reference = tag
+			opts.offset = tt.offset
+			opts.length = tt.length
+			opts.outputPath = filepath.Join(t.TempDir(), "part")
+			var stderr bytes.Buffer
+			err := opts.fetchPartial(ctx, src, &stderr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetchPartial() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := os.ReadFile(opts.outputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("fetchPartial() content = %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(stderr.String(), "not verified") {
+				t.Errorf("fetchPartial() notice = %q, want partial fetch notice", stderr.String())
+			}
+		})
+	}
+}
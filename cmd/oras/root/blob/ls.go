@@ -0,0 +1,128 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package blob
+
+import (
+	"archive/tar"
+	"errors"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+	orasio "oras.land/oras/internal/io"
+)
+
+type listBlobOptions struct {
+	option.Cache
+	option.Common
+	option.Format
+	option.Target
+	option.TarEntryFilter
+}
+
+func listCmd() *cobra.Command {
+	var opts listBlobOptions
+	cmd := &cobra.Command{
+		Use:   "ls [flags] <name>@<digest>",
+		Short: "[Preview] List entries of a tar blob in a registry or an OCI image layout",
+		Long: `[Preview] List entries of a tar blob in a registry or an OCI image layout
+
+The blob is streamed through the gzip, zstd or plain tar decoder without being
+written to disk, and verified against its digest after all entries are listed.
+The blob is read from the cache if ORAS_CACHE is set and the blob is cached.
+
+Example - List entries of a tar blob:
+  oras blob ls localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - List entries of a tar blob matching a path pattern:
+  oras blob ls --match 'usr/bin/*' localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - List entries of a tar blob with the name matching any of the patterns:
+  oras blob ls --match '*.so' --match '*.a' localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - List entries of a tar blob in JSON format:
+  oras blob ls --format json localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - List entries of a tar blob in OCI image layout folder 'layout-dir':
+  oras blob ls --oci-layout layout-dir@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+`,
+		Args:    oerrors.CheckArgs(argument.Exactly(1), "the tar blob to list"),
+		Aliases: []string{"list"},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return listBlob(cmd, &opts)
+		},
+	}
+
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func listBlob(cmd *cobra.Command, opts *listBlobOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	var target oras.ReadOnlyTarget
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, false); err != nil {
+		return err
+	}
+	if repo, ok := target.(*remote.Repository); ok {
+		target = repo.Blobs()
+	}
+	src, err := opts.CachedTarget(target)
+	if err != nil {
+		return err
+	}
+	desc, err := oras.Resolve(ctx, src, opts.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return err
+	}
+
+	handler, err := display.NewLayerListHandler(opts.Printer, opts.Format, opts.Path, false)
+	if err != nil {
+		return err
+	}
+	if err := handler.OnLayerListing(desc); err != nil {
+		return err
+	}
+	err = contentutil.WalkLayer(ctx, src, desc, func(hdr *tar.Header) error {
+		if !opts.Match(hdr.Name) {
+			return nil
+		}
+		return handler.OnEntryListed(desc, hdr)
+	})
+	if err != nil {
+		if errors.Is(err, orasio.ErrNotTarArchive) {
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: "Use `oras blob fetch` to fetch the blob",
+			}
+		}
+		return err
+	}
+	return handler.Render()
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
@@ -51,6 +52,7 @@ type copyOptions struct {
 	option.Platform
 	option.BinaryTarget
 	option.Terminal
+	option.Conflict
 
 	recursive   bool
 	force       bool
@@ -98,6 +100,9 @@ Example - Copy an artifact with multiple tags with concurrency tuned:
 
 Example - Copy a multi-arch image to a destination that may be partially populated (e.g. a registry cache):
   oras cp --force localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact only if the destination tag does not point to a different artifact:
+  oras cp --on-conflict fail localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(2), "the source and destination for copying"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -147,6 +152,46 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
 	statusHandler, metadataHandler := display.NewCopyHandler(opts.Printer, opts.TTY, dst)
 
+	// check the destination tags before copying unless they are overwritten
+	var dstTags []string
+	if opts.To.Reference != "" && !contentutil.IsDigest(opts.To.Reference) {
+		dstTags = append(dstTags, opts.To.Reference)
+	}
+	dstTags = append(dstTags, opts.extraRefs...)
+	var existing map[string]ocispec.Descriptor
+	if opts.OnConflict != option.ConflictPolicyOverwrite {
+		if existing, err = resolveExistingTags(ctx, dst, dstTags); err != nil {
+			return fmt.Errorf("failed to check the destination tags: %w", err)
+		}
+	}
+	if len(existing) > 0 {
+		rOpts := oras.DefaultResolveOptions
+		rOpts.TargetPlatform = opts.Platform.Platform
+		root, err := oras.Resolve(ctx, src, opts.From.Reference, rOpts)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+		}
+		var conflicts []string
+		for _, tag := range dstTags {
+			if desc, ok := existing[tag]; ok && desc.Digest != root.Digest {
+				conflicts = append(conflicts, tag)
+				if err := metadataHandler.OnTagConflict(tag, desc, root); err != nil {
+					return err
+				}
+			}
+		}
+		if len(conflicts) > 0 {
+			if opts.OnConflict == option.ConflictPolicyFail {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("%d tag(s) in the destination point to different artifacts", len(conflicts)),
+					Recommendation: `Use "--on-conflict overwrite" to overwrite the tags or "--on-conflict skip" to skip copying.`,
+				}
+			}
+			// skip the copy if any destination tag conflicts
+			return metadataHandler.OnConflictSkipped(opts.To.GetDisplayReference())
+		}
+	}
+
 	desc, err := doCopy(ctx, statusHandler, src, dst, opts)
 	if err != nil {
 		return err
@@ -169,10 +214,26 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 			return err
 		}
 	}
-
 	return metadataHandler.Render()
 }
 
+// resolveExistingTags resolves the tags in the target. Tags not found in the
+// target are omitted from the returned map.
+func resolveExistingTags(ctx context.Context, target oras.ReadOnlyTarget, tags []string) (map[string]ocispec.Descriptor, error) {
+	existing := make(map[string]ocispec.Descriptor)
+	for _, tag := range tags {
+		desc, err := target.Resolve(ctx, tag)
+		if err != nil {
+			if errors.Is(err, errdef.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		existing[tag] = desc
+	}
+	return existing, nil
+}
+
 func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, opts *copyOptions) (desc ocispec.Descriptor, err error) {
 	// Prepare copy options
 	extendedCopyGraphOptions := oras.DefaultExtendedCopyGraphOptions
@@ -566,3 +566,23 @@ func Test_getMountPoint(t *testing.T) {
 		})
 	}
 }
+
+func Test_resolveExistingTags(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifestContent)
+	if err := store.Push(ctx, desc, bytes.NewReader(manifestContent)); err != nil {
+		t.Fatalf("failed to push manifest: %v", err)
+	}
+	if err := store.Tag(ctx, desc, "v1"); err != nil {
+		t.Fatalf("failed to tag manifest: %v", err)
+	}
+
+	got, err := resolveExistingTags(ctx, store, []string{"v1", "v2"})
+	if err != nil {
+		t.Fatalf("resolveExistingTags() error = %v", err)
+	}
+	if len(got) != 1 || got["v1"].Digest != desc.Digest {
+		t.Errorf("resolveExistingTags() = %v, want only v1 resolved to %s", got, desc.Digest)
+	}
+}
@@ -17,14 +17,22 @@ package root
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
@@ -32,6 +40,8 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/graph"
 )
 
 type discoverOptions struct {
@@ -41,8 +51,19 @@ type discoverOptions struct {
 	option.Format
 	option.Terminal
 
-	artifactType string
-	depth        int
+	artifactTypes   []string
+	annotations     []string
+	sort            string
+	latest          int
+	depth           int
+	concurrency     int
+	up              bool
+	includeChildren bool
+
+	labelAnnotations []string
+	includeContent   bool
+
+	referrerFilter *graph.ReferrerFilter
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -77,11 +98,38 @@ Example - [Experimental] Discover only direct referrers, displayed in json view:
 Example - Discover referrers with type 'test-artifact' of manifest 'hello:v1' in registry 'localhost:5000':
   oras discover --artifact-type test-artifact localhost:5000/hello:v1
 
+Example - [Experimental] Discover referrers of any type except 'test-artifact':
+  oras discover --artifact-type '!test-artifact' localhost:5000/hello:v1
+
+Example - [Experimental] Discover referrers annotated with 'org.example.signer=alice' and a release version:
+  oras discover --annotation org.example.signer=alice --annotation 'org.example.version~=^v[0-9]+' localhost:5000/hello:v1
+
+Example - [Experimental] Discover the latest 2 referrers of each artifact type, from the latest to the oldest:
+  oras discover --latest 2 --sort created localhost:5000/hello:v1
+
+Example - [Experimental] Stream referrers as JSON Lines as they are discovered, up to 10 manifests at a time:
+  oras discover localhost:5000/hello:v1 --format jsonl --concurrency 10
+
+Example - [Experimental] Discover referrers of the index 'hello:v1' and of each of its platform manifests:
+  oras discover --include-children localhost:5000/hello:v1
+
+Example - [Experimental] Discover referrers and render the graph with Graphviz, labeling nodes with the created annotation:
+  oras discover localhost:5000/hello:v1 --format dot --label-annotation org.opencontainers.image.created | dot -Tsvg -o graph.svg
+
+Example - [Experimental] Discover referrers of an index, along with its manifests and their layers, as a Mermaid flowchart:
+  oras discover localhost:5000/hello:v1 --format mermaid --include-content
+
 Example - Discover referrers of the manifest tagged 'v1' in an OCI image layout folder 'layout-dir':
   oras discover --oci-layout layout-dir:v1
 
 Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI image layout folder 'layout-dir':
   oras discover example.com:v1 --oci-layout-path layout-dir
+
+Example - [Experimental] Discover the subject chain of a signature, up to the tagged indexes containing the signed image:
+  oras discover --up localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - [Experimental] Discover the tagged indexes containing the linux/amd64 manifest of 'hello:v1', displayed in json view:
+  oras discover --up --platform linux/amd64 --format json localhost:5000/hello:v1
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the target artifact to discover referrers from"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -91,6 +139,9 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 			if cmd.Flags().Changed("depth") && opts.depth < 1 {
 				return errors.New("depth value should be at least 1")
 			}
+			if opts.concurrency < 1 {
+				return errors.New("concurrency value should be at least 1")
+			}
 			// only show direct referrers for table format
 			if opts.FormatFlag == option.FormatTypeTable.Name {
 				opts.depth = 1
@@ -99,6 +150,19 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 			if err := option.Parse(cmd, &opts); err != nil {
 				return err
 			}
+			if opts.up {
+				for _, name := range []string{"artifact-type", "annotation", "sort", "latest", "include-children"} {
+					if cmd.Flags().Changed(name) {
+						return fmt.Errorf("--%s cannot be used with --up", name)
+					}
+				}
+				if opts.Format.Type != option.FormatTypeTree.Name && opts.Format.Type != option.FormatTypeJSON.Name {
+					return &oerrors.Error{
+						Err:            fmt.Errorf("format %q cannot be used with --up", opts.Format.Type),
+						Recommendation: fmt.Sprintf("Use --format %s or --format %s to display the subject chain.", option.FormatTypeTree.Name, option.FormatTypeJSON.Name),
+					}
+				}
+			}
 			if cmd.Flags().Changed("output") {
 				switch opts.Format.Type {
 				case option.FormatTypeTree.Name, option.FormatTypeJSON.Name, option.FormatTypeTable.Name:
@@ -107,6 +171,25 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 					return errors.New("output type can only be tree, table or json")
 				}
 			}
+			if opts.includeChildren && !opts.supportsChildren() {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("--include-children cannot be used with format %q", opts.Format.Type),
+					Recommendation: fmt.Sprintf("Use --format %s or --format %s to display the referrers of the child manifests.", option.FormatTypeTree.Name, option.FormatTypeJSON.Name),
+				}
+			}
+			if !opts.isDiagramFormat() {
+				for _, name := range []string{"label-annotation", "include-content"} {
+					if cmd.Flags().Changed(name) {
+						return &oerrors.Error{
+							Err:            fmt.Errorf("--%s cannot be used with format %q", name, opts.Format.Type),
+							Recommendation: fmt.Sprintf("Use --format %s, --format %s or --format %s to display the graph as a diagram.", option.FormatTypeDOT.Name, option.FormatTypeMermaid.Name, option.FormatTypePlantUML.Name),
+						}
+					}
+				}
+			}
+			if err := opts.parseReferrerFilter(); err != nil {
+				return err
+			}
 			opts.DisableTTY(opts.Debug, false)
 			return nil
 		},
@@ -115,16 +198,29 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "artifact type")
+	cmd.Flags().StringArrayVarP(&opts.artifactTypes, "artifact-type", "", nil, "artifact type, can be specified multiple times, prefix with '!' to exclude the artifact type")
+	cmd.Flags().StringArrayVarP(&opts.annotations, "annotation", "", nil, "[Experimental] only discover referrers with the annotation in the form of key=value, or key~=regex to match a regular expression, can be specified multiple times")
+	cmd.Flags().StringVarP(&opts.sort, "sort", "", "", "[Experimental] sort referrers of each level by created (from the latest) or artifactType")
+	cmd.Flags().IntVarP(&opts.latest, "latest", "", 0, "[Experimental] only discover the latest N referrers (0 for no limit) of each artifact type in each level, according to the created annotation")
 	cmd.Flags().StringVarP(&opts.FormatFlag, "output", "o", "tree", "[Deprecated] format in which to display referrers (table, json, or tree).")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "display full metadata of referrers")
 	cmd.Flags().IntVarP(&opts.depth, "depth", "", 0, "[Experimental] level of referrers to display, if unused shows referrers of all levels")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "[Experimental] number of manifests whose referrers are discovered concurrently")
+	cmd.Flags().BoolVarP(&opts.up, "up", "", false, "[Experimental] walk up the subject chain of the manifest instead of discovering its referrers, along with the tagged indexes containing the top manifest")
+	cmd.Flags().BoolVarP(&opts.includeChildren, "include-children", "", false, "[Experimental] if the manifest is an index, also discover referrers of its child manifests")
+	cmd.Flags().StringArrayVarP(&opts.labelAnnotations, "label-annotation", "", nil, "[Experimental] annotation key whose value is added to the node labels of diagram formats, can be specified multiple times")
+	cmd.Flags().BoolVarP(&opts.includeContent, "include-content", "", false, "[Experimental] include the manifests of indexes and the layers of manifests in diagram formats")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.SetTypes(
 		option.FormatTypeTree,
 		option.FormatTypeTable,
 		option.FormatTypeJSON.WithUsage("Get referrers and output in JSON format"),
 		option.FormatTypeGoTemplate.WithUsage("Print referrers using the given Go template"),
+		option.FormatTypeJSONLines.WithUsage("[Experimental] Print each referrer as a line of JSON as soon as it is discovered"),
+		option.FormatTypeText.WithUsage("[Experimental] Print each referrer as a line of text as soon as it is discovered"),
+		option.FormatTypeDOT.WithUsage("[Experimental] Print the referrer graph in Graphviz DOT format"),
+		option.FormatTypeMermaid.WithUsage("[Experimental] Print the referrer graph as a Mermaid flowchart"),
+		option.FormatTypePlantUML.WithUsage("[Experimental] Print the referrer graph in PlantUML format"),
 	)
 	opts.EnableDistributionSpecFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
@@ -150,49 +246,424 @@ func runDiscover(cmd *cobra.Command, opts *discoverOptions) error {
 		return err
 	}
 
-	handler, err := display.NewDiscoverHandler(opts.Printer, opts.Format, opts.Path, opts.RawReference, desc, opts.verbose, opts.TTY)
+	if opts.up {
+		handler, err := display.NewDiscoverSubjectHandler(opts.Printer, opts.Format, opts.Path, desc, opts.verbose, opts.TTY)
+		if err != nil {
+			return err
+		}
+		if err := fetchSubjectChain(ctx, repo, desc, handler, opts.depth, logger); err != nil {
+			return err
+		}
+		return handler.Render()
+	}
+
+	handler, err := display.NewDiscoverHandler(opts.Printer, opts.Format, opts.Path, opts.RawReference, desc, opts.verbose, opts.TTY, opts.labelAnnotations)
 	if err != nil {
 		return err
 	}
-	if err := fetchAllReferrers(ctx, repo, desc, opts.artifactType, handler, opts.depth, make(map[digest.Digest]bool)); err != nil {
+	discoverHandler, recorder := recordReferrers(handler, opts.includeContent)
+	visited := make(map[digest.Digest]bool)
+	if err := fetchAllReferrers(ctx, repo, desc, opts.referrerFilter, discoverHandler, opts.depth, opts.concurrency, visited); err != nil {
 		return err
 	}
+	if opts.includeChildren {
+		childHandler, ok := handler.(metadata.DiscoverChildHandler)
+		if !ok {
+			return oerrors.UnsupportedFormatTypeError(opts.Format.Type)
+		}
+		if err := fetchChildrenReferrers(ctx, repo, desc, opts.referrerFilter, discoverHandler, childHandler, opts.depth, opts.concurrency, visited); err != nil {
+			return err
+		}
+	}
+	if opts.includeContent {
+		contentHandler, ok := handler.(metadata.DiscoverContentHandler)
+		if !ok {
+			return oerrors.UnsupportedFormatTypeError(opts.Format.Type)
+		}
+		if err := fetchContent(ctx, repo, append([]ocispec.Descriptor{desc}, recorder.referrers...), contentHandler); err != nil {
+			return err
+		}
+	}
 	return handler.Render()
 }
 
-// fetchAllReferrers recursively discovers the referrers of desc. visited tracks
-// the descriptors already traversed so that a cyclic referrer graph (e.g.
-// A -> B -> A), which a malicious registry can craft, does not cause unbounded
-// recursion.
-func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, artifactType string, handler metadata.DiscoverHandler, depth int, visited map[digest.Digest]bool) error {
-	if visited[desc.Digest] {
-		return nil
+// fetchAllReferrers recursively discovers the referrers of desc selected by
+// the filter. Referrers are listed page by page and passed to the handler as
+// soon as a page is received, unless the filter needs all the referrers of a
+// subject to select any. Referrers not selected are not traversed. The
+// referrers of up to concurrency subjects are discovered concurrently, while
+// the handler is called serially. visited tracks the descriptors already
+// traversed so that a cyclic referrer graph (e.g. A -> B -> A), which a
+// malicious registry can craft, does not cause unbounded recursion.
+func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, filter *graph.ReferrerFilter, handler metadata.DiscoverHandler, depth int, concurrency int, visited map[digest.Digest]bool) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	if concurrency > 0 {
+		eg.SetLimit(concurrency)
 	}
-	visited[desc.Digest] = true
+	w := &referrerWalker{
+		repo:    repo,
+		filter:  filter,
+		handler: handler,
+		eg:      eg,
+		visited: visited,
+	}
+	eg.Go(func() error {
+		return w.walk(egCtx, desc, depth)
+	})
+	return eg.Wait()
+}
 
-	results, err := registry.Referrers(ctx, repo, desc, artifactType)
-	if err != nil {
-		return err
+// referrerWalker walks the referrer graph concurrently.
+type referrerWalker struct {
+	repo    oras.ReadOnlyGraphTarget
+	filter  *graph.ReferrerFilter
+	handler metadata.DiscoverHandler
+	eg      *errgroup.Group
+
+	visitedLock sync.Mutex
+	visited     map[digest.Digest]bool
+	handlerLock sync.Mutex
+}
+
+// walk discovers the referrers of desc, and traverses them in new goroutines
+// if the concurrency limit allows, or in the current goroutine otherwise.
+func (w *referrerWalker) walk(ctx context.Context, desc ocispec.Descriptor, depth int) error {
+	w.visitedLock.Lock()
+	if w.visited[desc.Digest] {
+		w.visitedLock.Unlock()
+		return nil
 	}
+	w.visited[desc.Digest] = true
+	w.visitedLock.Unlock()
 
 	var nextDepth int
 	if depth > 0 {
 		nextDepth = depth - 1
 	}
-	for _, r := range results {
-		if err := handler.OnDiscovered(r, desc); err != nil {
-			return err
+	onReferrers := func(referrers []ocispec.Descriptor) error {
+		referrers = w.filter.Apply(referrers)
+		for _, r := range referrers {
+			w.handlerLock.Lock()
+			err := w.handler.OnDiscovered(r, desc)
+			w.handlerLock.Unlock()
+			if err != nil {
+				return err
+			}
+			if depth == 1 {
+				continue
+			}
+			next := ocispec.Descriptor{
+				Digest:    r.Digest,
+				Size:      r.Size,
+				MediaType: r.MediaType,
+			}
+			if !w.eg.TryGo(func() error {
+				return w.walk(ctx, next, nextDepth)
+			}) {
+				if err := w.walk(ctx, next, nextDepth); err != nil {
+					return err
+				}
+			}
 		}
-		if depth == 1 {
+		return nil
+	}
+
+	if w.filter.Streamable() {
+		return listReferrers(ctx, w.repo, desc, w.filter.ArtifactType(), onReferrers)
+	}
+	var all []ocispec.Descriptor
+	if err := listReferrers(ctx, w.repo, desc, w.filter.ArtifactType(), func(referrers []ocispec.Descriptor) error {
+		all = append(all, referrers...)
+		return nil
+	}); err != nil {
+		return err
+	}
+	return onReferrers(all)
+}
+
+// listReferrers lists the referrers of desc page by page if the target
+// supports it, or all at once otherwise.
+func listReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, artifactType string, fn func(referrers []ocispec.Descriptor) error) error {
+	if lister, ok := repo.(registry.ReferrerLister); ok {
+		return lister.Referrers(ctx, desc, artifactType, fn)
+	}
+	referrers, err := registry.Referrers(ctx, repo, desc, artifactType)
+	if err != nil {
+		return err
+	}
+	return fn(referrers)
+}
+
+// fetchChildrenReferrers discovers the referrers of the child manifests of
+// index, reusing the child enumeration of backup. Nothing is discovered if
+// index is not an image index or a manifest list.
+func fetchChildrenReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, index ocispec.Descriptor, filter *graph.ReferrerFilter, handler metadata.DiscoverHandler, childHandler metadata.DiscoverChildHandler, depth int, concurrency int, visited map[digest.Digest]bool) error {
+	children, err := graph.IndexChildren(ctx, repo, index)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if visited[child.Digest] {
 			continue
 		}
-		if err := fetchAllReferrers(ctx, repo, ocispec.Descriptor{
-			Digest:    r.Digest,
-			Size:      r.Size,
-			MediaType: r.MediaType,
-		}, artifactType, handler, nextDepth, visited); err != nil {
+		if err := childHandler.OnChildDiscovered(child, index); err != nil {
+			return err
+		}
+		if err := fetchAllReferrers(ctx, repo, child, filter, handler, depth, concurrency, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordReferrers wraps handler with a recorder of the discovered referrers if
+// their content is to be discovered. Otherwise, handler is returned as is and
+// the recorder is nil, so that the referrers are not kept in memory.
+func recordReferrers(handler metadata.DiscoverHandler, includeContent bool) (metadata.DiscoverHandler, *referrerRecorder) {
+	if !includeContent {
+		return handler, nil
+	}
+	recorder := &referrerRecorder{DiscoverHandler: handler}
+	return recorder, recorder
+}
+
+// referrerRecorder records the referrers passed to a discover handler.
+type referrerRecorder struct {
+	metadata.DiscoverHandler
+	referrers []ocispec.Descriptor
+}
+
+// OnDiscovered implements metadata.DiscoverHandler.
+func (r *referrerRecorder) OnDiscovered(referrer, subject ocispec.Descriptor) error {
+	r.referrers = append(r.referrers, referrer)
+	return r.DiscoverHandler.OnDiscovered(referrer, subject)
+}
+
+// fetchContent discovers the manifests of the indexes, and the layers of the
+// manifests, in the content of the nodes.
+func fetchContent(ctx context.Context, fetcher content.Fetcher, nodes []ocispec.Descriptor, handler metadata.DiscoverContentHandler) error {
+	visited := make(map[digest.Digest]bool)
+	var walk func(node ocispec.Descriptor) error
+	walk = func(node ocispec.Descriptor) error {
+		if visited[node.Digest] {
+			return nil
+		}
+		visited[node.Digest] = true
+		successors, _, _, err := graph.Successors(ctx, fetcher, node)
+		if err != nil {
+			return err
+		}
+		for _, successor := range successors {
+			if err := handler.OnContentDiscovered(successor, node); err != nil {
+				return err
+			}
+			if err := walk(successor); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, node := range nodes {
+		if err := walk(node); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// supportsChildren returns true if the referrers of the child manifests of an
+// index can be displayed in the format.
+func (opts *discoverOptions) supportsChildren() bool {
+	switch opts.Format.Type {
+	case option.FormatTypeTable.Name, option.FormatTypeJSONLines.Name, option.FormatTypeText.Name:
+		return false
+	}
+	return true
+}
+
+// isDiagramFormat returns true if the referrer graph is displayed as a
+// diagram.
+func (opts *discoverOptions) isDiagramFormat() bool {
+	switch opts.Format.Type {
+	case option.FormatTypeDOT.Name, option.FormatTypeMermaid.Name, option.FormatTypePlantUML.Name:
+		return true
+	}
+	return false
+}
+
+// parseReferrerFilter parses the flags selecting and ordering the referrers.
+func (opts *discoverOptions) parseReferrerFilter() error {
+	if opts.latest < 0 {
+		return fmt.Errorf("invalid value %d for --latest, it must not be negative", opts.latest)
+	}
+	switch opts.sort {
+	case "", graph.SortByCreated, graph.SortByArtifactType:
+	default:
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid value %q for --sort", opts.sort),
+			Recommendation: fmt.Sprintf("Use %q or %q to sort referrers.", graph.SortByCreated, graph.SortByArtifactType),
+		}
+	}
+	filter := &graph.ReferrerFilter{
+		Latest: opts.latest,
+		Sort:   opts.sort,
+	}
+	for _, artifactType := range opts.artifactTypes {
+		excluded, isExcluded := strings.CutPrefix(artifactType, "!")
+		if excluded == "" {
+			return fmt.Errorf("invalid value %q for --artifact-type, the artifact type cannot be empty", artifactType)
+		}
+		if isExcluded {
+			filter.ExcludedArtifactTypes = append(filter.ExcludedArtifactTypes, excluded)
+		} else {
+			filter.ArtifactTypes = append(filter.ArtifactTypes, artifactType)
+		}
+	}
+	for _, annotation := range opts.annotations {
+		matcher, err := graph.ParseAnnotationMatcher(annotation)
+		if err != nil {
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: `Use --annotation key=value to match a value, or --annotation 'key~=regex' to match a regular expression.`,
+			}
+		}
+		filter.Annotations = append(filter.Annotations, matcher)
+	}
+	opts.referrerFilter = filter
+	return nil
+}
+
+// fetchSubjectChain walks up the subject chain of desc. Once the top of the
+// chain is reached, the tagged indexes containing the top manifest are
+// discovered if the target supports tag listing.
+func fetchSubjectChain(ctx context.Context, repo oras.ReadOnlyTarget, desc ocispec.Descriptor, handler metadata.DiscoverSubjectHandler, depth int, logger logrus.FieldLogger) error {
+	_, subject, err := describeManifest(ctx, repo, desc)
+	if err != nil {
+		return err
+	}
+	current := desc
+	visited := map[digest.Digest]bool{desc.Digest: true}
+	for level := 1; subject != nil; level++ {
+		if visited[subject.Digest] {
+			return nil
+		}
+		visited[subject.Digest] = true
+
+		described, next, err := describeManifest(ctx, repo, *subject)
+		if err != nil {
+			if errors.Is(err, errdef.ErrNotFound) {
+				// the subject is not in the repository, so the chain ends here
+				return handler.OnSubjectDiscovered(*subject, current)
+			}
+			return err
+		}
+		if err := handler.OnSubjectDiscovered(described, current); err != nil {
+			return err
+		}
+		if level == depth {
+			return nil
+		}
+		current, subject = described, next
+	}
+	return fetchContainingIndexes(ctx, repo, current, handler, logger)
+}
+
+// describeManifest fetches the manifest of desc and returns desc with the
+// artifact type and the annotations of the manifest, along with the subject
+// of the manifest.
+func describeManifest(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) (ocispec.Descriptor, *ocispec.Descriptor, error) {
+	manifestBytes, err := content.FetchAll(ctx, fetcher, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	var manifest struct {
+		ArtifactType string              `json:"artifactType,omitempty"`
+		Config       *ocispec.Descriptor `json:"config,omitempty"`
+		Subject      *ocispec.Descriptor `json:"subject,omitempty"`
+		Annotations  map[string]string   `json:"annotations,omitempty"`
+	}
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("failed to parse manifest %s: %w", desc.Digest, err)
+	}
+	described := ocispec.Descriptor{
+		MediaType:    desc.MediaType,
+		Digest:       desc.Digest,
+		Size:         desc.Size,
+		ArtifactType: manifest.ArtifactType,
+		Annotations:  manifest.Annotations,
+	}
+	if described.ArtifactType == "" && manifest.Config != nil && manifest.Config.MediaType != ocispec.MediaTypeEmptyJSON {
+		// the artifact type of an image is the media type of its config
+		described.ArtifactType = manifest.Config.MediaType
+	}
+	return described, manifest.Subject, nil
+}
+
+// fetchContainingIndexes discovers the tagged indexes in repo containing the
+// manifest. It is skipped if repo does not support or allow tag listing.
+func fetchContainingIndexes(ctx context.Context, repo oras.ReadOnlyTarget, manifest ocispec.Descriptor, handler metadata.DiscoverSubjectHandler, logger logrus.FieldLogger) error {
+	tagLister, ok := repo.(registry.TagLister)
+	if !ok {
+		return nil
+	}
+	var indexes []ocispec.Descriptor
+	tagsOf := make(map[digest.Digest][]string)
+	contains := make(map[digest.Digest]bool)
+	var walkErr error
+	listErr := tagLister.Tags(ctx, "", func(tags []string) error {
+		for _, tag := range tags {
+			desc, err := repo.Resolve(ctx, tag)
+			if err != nil {
+				walkErr = fmt.Errorf("failed to resolve tag %q: %w", tag, err)
+				return walkErr
+			}
+			if !descriptor.IsIndex(desc) {
+				continue
+			}
+			found, checked := contains[desc.Digest]
+			if !checked {
+				if found, err = indexContains(ctx, repo, desc, manifest.Digest); err != nil {
+					walkErr = err
+					return walkErr
+				}
+				contains[desc.Digest] = found
+			}
+			if !found {
+				continue
+			}
+			if _, ok := tagsOf[desc.Digest]; !ok {
+				indexes = append(indexes, descriptor.Plain(desc))
+			}
+			tagsOf[desc.Digest] = append(tagsOf[desc.Digest], tag)
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+	if listErr != nil {
+		logger.Debugf("skipped discovering the indexes containing %s: failed to list tags: %v", manifest.Digest, listErr)
+		return nil
+	}
+	for _, index := range indexes {
+		if err := handler.OnIndexDiscovered(index, tagsOf[index.Digest], manifest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexContains reports whether the index directly contains the manifest.
+func indexContains(ctx context.Context, fetcher content.Fetcher, index ocispec.Descriptor, manifest digest.Digest) (bool, error) {
+	indexBytes, err := content.FetchAll(ctx, fetcher, index)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch index %s: %w", index.Digest, err)
+	}
+	var parsed ocispec.Index
+	if err := json.Unmarshal(indexBytes, &parsed); err != nil {
+		return false, fmt.Errorf("failed to parse index %s: %w", index.Digest, err)
+	}
+	return slices.ContainsFunc(parsed.Manifests, func(desc ocispec.Descriptor) bool {
+		return desc.Digest == manifest
+	}), nil
+}
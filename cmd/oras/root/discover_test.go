@@ -16,13 +16,29 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/graph"
 )
 
 // cyclicReferrerTarget is a minimal ReadOnlyGraphTarget that serves a
@@ -75,7 +91,7 @@ func TestFetchAllReferrers_CyclicGraphTerminates(t *testing.T) {
 	handler := &recordingDiscoverHandler{}
 
 	// depth 0 means unlimited; without cycle detection this never returns.
-	if err := fetchAllReferrers(context.Background(), target, descA, "", handler, 0, make(map[digest.Digest]bool)); err != nil {
+	if err := fetchAllReferrers(context.Background(), target, descA, nil, handler, 0, 3, make(map[digest.Digest]bool)); err != nil {
 		t.Fatalf("fetchAllReferrers() error = %v", err)
 	}
 	// Each edge (A->B and B->A) is reported exactly once.
@@ -83,3 +99,504 @@ func TestFetchAllReferrers_CyclicGraphTerminates(t *testing.T) {
 		t.Errorf("OnDiscovered called %d times, want 2", handler.count)
 	}
 }
+
+// recordingSubjectHandler records the discovered subject chain.
+type recordingSubjectHandler struct {
+	subjects []digest.Digest
+	indexes  map[digest.Digest][]string
+}
+
+func (h *recordingSubjectHandler) OnSubjectDiscovered(subject, _ ocispec.Descriptor) error {
+	h.subjects = append(h.subjects, subject.Digest)
+	return nil
+}
+
+func (h *recordingSubjectHandler) OnIndexDiscovered(index ocispec.Descriptor, tags []string, _ ocispec.Descriptor) error {
+	if h.indexes == nil {
+		h.indexes = make(map[digest.Digest][]string)
+	}
+	h.indexes[index.Digest] = tags
+	return nil
+}
+
+func (h *recordingSubjectHandler) Render() error { return nil }
+
+func Test_fetchSubjectChain(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create OCI store: %v", err)
+	}
+	pack := func(artifactType string, subject *ocispec.Descriptor) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{Subject: subject})
+		if err != nil {
+			t.Fatalf("failed to pack manifest: %v", err)
+		}
+		return desc
+	}
+	image := pack("test/image", nil)
+	sbom := pack("test/sbom", &image)
+	signature := pack("test/signature", &sbom)
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{image},
+	}
+	indexBytes, err := json.Marshal(index)
+	if err != nil {
+		t.Fatalf("failed to marshal index: %v", err)
+	}
+	indexDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, indexBytes)
+	if err := store.Push(ctx, indexDesc, bytes.NewReader(indexBytes)); err != nil {
+		t.Fatalf("failed to push index: %v", err)
+	}
+	for _, tag := range []string{"v1", "latest"} {
+		if err := store.Tag(ctx, indexDesc, tag); err != nil {
+			t.Fatalf("failed to tag index: %v", err)
+		}
+	}
+	if err := store.Tag(ctx, image, "image"); err != nil {
+		t.Fatalf("failed to tag image: %v", err)
+	}
+
+	t.Run("full chain", func(t *testing.T) {
+		handler := &recordingSubjectHandler{}
+		if err := fetchSubjectChain(ctx, store, signature, handler, 0, logrus.New()); err != nil {
+			t.Fatalf("fetchSubjectChain() error = %v", err)
+		}
+		if want := []digest.Digest{sbom.Digest, image.Digest}; !reflect.DeepEqual(handler.subjects, want) {
+			t.Errorf("subjects = %v, want %v", handler.subjects, want)
+		}
+		want := map[digest.Digest][]string{indexDesc.Digest: {"latest", "v1"}}
+		if !reflect.DeepEqual(handler.indexes, want) {
+			t.Errorf("indexes = %v, want %v", handler.indexes, want)
+		}
+	})
+
+	t.Run("limited depth", func(t *testing.T) {
+		handler := &recordingSubjectHandler{}
+		if err := fetchSubjectChain(ctx, store, signature, handler, 1, logrus.New()); err != nil {
+			t.Fatalf("fetchSubjectChain() error = %v", err)
+		}
+		if want := []digest.Digest{sbom.Digest}; !reflect.DeepEqual(handler.subjects, want) {
+			t.Errorf("subjects = %v, want %v", handler.subjects, want)
+		}
+		if len(handler.indexes) != 0 {
+			t.Errorf("indexes = %v, want none", handler.indexes)
+		}
+	})
+
+	t.Run("missing subject", func(t *testing.T) {
+		missing := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("missing"), Size: 7}
+		orphan := pack("test/orphan", &missing)
+		handler := &recordingSubjectHandler{}
+		if err := fetchSubjectChain(ctx, store, orphan, handler, 0, logrus.New()); err != nil {
+			t.Fatalf("fetchSubjectChain() error = %v", err)
+		}
+		if want := []digest.Digest{missing.Digest}; !reflect.DeepEqual(handler.subjects, want) {
+			t.Errorf("subjects = %v, want %v", handler.subjects, want)
+		}
+	})
+}
+
+func Test_describeManifest(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "", oras.PackManifestOptions{
+		ConfigDescriptor:    &ocispec.Descriptor{MediaType: "test/config", Digest: digest.FromString(""), Size: 0},
+		ManifestAnnotations: map[string]string{"key": "value"},
+	})
+	if err != nil {
+		t.Fatalf("failed to pack manifest: %v", err)
+	}
+	got, subject, err := describeManifest(ctx, store, desc)
+	if err != nil {
+		t.Fatalf("describeManifest() error = %v", err)
+	}
+	if got.ArtifactType != "test/config" {
+		t.Errorf("describeManifest() artifact type = %q, want %q", got.ArtifactType, "test/config")
+	}
+	if got.Annotations["key"] != "value" {
+		t.Errorf("describeManifest() annotations = %v, want key=value", got.Annotations)
+	}
+	if subject != nil {
+		t.Errorf("describeManifest() subject = %v, want nil", subject)
+	}
+}
+
+func TestFetchAllReferrers_filter(t *testing.T) {
+	descA := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("A"), Size: 1}
+	descB := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("B"), Size: 1, ArtifactType: "test/sig"}
+	descC := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("C"), Size: 1, ArtifactType: "test/sbom"}
+	descD := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("D"), Size: 1, ArtifactType: "test/sig"}
+	target := &cyclicReferrerTarget{referrers: map[digest.Digest][]ocispec.Descriptor{
+		descA.Digest: {descB, descC},
+		descC.Digest: {descD},
+	}}
+	handler := &recordingDiscoverHandler{}
+
+	// the referrers of C are not traversed as C is excluded
+	filter := &graph.ReferrerFilter{ExcludedArtifactTypes: []string{"test/sbom"}}
+	if err := fetchAllReferrers(context.Background(), target, descA, filter, handler, 0, 3, make(map[digest.Digest]bool)); err != nil {
+		t.Fatalf("fetchAllReferrers() error = %v", err)
+	}
+	if handler.count != 1 {
+		t.Errorf("OnDiscovered called %d times, want 1", handler.count)
+	}
+}
+
+func Test_discoverOptions_parseReferrerFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    discoverOptions
+		want    *graph.ReferrerFilter
+		wantErr bool
+		errText string // expected in the error message if set
+	}{
+		{
+			name: "no filter",
+			want: &graph.ReferrerFilter{},
+		},
+		{
+			name: "artifact types",
+			opts: discoverOptions{artifactTypes: []string{"test/sig", "!test/sbom"}, latest: 2, sort: "created"},
+			want: &graph.ReferrerFilter{
+				ArtifactTypes:         []string{"test/sig"},
+				ExcludedArtifactTypes: []string{"test/sbom"},
+				Latest:                2,
+				Sort:                  graph.SortByCreated,
+			},
+		},
+		{
+			name: "annotations",
+			opts: discoverOptions{annotations: []string{"signer=alice"}},
+			want: &graph.ReferrerFilter{
+				Annotations: []graph.AnnotationMatcher{{Key: "signer", Value: "alice"}},
+			},
+		},
+		{
+			name:    "empty excluded artifact type",
+			opts:    discoverOptions{artifactTypes: []string{"!"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid annotation",
+			opts:    discoverOptions{annotations: []string{"signer"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid sort",
+			opts:    discoverOptions{sort: "size"},
+			wantErr: true,
+		},
+		{
+			name: "no latest limit",
+			opts: discoverOptions{latest: 0},
+			want: &graph.ReferrerFilter{},
+		},
+		{
+			name:    "negative latest",
+			opts:    discoverOptions{latest: -1},
+			wantErr: true,
+			errText: "must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.parseReferrerFilter()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseReferrerFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("parseReferrerFilter() error = %v, want containing %q", err, tt.errText)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.opts.referrerFilter, tt.want) {
+				t.Errorf("parseReferrerFilter() = %+v, want %+v", tt.opts.referrerFilter, tt.want)
+			}
+		})
+	}
+}
+
+type recordingContentHandler struct {
+	edges []string
+}
+
+func (h *recordingContentHandler) OnContentDiscovered(node, parent ocispec.Descriptor) error {
+	h.edges = append(h.edges, parent.Digest.Encoded()[:4]+"->"+node.Digest.Encoded()[:4])
+	return nil
+}
+
+func Test_fetchContent(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	layer := content.NewDescriptorFromBytes("test/layer", []byte("layer"))
+	if err := store.Push(ctx, layer, bytes.NewReader([]byte("layer"))); err != nil {
+		t.Fatalf("failed to push layer: %v", err)
+	}
+	manifest, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "test/image", oras.PackManifestOptions{Layers: []ocispec.Descriptor{layer}})
+	if err != nil {
+		t.Fatalf("failed to pack manifest: %v", err)
+	}
+	indexBytes, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{manifest},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal index: %v", err)
+	}
+	index := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, indexBytes)
+	if err := store.Push(ctx, index, bytes.NewReader(indexBytes)); err != nil {
+		t.Fatalf("failed to push index: %v", err)
+	}
+
+	// the manifest is walked only once although it is also a node
+	handler := &recordingContentHandler{}
+	if err := fetchContent(ctx, store, []ocispec.Descriptor{index, manifest}, handler); err != nil {
+		t.Fatalf("fetchContent() error = %v", err)
+	}
+	short := func(desc ocispec.Descriptor) string { return desc.Digest.Encoded()[:4] }
+	want := []string{short(index) + "->" + short(manifest), short(manifest) + "->" + short(layer)}
+	if !reflect.DeepEqual(handler.edges, want) {
+		t.Errorf("edges = %v, want %v", handler.edges, want)
+	}
+}
+
+type recordingChildHandler struct {
+	recordingDiscoverHandler
+	children []digest.Digest
+}
+
+func (h *recordingChildHandler) OnChildDiscovered(child, _ ocispec.Descriptor) error {
+	h.children = append(h.children, child.Digest)
+	return nil
+}
+
+func Test_fetchChildrenReferrers(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	pack := func(artifactType string, subject *ocispec.Descriptor) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{Subject: subject})
+		if err != nil {
+			t.Fatalf("failed to pack manifest: %v", err)
+		}
+		return desc
+	}
+	amd64 := pack("test/amd64", nil)
+	arm64 := pack("test/arm64", nil)
+	pack("test/sig", &amd64)
+	pack("test/sig", &arm64)
+	pack("test/sbom", &arm64)
+	indexBytes, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{amd64, arm64},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal index: %v", err)
+	}
+	index := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, indexBytes)
+	if err := store.Push(ctx, index, bytes.NewReader(indexBytes)); err != nil {
+		t.Fatalf("failed to push index: %v", err)
+	}
+
+	t.Run("index", func(t *testing.T) {
+		handler := &recordingChildHandler{}
+		if err := fetchChildrenReferrers(ctx, store, index, nil, handler, handler, 0, 3, make(map[digest.Digest]bool)); err != nil {
+			t.Fatalf("fetchChildrenReferrers() error = %v", err)
+		}
+		if want := []digest.Digest{amd64.Digest, arm64.Digest}; !reflect.DeepEqual(handler.children, want) {
+			t.Errorf("children = %v, want %v", handler.children, want)
+		}
+		if handler.count != 3 {
+			t.Errorf("OnDiscovered called %d times, want 3", handler.count)
+		}
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		handler := &recordingChildHandler{}
+		filter := &graph.ReferrerFilter{ArtifactTypes: []string{"test/sbom"}}
+		if err := fetchChildrenReferrers(ctx, store, index, filter, handler, handler, 0, 3, make(map[digest.Digest]bool)); err != nil {
+			t.Fatalf("fetchChildrenReferrers() error = %v", err)
+		}
+		if handler.count != 1 {
+			t.Errorf("OnDiscovered called %d times, want 1", handler.count)
+		}
+	})
+
+	t.Run("not an index", func(t *testing.T) {
+		handler := &recordingChildHandler{}
+		if err := fetchChildrenReferrers(ctx, store, amd64, nil, handler, handler, 0, 3, make(map[digest.Digest]bool)); err != nil {
+			t.Fatalf("fetchChildrenReferrers() error = %v", err)
+		}
+		if len(handler.children) != 0 || handler.count != 0 {
+			t.Errorf("children = %v, OnDiscovered called %d times, want none", handler.children, handler.count)
+		}
+	})
+}
+
+// pagedReferrerTarget serves the referrers of each manifest in pages of
+// pageSize, recording the number of discovered referrers when each page is
+// requested.
+type pagedReferrerTarget struct {
+	cyclicReferrerTarget
+	pageSize int
+	handler  *recordingDiscoverHandler
+	seen     []int
+}
+
+func (t *pagedReferrerTarget) Referrers(_ context.Context, desc ocispec.Descriptor, _ string, fn func(referrers []ocispec.Descriptor) error) error {
+	referrers := t.referrers[desc.Digest]
+	for start := 0; start < len(referrers); start += t.pageSize {
+		t.seen = append(t.seen, t.handler.count)
+		if err := fn(referrers[start:min(start+t.pageSize, len(referrers))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestFetchAllReferrers_paged(t *testing.T) {
+	root := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("root"), Size: 1}
+	var referrers []ocispec.Descriptor
+	for i := range 5 {
+		referrers = append(referrers, ocispec.Descriptor{
+			MediaType:    ocispec.MediaTypeImageManifest,
+			Digest:       digest.FromString(strconv.Itoa(i)),
+			Size:         1,
+			ArtifactType: "test/sig",
+		})
+	}
+
+	t.Run("streamed", func(t *testing.T) {
+		handler := &recordingDiscoverHandler{}
+		target := &pagedReferrerTarget{
+			cyclicReferrerTarget: cyclicReferrerTarget{referrers: map[digest.Digest][]ocispec.Descriptor{root.Digest: referrers}},
+			pageSize:             2,
+			handler:              handler,
+		}
+		if err := fetchAllReferrers(context.Background(), target, root, nil, handler, 1, 1, make(map[digest.Digest]bool)); err != nil {
+			t.Fatalf("fetchAllReferrers() error = %v", err)
+		}
+		// each page is handled before the next page is requested
+		if want := []int{0, 2, 4}; !reflect.DeepEqual(target.seen, want) {
+			t.Errorf("discovered referrers when pages requested = %v, want %v", target.seen, want)
+		}
+		if handler.count != 5 {
+			t.Errorf("OnDiscovered called %d times, want 5", handler.count)
+		}
+	})
+
+	t.Run("sorted", func(t *testing.T) {
+		handler := &recordingDiscoverHandler{}
+		target := &pagedReferrerTarget{
+			cyclicReferrerTarget: cyclicReferrerTarget{referrers: map[digest.Digest][]ocispec.Descriptor{root.Digest: referrers}},
+			pageSize:             2,
+			handler:              handler,
+		}
+		filter := &graph.ReferrerFilter{Latest: 1}
+		if err := fetchAllReferrers(context.Background(), target, root, filter, handler, 1, 1, make(map[digest.Digest]bool)); err != nil {
+			t.Fatalf("fetchAllReferrers() error = %v", err)
+		}
+		// all pages are requested before selecting the latest referrer
+		if want := []int{0, 0, 0}; !reflect.DeepEqual(target.seen, want) {
+			t.Errorf("discovered referrers when pages requested = %v, want %v", target.seen, want)
+		}
+		if handler.count != 1 {
+			t.Errorf("OnDiscovered called %d times, want 1", handler.count)
+		}
+	})
+}
+
+// orderedDiscoverHandler records the referrers of each subject in order.
+type orderedDiscoverHandler struct {
+	referrers map[digest.Digest][]ocispec.Descriptor
+}
+
+func (h *orderedDiscoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) error {
+	h.referrers[subject.Digest] = append(h.referrers[subject.Digest], referrer)
+	return nil
+}
+
+func (h *orderedDiscoverHandler) Render() error { return nil }
+
+func TestFetchAllReferrers_concurrent(t *testing.T) {
+	newDesc := func(name string) ocispec.Descriptor {
+		return ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString(name), Size: 1}
+	}
+	root := newDesc("root")
+	graphReferrers := make(map[digest.Digest][]ocispec.Descriptor)
+	for i := range 10 {
+		child := newDesc(strconv.Itoa(i))
+		graphReferrers[root.Digest] = append(graphReferrers[root.Digest], child)
+		for j := range 5 {
+			graphReferrers[child.Digest] = append(graphReferrers[child.Digest], newDesc(fmt.Sprintf("%d-%d", i, j)))
+		}
+	}
+	target := &cyclicReferrerTarget{referrers: graphReferrers}
+
+	for _, concurrency := range []int{1, 4} {
+		t.Run(strconv.Itoa(concurrency), func(t *testing.T) {
+			handler := &orderedDiscoverHandler{referrers: make(map[digest.Digest][]ocispec.Descriptor)}
+			if err := fetchAllReferrers(context.Background(), target, root, nil, handler, 0, concurrency, make(map[digest.Digest]bool)); err != nil {
+				t.Fatalf("fetchAllReferrers() error = %v", err)
+			}
+			// the referrers of each subject are discovered in order
+			if !reflect.DeepEqual(handler.referrers, graphReferrers) {
+				t.Errorf("discovered referrers = %v, want %v", handler.referrers, graphReferrers)
+			}
+		})
+	}
+}
+
+func Test_recordReferrers(t *testing.T) {
+	root := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("root"), Size: 1}
+	var referrers []ocispec.Descriptor
+	for i := range 3 {
+		referrers = append(referrers, ocispec.Descriptor{
+			MediaType:    ocispec.MediaTypeImageManifest,
+			Digest:       digest.FromString(strconv.Itoa(i)),
+			Size:         1,
+			ArtifactType: "test/sig",
+		})
+	}
+	target := &cyclicReferrerTarget{referrers: map[digest.Digest][]ocispec.Descriptor{root.Digest: referrers}}
+
+	for _, format := range []*option.FormatType{option.FormatTypeJSONLines, option.FormatTypeText} {
+		t.Run(format.Name+" streams without recording", func(t *testing.T) {
+			var buf bytes.Buffer
+			handler, err := display.NewDiscoverHandler(output.NewPrinter(&buf, io.Discard), option.Format{Type: format.Name}, "localhost:5000/test", "localhost:5000/test:v1", root, false, nil, nil)
+			if err != nil {
+				t.Fatalf("NewDiscoverHandler() error = %v", err)
+			}
+			discoverHandler, recorder := recordReferrers(handler, false)
+			if recorder != nil {
+				t.Fatal("recordReferrers() recorder = non-nil, want nil without --include-content")
+			}
+			if discoverHandler != handler {
+				t.Fatalf("recordReferrers() handler = %T, want the handler as is", discoverHandler)
+			}
+			if err := fetchAllReferrers(context.Background(), target, root, nil, discoverHandler, 1, 1, make(map[digest.Digest]bool)); err != nil {
+				t.Fatalf("fetchAllReferrers() error = %v", err)
+			}
+			if buf.Len() == 0 {
+				t.Error("referrers are not streamed to the output")
+			}
+		})
+	}
+
+	t.Run("recorded with content", func(t *testing.T) {
+		discoverHandler, recorder := recordReferrers(&recordingDiscoverHandler{}, true)
+		if recorder == nil {
+			t.Fatal("recordReferrers() recorder = nil, want non-nil with --include-content")
+		}
+		if err := fetchAllReferrers(context.Background(), target, root, nil, discoverHandler, 1, 1, make(map[digest.Digest]bool)); err != nil {
+			t.Fatalf("fetchAllReferrers() error = %v", err)
+		}
+		if !reflect.DeepEqual(recorder.referrers, referrers) {
+			t.Errorf("recorded referrers = %v, want %v", recorder.referrers, referrers)
+		}
+	})
+}
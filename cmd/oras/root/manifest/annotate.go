@@ -0,0 +1,312 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/listener"
+)
+
+var errNoAnnotationChange = errors.New("no annotation change is requested")
+
+type annotateOptions struct {
+	option.Common
+	option.Target
+	option.Format
+
+	setArguments     []string
+	unsetArguments   []string
+	tags             []string
+	migrateReferrers bool
+
+	set map[string]string
+}
+
+func annotateCmd() *cobra.Command {
+	var opts annotateOptions
+	cmd := &cobra.Command{
+		Use:   "annotate [flags] <name>{:<tag>|@<digest>} {--set <key>=<value>|--unset <key>} [...]",
+		Short: "[Experimental] Edit the annotations of a manifest",
+		Long: `[Experimental] Edit the annotations of a manifest
+
+The edited manifest is pushed with a new digest, and the tag in the reference is
+moved to it. The original manifest is left in place. Referrers of the original
+manifest, such as signatures and SBOMs, are not attached to the edited manifest
+unless they are migrated with the "--migrate-referrers" flag, which re-pushes
+them recursively with updated subjects. Signatures embedding the digest of the
+original manifest in their payloads, such as Notary Project and cosign
+signatures, remain bound to the original digest and need to be re-signed.
+
+Example - Set the annotation 'key' of the manifest tagged 'v1' and move the tag to the edited manifest:
+  oras manifest annotate localhost:5000/hello:v1 --set key=value
+
+Example - Set an annotation and remove another one:
+  oras manifest annotate localhost:5000/hello:v1 --set key1=value1 --unset key2
+
+Example - Edit an annotation and migrate the referrers of the manifest to the edited manifest:
+  oras manifest annotate --migrate-referrers localhost:5000/hello:v1 --set key=value
+
+Example - Edit the annotations of a manifest specified by its digest and tag the edited manifest with 'v1' and 'v1.0':
+  oras manifest annotate localhost:5000/hello@sha256:99e4703fbf30916f549cd6bfa9cdbab614b5392fbe64fdee971359a77073cdf9 --set key=value --tag v1 --tag v1.0
+
+Example - Edit the annotations of a manifest in an OCI image layout folder 'layout-dir' and output the digest mapping in JSON:
+  oras manifest annotate --oci-layout --format json layout-dir:v1 --unset key
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the manifest to annotate"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if len(opts.setArguments) == 0 && len(opts.unsetArguments) == 0 {
+				return &oerrors.Error{
+					Err:            errNoAnnotationChange,
+					Recommendation: `Please specify the annotations to edit via the "--set" or "--unset" flag`,
+				}
+			}
+			var err error
+			if opts.set, err = parseAnnotationEdits(opts.setArguments, opts.unsetArguments); err != nil {
+				return err
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return annotateManifest(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.setArguments, "set", "", nil, "annotation to set, in the form of `key=value`")
+	cmd.Flags().StringArrayVarP(&opts.unsetArguments, "unset", "", nil, "annotation `key` to remove")
+	cmd.Flags().StringArrayVarP(&opts.tags, "tag", "", nil, "extra tags for the edited manifest")
+	cmd.Flags().BoolVarP(&opts.migrateReferrers, "migrate-referrers", "", false, "re-push the referrers of the manifest, recursively, with their subjects updated to the edited manifest")
+	opts.SetTypes(
+		option.FormatTypeText,
+		option.FormatTypeJSON,
+	)
+	opts.EnableDistributionSpecFlag()
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func annotateManifest(cmd *cobra.Command, opts *annotateOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewManifestAnnotateHandler(opts.Printer, opts.Format, &opts.Target)
+	if err != nil {
+		return err
+	}
+	target, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+
+	from, manifestBytes, err := oras.FetchBytes(ctx, target, opts.Reference, oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the content of %q: %w", opts.RawReference, err)
+	}
+	if !descriptor.IsManifest(from) {
+		return fmt.Errorf("%s is not a manifest", opts.RawReference)
+	}
+	editedBytes, changed, err := editAnnotations(manifestBytes, opts.set, opts.unsetArguments)
+	if err != nil {
+		return fmt.Errorf("failed to edit the annotations of %q: %w", opts.RawReference, err)
+	}
+	if !changed {
+		if err := handler.OnAnnotationSkipped(from); err != nil {
+			return err
+		}
+		return handler.Render()
+	}
+
+	// push the edited manifest and migrate the referrers before moving the
+	// tags, so that the referrers are in place once the tags are moved
+	to := content.NewDescriptorFromBytes(from.MediaType, editedBytes)
+	if err := pushIfNotExist(ctx, target, to, editedBytes); err != nil {
+		return err
+	}
+	if err := handler.OnAnnotated(from, to); err != nil {
+		return err
+	}
+	if opts.migrateReferrers {
+		if err := migrateReferrers(ctx, target, from, to, handler, make(map[digest.Digest]bool)); err != nil {
+			return err
+		}
+	}
+
+	tags := opts.tags
+	if opts.Reference != "" && !contentutil.IsDigest(opts.Reference) {
+		tags = append([]string{opts.Reference}, tags...)
+	}
+	if len(tags) != 0 {
+		tagListener := listener.NewTaggedListener(target, handler.OnTagged)
+		if _, err := oras.TagBytesN(ctx, tagListener, to.MediaType, editedBytes, tags, oras.DefaultTagBytesNOptions); err != nil {
+			return err
+		}
+	}
+	return handler.Render()
+}
+
+// parseAnnotationEdits parses the "key=value" pairs of annotations to set,
+// and checks for conflicts with the keys of annotations to unset.
+func parseAnnotationEdits(setArguments []string, unsetArguments []string) (map[string]string, error) {
+	set := make(map[string]string, len(setArguments))
+	for _, arg := range setArguments {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, &oerrors.Error{
+				Err:            fmt.Errorf("invalid annotation %q", arg),
+				Recommendation: `Please use the correct format in the flag: --set "key=value"`,
+			}
+		}
+		if _, ok := set[key]; ok {
+			return nil, fmt.Errorf("duplicate annotation key to set: %s", key)
+		}
+		set[key] = value
+	}
+	for _, key := range unsetArguments {
+		if _, ok := set[key]; ok {
+			return nil, fmt.Errorf("annotation key %s cannot be both set and unset", key)
+		}
+	}
+	return set, nil
+}
+
+// editAnnotations applies the annotation edits to the content of a manifest.
+// Fields other than the annotations are kept as is. The returned bool is
+// false if the annotations are unchanged.
+func editAnnotations(manifestBytes []byte, set map[string]string, unset []string) ([]byte, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(manifestBytes, &fields); err != nil {
+		return nil, false, err
+	}
+	var annotations map[string]string
+	if raw, ok := fields["annotations"]; ok {
+		if err := json.Unmarshal(raw, &annotations); err != nil {
+			return nil, false, err
+		}
+	}
+	edited := maps.Clone(annotations)
+	if edited == nil {
+		edited = make(map[string]string)
+	}
+	for _, key := range unset {
+		delete(edited, key)
+	}
+	maps.Copy(edited, set)
+	if maps.Equal(annotations, edited) {
+		return nil, false, nil
+	}
+
+	if len(edited) == 0 {
+		delete(fields, "annotations")
+	} else {
+		raw, err := json.Marshal(edited)
+		if err != nil {
+			return nil, false, err
+		}
+		fields["annotations"] = raw
+	}
+	editedBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return editedBytes, true, nil
+}
+
+// setSubject replaces the subject in the content of a manifest.
+func setSubject(manifestBytes []byte, subject ocispec.Descriptor) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(manifestBytes, &fields); err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(descriptor.Plain(subject))
+	if err != nil {
+		return nil, err
+	}
+	fields["subject"] = raw
+	return json.Marshal(fields)
+}
+
+// migrateReferrers re-pushes the referrers of from with their subjects
+// updated to to. As the digests of the referrers change, their own referrers
+// are migrated recursively. visited tracks the manifests already migrated so
+// that a cyclic referrer graph, which a malicious registry can craft, does not
+// cause unbounded recursion.
+func migrateReferrers(ctx context.Context, target oras.GraphTarget, from ocispec.Descriptor, to ocispec.Descriptor, handler metadata.ManifestAnnotateHandler, visited map[digest.Digest]bool) error {
+	if visited[from.Digest] {
+		return nil
+	}
+	visited[from.Digest] = true
+	referrers, err := registry.Referrers(ctx, target, from, "")
+	if err != nil {
+		return fmt.Errorf("failed to list the referrers of %s: %w", from.Digest, err)
+	}
+	for _, referrer := range referrers {
+		if visited[referrer.Digest] {
+			continue
+		}
+		referrerBytes, err := content.FetchAll(ctx, target, referrer)
+		if err != nil {
+			return err
+		}
+		migratedBytes, err := setSubject(referrerBytes, to)
+		if err != nil {
+			return fmt.Errorf("failed to update the subject of %s: %w", referrer.Digest, err)
+		}
+		migrated := content.NewDescriptorFromBytes(referrer.MediaType, migratedBytes)
+		migrated.ArtifactType = referrer.ArtifactType
+		migrated.Annotations = referrer.Annotations
+		if err := pushIfNotExist(ctx, target, descriptor.Plain(migrated), migratedBytes); err != nil {
+			return err
+		}
+		if err := handler.OnReferrerMigrated(referrer, migrated); err != nil {
+			return err
+		}
+		if err := migrateReferrers(ctx, target, referrer, migrated, handler, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushIfNotExist pushes the content of desc to target, ignoring the error of
+// existing content.
+func pushIfNotExist(ctx context.Context, target oras.Target, desc ocispec.Descriptor, contentBytes []byte) error {
+	if err := target.Push(ctx, desc, bytes.NewReader(contentBytes)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return err
+	}
+	return nil
+}
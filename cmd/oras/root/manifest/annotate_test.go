@@ -0,0 +1,253 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry"
+)
+
+func Test_parseAnnotationEdits(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     []string
+		unset   []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "set and unset", set: []string{"a=1", "b=x=y", "c="}, unset: []string{"d"}, want: map[string]string{"a": "1", "b": "x=y", "c": ""}},
+		{name: "invalid format", set: []string{"a"}, wantErr: true},
+		{name: "empty key", set: []string{"=1"}, wantErr: true},
+		{name: "duplicate key", set: []string{"a=1", "a=2"}, wantErr: true},
+		{name: "set and unset the same key", set: []string{"a=1"}, unset: []string{"a"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAnnotationEdits(tt.set, tt.unset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAnnotationEdits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAnnotationEdits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_editAnnotations(t *testing.T) {
+	manifest := `{"schemaVersion":2,"annotations":{"a":"1","b":"2"},"x-custom":{"keep":true}}`
+	tests := []struct {
+		name        string
+		set         map[string]string
+		unset       []string
+		want        string
+		wantChanged bool
+	}{
+		{
+			name:        "set and unset",
+			set:         map[string]string{"a": "10", "c": "3"},
+			unset:       []string{"b"},
+			want:        `{"annotations":{"a":"10","c":"3"},"schemaVersion":2,"x-custom":{"keep":true}}`,
+			wantChanged: true,
+		},
+		{
+			name:        "unset all",
+			unset:       []string{"a", "b"},
+			want:        `{"schemaVersion":2,"x-custom":{"keep":true}}`,
+			wantChanged: true,
+		},
+		{
+			name:  "unchanged",
+			set:   map[string]string{"a": "1"},
+			unset: []string{"missing"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed, err := editAnnotations([]byte(manifest), tt.set, tt.unset)
+			if err != nil {
+				t.Fatalf("editAnnotations() error = %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Fatalf("editAnnotations() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if string(got) != tt.want {
+				t.Errorf("editAnnotations() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("no annotations", func(t *testing.T) {
+		got, changed, err := editAnnotations([]byte(`{"schemaVersion":2}`), map[string]string{"a": "1"}, nil)
+		if err != nil || !changed {
+			t.Fatalf("editAnnotations() changed = %v, error = %v", changed, err)
+		}
+		if want := `{"annotations":{"a":"1"},"schemaVersion":2}`; string(got) != want {
+			t.Errorf("editAnnotations() = %s, want %s", got, want)
+		}
+	})
+}
+
+type migrationRecorder struct {
+	mappings map[digest.Digest]ocispec.Descriptor
+}
+
+func (r *migrationRecorder) OnAnnotated(_, _ ocispec.Descriptor) error { return nil }
+
+func (r *migrationRecorder) OnReferrerMigrated(from, to ocispec.Descriptor) error {
+	r.mappings[from.Digest] = to
+	return nil
+}
+
+func (r *migrationRecorder) OnAnnotationSkipped(_ ocispec.Descriptor) error { return nil }
+
+func (r *migrationRecorder) OnTagged(_ ocispec.Descriptor, _ string) error { return nil }
+
+func (r *migrationRecorder) Render() error { return nil }
+
+// cyclicReferrerTarget lists the same referrers for any subject, forming a
+// cyclic referrer graph.
+type cyclicReferrerTarget struct {
+	oras.GraphTarget
+	referrers []ocispec.Descriptor
+}
+
+func (t *cyclicReferrerTarget) Referrers(_ context.Context, _ ocispec.Descriptor, _ string, fn func(referrers []ocispec.Descriptor) error) error {
+	return fn(t.referrers)
+}
+
+func Test_migrateReferrers_cycle(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	push := func(m ocispec.Manifest) ocispec.Descriptor {
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, b)
+		if err := store.Push(ctx, desc, bytes.NewReader(b)); err != nil {
+			t.Fatalf("failed to push: %v", err)
+		}
+		return desc
+	}
+	newManifest := func(subject *ocispec.Descriptor, annotations map[string]string) ocispec.Manifest {
+		return ocispec.Manifest{
+			MediaType:   ocispec.MediaTypeImageManifest,
+			Config:      ocispec.DescriptorEmptyJSON,
+			Layers:      []ocispec.Descriptor{},
+			Subject:     subject,
+			Annotations: annotations,
+		}
+	}
+	from := push(newManifest(nil, map[string]string{"k": "1"}))
+	to := push(newManifest(nil, map[string]string{"k": "2"}))
+	sig := push(newManifest(&from, nil))
+
+	// the referrer is listed as a referrer of itself
+	target := &cyclicReferrerTarget{GraphTarget: store, referrers: []ocispec.Descriptor{sig}}
+	recorder := &migrationRecorder{mappings: make(map[digest.Digest]ocispec.Descriptor)}
+	if err := migrateReferrers(ctx, target, from, to, recorder, make(map[digest.Digest]bool)); err != nil {
+		t.Fatalf("migrateReferrers() error = %v", err)
+	}
+	if len(recorder.mappings) != 1 {
+		t.Fatalf("migrateReferrers() migrated %d referrers, want 1", len(recorder.mappings))
+	}
+}
+
+func Test_migrateReferrers(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	push := func(v any) ocispec.Descriptor {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, b)
+		if err := store.Push(ctx, desc, bytes.NewReader(b)); err != nil {
+			t.Fatalf("failed to push: %v", err)
+		}
+		return desc
+	}
+	config := content.NewDescriptorFromBytes(ocispec.MediaTypeEmptyJSON, ocispec.DescriptorEmptyJSON.Data)
+	if err := store.Push(ctx, config, bytes.NewReader(ocispec.DescriptorEmptyJSON.Data)); err != nil {
+		t.Fatalf("failed to push config: %v", err)
+	}
+	newManifest := func(artifactType string, subject *ocispec.Descriptor, annotations map[string]string) ocispec.Manifest {
+		return ocispec.Manifest{
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: artifactType,
+			Config:       config,
+			Layers:       []ocispec.Descriptor{},
+			Subject:      subject,
+			Annotations:  annotations,
+		}
+	}
+	from := push(newManifest("test/image", nil, map[string]string{"k": "1"}))
+	to := push(newManifest("test/image", nil, map[string]string{"k": "2"}))
+	sig := push(newManifest("test/sig", &from, nil))
+	sigOfSig := push(newManifest("test/sig", &sig, nil))
+
+	recorder := &migrationRecorder{mappings: make(map[digest.Digest]ocispec.Descriptor)}
+	if err := migrateReferrers(ctx, store, from, to, recorder, make(map[digest.Digest]bool)); err != nil {
+		t.Fatalf("migrateReferrers() error = %v", err)
+	}
+	if len(recorder.mappings) != 2 {
+		t.Fatalf("migrateReferrers() migrated %d referrers, want 2", len(recorder.mappings))
+	}
+
+	// check the migrated referrers are attached to the new subjects
+	for _, pair := range [][2]ocispec.Descriptor{
+		{to, recorder.mappings[sig.Digest]},
+		{recorder.mappings[sig.Digest], recorder.mappings[sigOfSig.Digest]},
+	} {
+		subject, referrer := pair[0], pair[1]
+		var manifest ocispec.Manifest
+		b, err := content.FetchAll(ctx, store, referrer)
+		if err != nil {
+			t.Fatalf("failed to fetch the migrated referrer: %v", err)
+		}
+		if err := json.Unmarshal(b, &manifest); err != nil {
+			t.Fatalf("failed to unmarshal the migrated referrer: %v", err)
+		}
+		if manifest.Subject == nil || manifest.Subject.Digest != subject.Digest {
+			t.Errorf("subject of the migrated referrer = %v, want %v", manifest.Subject, subject.Digest)
+		}
+		if manifest.ArtifactType != "test/sig" {
+			t.Errorf("artifact type of the migrated referrer = %s, want test/sig", manifest.ArtifactType)
+		}
+		referrers, err := registry.Referrers(ctx, store, subject, "")
+		if err != nil {
+			t.Fatalf("failed to list referrers: %v", err)
+		}
+		if len(referrers) != 1 || referrers[0].Digest != referrer.Digest {
+			t.Errorf("referrers of %s = %v, want %s", subject.Digest, referrers, referrer.Digest)
+		}
+	}
+
+	// the original referrers are kept
+	if exists, err := store.Exists(ctx, sig); err != nil || !exists {
+		t.Errorf("original referrer exists = %v, error = %v", exists, err)
+	}
+}
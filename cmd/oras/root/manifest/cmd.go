@@ -27,10 +27,15 @@ func Cmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		annotateCmd(),
+		convertCmd(),
 		deleteCmd(),
+		diffCmd(),
 		fetchCmd(),
 		fetchConfigCmd(),
+		listFilesCmd(),
 		pushCmd(),
+		validateCmd(),
 		index.Cmd(),
 	)
 	return cmd
@@ -0,0 +1,213 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/listener"
+)
+
+type convertOptions struct {
+	option.Common
+	option.Target
+	option.Format
+
+	imageSpec option.ImageSpec
+	tags      []string
+}
+
+func convertCmd() *cobra.Command {
+	var opts convertOptions
+	cmd := &cobra.Command{
+		Use:   "convert [flags] --image-spec {v1.0|v1.1} <name>{:<tag>|@<digest>}",
+		Short: "[Experimental] Convert an artifact between the image-spec v1.0 and v1.1 layouts",
+		Long: `[Experimental] Convert an artifact between the image-spec v1.0 and v1.1 layouts
+
+In the image-spec v1.0 layout, the artifact type of an artifact is the media type
+of its config. In the image-spec v1.1 layout, the artifact type is in the
+"artifactType" field and the config is empty unless it has content.
+
+The converted manifest reuses the config and layers of the original manifest and
+is pushed with a new digest. The original manifest and its tags are left in
+place, and the converted manifest is tagged only with the "--tag" flag. As
+image-spec v1.0 does not support the subject field, artifacts with a subject,
+such as signatures and SBOMs, cannot be converted to the image-spec v1.0 layout.
+
+Example - Convert the artifact tagged 'v1' to the image-spec v1.0 layout and tag it with 'v1-legacy':
+  oras manifest convert --image-spec v1.0 localhost:5000/hello:v1 --tag v1-legacy
+
+Example - Convert the artifact tagged 'v1' to the image-spec v1.1 layout and move the tag 'v1' to it:
+  oras manifest convert --image-spec v1.1 localhost:5000/hello:v1 --tag v1
+
+Example - Convert an artifact specified by its digest to the image-spec v1.1 layout:
+  oras manifest convert --image-spec v1.1 localhost:5000/hello@sha256:99e4703fbf30916f549cd6bfa9cdbab614b5392fbe64fdee971359a77073cdf9
+
+Example - Convert an artifact in an OCI image layout folder 'layout-dir' and output the result in JSON:
+  oras manifest convert --oci-layout --format json --image-spec v1.0 layout-dir:v1 --tag v1-legacy
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifact to convert"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return convertManifest(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().Var(&opts.imageSpec, "image-spec", "image spec layout to convert the artifact to. Options: "+opts.imageSpec.Options())
+	_ = cmd.MarkFlagRequired("image-spec")
+	cmd.Flags().StringArrayVarP(&opts.tags, "tag", "", nil, "tags for the converted manifest")
+	opts.SetTypes(
+		option.FormatTypeText,
+		option.FormatTypeJSON,
+	)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func convertManifest(cmd *cobra.Command, opts *convertOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewManifestConvertHandler(opts.Printer, opts.Format, &opts.Target)
+	if err != nil {
+		return err
+	}
+	target, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+
+	from, manifestBytes, err := oras.FetchBytes(ctx, target, opts.Reference, oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the content of %q: %w", opts.RawReference, err)
+	}
+	if from.MediaType != ocispec.MediaTypeImageManifest {
+		return fmt.Errorf("%s is not an OCI image manifest but of media type %q", opts.RawReference, from.MediaType)
+	}
+	convertedBytes, changed, err := convertArtifact(manifestBytes, opts.imageSpec.Flag)
+	if err != nil {
+		return fmt.Errorf("failed to convert %q: %w", opts.RawReference, err)
+	}
+	if !changed {
+		if err := handler.OnConversionSkipped(from, opts.imageSpec.Flag); err != nil {
+			return err
+		}
+		return handler.Render()
+	}
+
+	to := content.NewDescriptorFromBytes(from.MediaType, convertedBytes)
+	if err := pushIfNotExist(ctx, target, to, convertedBytes); err != nil {
+		return err
+	}
+	if err := handler.OnConverted(from, to, opts.imageSpec.Flag); err != nil {
+		return err
+	}
+	if len(opts.tags) != 0 {
+		tagListener := listener.NewTaggedListener(target, handler.OnTagged)
+		if _, err := oras.TagBytesN(ctx, tagListener, to.MediaType, convertedBytes, opts.tags, oras.DefaultTagBytesNOptions); err != nil {
+			return err
+		}
+	}
+	return handler.Render()
+}
+
+// convertArtifact converts the content of an artifact manifest to the layout
+// of imageSpec. Fields other than the artifact type and the config are kept
+// as is. The returned bool is false if the manifest is already in the layout.
+func convertArtifact(manifestBytes []byte, imageSpec string) ([]byte, bool, error) {
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, false, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(manifestBytes, &fields); err != nil {
+		return nil, false, err
+	}
+
+	config := manifest.Config
+	switch imageSpec {
+	case option.ImageSpecV1_1:
+		if manifest.ArtifactType != "" {
+			return nil, false, nil
+		}
+		artifactType := config.MediaType
+		switch artifactType {
+		case ocispec.MediaTypeImageConfig:
+			return nil, false, errors.New("an image cannot be converted as an artifact")
+		case oras.MediaTypeUnknownConfig, ocispec.MediaTypeEmptyJSON:
+			// the empty config does not tell the artifact type
+			artifactType = oras.MediaTypeUnknownArtifact
+		}
+		if config.Digest == ocispec.DescriptorEmptyJSON.Digest {
+			config = ocispec.DescriptorEmptyJSON
+		}
+		raw, err := json.Marshal(artifactType)
+		if err != nil {
+			return nil, false, err
+		}
+		fields["artifactType"] = raw
+	case option.ImageSpecV1_0:
+		if manifest.ArtifactType == "" {
+			return nil, false, nil
+		}
+		if manifest.Subject != nil {
+			return nil, false, &oerrors.Error{
+				Err:            errors.New("the subject field is not supported by image-spec v1.0"),
+				Recommendation: "Keep the artifact in the image-spec v1.1 layout",
+			}
+		}
+		switch config.MediaType {
+		case ocispec.MediaTypeEmptyJSON:
+			config.MediaType = manifest.ArtifactType
+			if config.MediaType == oras.MediaTypeUnknownArtifact {
+				config.MediaType = oras.MediaTypeUnknownConfig
+			}
+			// embedded data is not supported by image-spec v1.0
+			config.Data = nil
+		case manifest.ArtifactType:
+		default:
+			return nil, false, fmt.Errorf("the artifact type %q would be lost as the config is of media type %q", manifest.ArtifactType, config.MediaType)
+		}
+		delete(fields, "artifactType")
+	default:
+		return nil, false, fmt.Errorf("unknown image spec %q", imageSpec)
+	}
+
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return nil, false, err
+	}
+	fields["config"] = raw
+	convertedBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return convertedBytes, true, nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"testing"
+
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+func Test_convertArtifact(t *testing.T) {
+	const (
+		layers      = `"layers":[{"mediaType":"text/plain","digest":"sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824","size":5}]`
+		emptyV1_0   = `{"mediaType":"application/vnd.example","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2}`
+		emptyV1_1   = `{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2,"data":"e30="}`
+		config      = `{"mediaType":"application/vnd.example","digest":"sha256:b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c","size":4}`
+		unknownV1_0 = `{"mediaType":"application/vnd.unknown.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2}`
+		subject     = `"subject":{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c","size":4}`
+	)
+	tests := []struct {
+		name        string
+		manifest    string
+		imageSpec   string
+		want        string
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			name:        "v1.0 to v1.1 with an empty config",
+			manifest:    `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":` + emptyV1_0 + `,` + layers + `,"annotations":{"a":"1"}}`,
+			imageSpec:   option.ImageSpecV1_1,
+			want:        `{"annotations":{"a":"1"},"artifactType":"application/vnd.example","config":` + emptyV1_1 + `,` + layers + `,"mediaType":"application/vnd.oci.image.manifest.v1+json","schemaVersion":2}`,
+			wantChanged: true,
+		},
+		{
+			name:        "v1.0 to v1.1 with a config",
+			manifest:    `{"schemaVersion":2,"config":` + config + `,` + layers + `}`,
+			imageSpec:   option.ImageSpecV1_1,
+			want:        `{"artifactType":"application/vnd.example","config":` + config + `,` + layers + `,"schemaVersion":2}`,
+			wantChanged: true,
+		},
+		{
+			name:        "v1.0 to v1.1 with an unknown config",
+			manifest:    `{"schemaVersion":2,"config":` + unknownV1_0 + `,` + layers + `}`,
+			imageSpec:   option.ImageSpecV1_1,
+			want:        `{"artifactType":"application/vnd.unknown.artifact.v1","config":` + emptyV1_1 + `,` + layers + `,"schemaVersion":2}`,
+			wantChanged: true,
+		},
+		{
+			name:        "v1.0 to v1.1 with an empty config without artifact type",
+			manifest:    `{"schemaVersion":2,"config":` + emptyV1_1 + `,` + layers + `}`,
+			imageSpec:   option.ImageSpecV1_1,
+			want:        `{"artifactType":"application/vnd.unknown.artifact.v1","config":` + emptyV1_1 + `,` + layers + `,"schemaVersion":2}`,
+			wantChanged: true,
+		},
+		{
+			name:      "v1.1 to v1.1",
+			manifest:  `{"schemaVersion":2,"artifactType":"application/vnd.example","config":` + emptyV1_1 + `,` + layers + `}`,
+			imageSpec: option.ImageSpecV1_1,
+		},
+		{
+			name:      "image to v1.1",
+			manifest:  `{"schemaVersion":2,"config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},` + layers + `}`,
+			imageSpec: option.ImageSpecV1_1,
+			wantErr:   true,
+		},
+		{
+			name:        "v1.1 to v1.0 with an empty config",
+			manifest:    `{"schemaVersion":2,"artifactType":"application/vnd.example","config":` + emptyV1_1 + `,` + layers + `,"x-custom":true}`,
+			imageSpec:   option.ImageSpecV1_0,
+			want:        `{"config":` + emptyV1_0 + `,` + layers + `,"schemaVersion":2,"x-custom":true}`,
+			wantChanged: true,
+		},
+		{
+			name:        "v1.1 to v1.0 with an unknown artifact type",
+			manifest:    `{"schemaVersion":2,"artifactType":"application/vnd.unknown.artifact.v1","config":` + emptyV1_1 + `,` + layers + `}`,
+			imageSpec:   option.ImageSpecV1_0,
+			want:        `{"config":` + unknownV1_0 + `,` + layers + `,"schemaVersion":2}`,
+			wantChanged: true,
+		},
+		{
+			name:        "v1.1 to v1.0 with a config",
+			manifest:    `{"schemaVersion":2,"artifactType":"application/vnd.example","config":` + config + `,` + layers + `}`,
+			imageSpec:   option.ImageSpecV1_0,
+			want:        `{"config":` + config + `,` + layers + `,"schemaVersion":2}`,
+			wantChanged: true,
+		},
+		{
+			name:      "v1.1 to v1.0 with a config of another media type",
+			manifest:  `{"schemaVersion":2,"artifactType":"application/vnd.other","config":` + config + `,` + layers + `}`,
+			imageSpec: option.ImageSpecV1_0,
+			wantErr:   true,
+		},
+		{
+			name:      "v1.1 to v1.0 with a subject",
+			manifest:  `{"schemaVersion":2,"artifactType":"application/vnd.example","config":` + emptyV1_1 + `,` + layers + `,` + subject + `}`,
+			imageSpec: option.ImageSpecV1_0,
+			wantErr:   true,
+		},
+		{
+			name:      "v1.0 to v1.0",
+			manifest:  `{"schemaVersion":2,"config":` + config + `,` + layers + `}`,
+			imageSpec: option.ImageSpecV1_0,
+		},
+		{
+			name:        "v1.0 to v1.1 keeps the subject",
+			manifest:    `{"schemaVersion":2,"config":` + config + `,` + layers + `,` + subject + `}`,
+			imageSpec:   option.ImageSpecV1_1,
+			want:        `{"artifactType":"application/vnd.example","config":` + config + `,` + layers + `,"schemaVersion":2,` + subject + `}`,
+			wantChanged: true,
+		},
+		{
+			name:      "invalid manifest",
+			manifest:  `not a manifest`,
+			imageSpec: option.ImageSpecV1_1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed, err := convertArtifact([]byte(tt.manifest), tt.imageSpec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("convertArtifact() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("convertArtifact() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if string(got) != tt.want {
+				t.Errorf("convertArtifact() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
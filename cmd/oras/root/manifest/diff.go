@@ -0,0 +1,132 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/diff"
+)
+
+type diffOptions struct {
+	option.Common
+	option.Platform
+	option.BinaryTarget
+	option.Format
+
+	recursive bool
+	referrers bool
+}
+
+func diffCmd() *cobra.Command {
+	var opts diffOptions
+	cmd := &cobra.Command{
+		Use:   "diff [flags] <from>{:<tag>|@<digest>} <to>{:<tag>|@<digest>}",
+		Short: "[Experimental] Compare the manifests of two artifacts",
+		Long: `[Experimental] Compare the manifests of two artifacts
+
+Layers are matched by title, and manifests of indexes are matched by platform.
+Configs in JSON are compared property by property.
+
+Example - Compare 'hello:v1' with 'hello:v2' in registry 'localhost:5000':
+  oras manifest diff localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Compare the linux/amd64 manifests of two multi-arch images:
+  oras manifest diff --platform linux/amd64 localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Compare two indexes along with their modified platform manifests:
+  oras manifest diff --recursive localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Compare two artifacts along with their referrers, such as signatures and SBOMs:
+  oras manifest diff --referrers localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Compare an artifact in a registry with an artifact in an OCI image layout folder 'layout-dir', in JSON:
+  oras manifest diff --to-oci-layout --format json localhost:5000/hello:v1 layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(2), "the two artifacts to compare"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.From.RawReference = args[0]
+			opts.To.RawReference = args[1]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return diffManifests(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "compare the modified manifests of indexes")
+	cmd.Flags().BoolVarP(&opts.referrers, "referrers", "", false, "compare the direct referrers, matched by artifact type")
+	opts.SetTypes(
+		option.FormatTypeText,
+		option.FormatTypeJSON,
+		option.FormatTypeGoTemplate,
+	)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.BinaryTarget)
+}
+
+func diffManifests(cmd *cobra.Command, opts *diffOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewManifestDiffHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	src, err := opts.From.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.From.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	dst, err := opts.To.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.To.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+
+	resolveOpts := oras.DefaultResolveOptions
+	resolveOpts.TargetPlatform = opts.Platform.Platform
+	from, err := oras.Resolve(ctx, src, opts.From.Reference, resolveOpts)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.From.RawReference, err)
+	}
+	to, err := oras.Resolve(ctx, dst, opts.To.Reference, resolveOpts)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.To.RawReference, err)
+	}
+
+	result, err := diff.Compare(ctx, src, from, dst, to, diff.Options{
+		Recursive: opts.recursive,
+		Referrers: opts.referrers,
+	})
+	if err != nil {
+		return err
+	}
+	if err := handler.OnCompared(opts.From.Path, from, opts.To.Path, to, result); err != nil {
+		return err
+	}
+	return handler.Render()
+}
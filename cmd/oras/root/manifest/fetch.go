@@ -16,17 +16,29 @@ limitations under the License.
 package manifest
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/graph"
 )
 
 type fetchOptions struct {
@@ -38,8 +50,11 @@ type fetchOptions struct {
 	option.Target
 	option.Format
 
-	mediaTypes []string
-	outputPath string
+	mediaTypes       []string
+	outputPath       string
+	recursive        bool
+	outputDir        string
+	includeReferrers bool
 }
 
 func fetchCmd() *cobra.Command {
@@ -70,6 +85,12 @@ Example - Fetch manifest from a registry with certain platform:
 Example - Fetch manifest from a registry with prettified json result:
   oras manifest fetch --pretty localhost:5000/hello:v1
 
+Example - [Experimental] Fetch an index, its manifests and their configs recursively, and write them to the directory 'out':
+  oras manifest fetch --recursive --output-dir out localhost:5000/hello:v1
+
+Example - [Experimental] Fetch an index recursively along with the referrers, such as signatures and SBOMs:
+  oras manifest fetch --recursive --include-referrers --output-dir out localhost:5000/hello:v1
+
 Example - Fetch raw manifest from an OCI image layout folder 'layout-dir':
   oras manifest fetch --oci-layout layout-dir:v1
 
@@ -93,6 +114,9 @@ Example - Fetch raw manifest tagged 'example.com:v1' from an OCI image layout fo
 			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "format", "descriptor"); err != nil {
 				return err
 			}
+			if err := opts.checkRecursiveFlags(cmd); err != nil {
+				return err
+			}
 			opts.RawReference = args[0]
 			return option.Parse(cmd, &opts)
 		},
@@ -104,6 +128,9 @@ Example - Fetch raw manifest tagged 'example.com:v1' from an OCI image layout fo
 
 	cmd.Flags().StringSliceVarP(&opts.mediaTypes, "media-type", "", nil, "accepted media types")
 	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "file `path` to write the fetched manifest to, use - for stdout")
+	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "[Experimental] fetch the manifests of indexes and the configs recursively, requires --output-dir")
+	cmd.Flags().StringVarP(&opts.outputDir, "output-dir", "", "", "[Experimental] `directory` to write the recursively fetched manifests and configs to, as <algorithm>-<encoded digest>.json files")
+	cmd.Flags().BoolVarP(&opts.includeReferrers, "include-referrers", "", false, "[Experimental] fetch the referrers of the manifests recursively, requires --recursive")
 	opts.SetTypes(
 		option.FormatTypeText,
 		option.FormatTypeJSON.WithUsage("Print in prettified JSON format"),
@@ -138,6 +165,9 @@ func fetchManifest(cmd *cobra.Command, opts *fetchOptions) (fetchErr error) {
 	if err != nil {
 		return err
 	}
+	if opts.recursive {
+		return fetchRecursively(ctx, opts, target, src)
+	}
 	var desc ocispec.Descriptor
 	var content []byte
 	if opts.OutputDescriptor && opts.outputPath == "" {
@@ -162,3 +192,168 @@ func fetchManifest(cmd *cobra.Command, opts *fetchOptions) (fetchErr error) {
 	}
 	return metadataHandler.OnFetched(opts.Path, desc, content)
 }
+
+// checkRecursiveFlags checks the flags used with or required by --recursive.
+func (opts *fetchOptions) checkRecursiveFlags(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	if !opts.recursive {
+		for _, name := range []string{"output-dir", "include-referrers"} {
+			if flags.Changed(name) {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("`--%s` can only be used with `--recursive`", name),
+					Recommendation: "Please use `--recursive` to fetch the manifests recursively",
+				}
+			}
+		}
+		return nil
+	}
+	if opts.outputDir == "" {
+		return &oerrors.Error{
+			Err:            errors.New("`--recursive` requires `--output-dir`"),
+			Recommendation: "Please specify the directory to write the fetched manifests to via `--output-dir`",
+		}
+	}
+	for _, name := range []string{"output", "descriptor", "pretty"} {
+		if flags.Changed(name) {
+			return fmt.Errorf("`--%s` cannot be used with `--recursive` at the same time", name)
+		}
+	}
+	if opts.FormatFlag == option.FormatTypeGoTemplate.Name {
+		return fmt.Errorf("`--format %s` cannot be used with `--recursive` at the same time", opts.FormatFlag)
+	}
+	return nil
+}
+
+// fetchRecursively fetches the root manifest, the manifests of indexes, the
+// configs and optionally the referrers, and writes them to the output
+// directory. Layers are not fetched.
+func fetchRecursively(ctx context.Context, opts *fetchOptions, target oras.ReadOnlyGraphTarget, src oras.ReadOnlyTarget) error {
+	handler, err := display.NewManifestExportHandler(opts.Printer, opts.Format, opts.outputDir)
+	if err != nil {
+		return err
+	}
+	resolveOpts := oras.DefaultResolveOptions
+	resolveOpts.TargetPlatform = opts.Platform.Platform
+	root, err := oras.Resolve(ctx, src, opts.Reference, resolveOpts)
+	if err != nil {
+		return fmt.Errorf("failed to find %q: %w", opts.RawReference, err)
+	}
+	if err := os.MkdirAll(opts.outputDir, 0777); err != nil {
+		return err
+	}
+	exporter := &manifestExporter{
+		fetcher:   src,
+		outputDir: opts.outputDir,
+		handler:   handler,
+		fetched:   make(map[digest.Digest][]byte),
+		walked:    make(map[digest.Digest]bool),
+	}
+	if opts.includeReferrers {
+		exporter.referrerLister = target
+	}
+	file, err := exporter.write(ctx, root)
+	if err != nil {
+		return err
+	}
+	if err := handler.OnRootExported(opts.Path, root, file); err != nil {
+		return err
+	}
+	if err := exporter.walk(ctx, root); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// manifestExporter writes the manifests and the configs of a graph to files
+// named after their digests.
+type manifestExporter struct {
+	fetcher   content.Fetcher
+	outputDir string
+	handler   metadata.ManifestExportHandler
+	// referrerLister lists the referrers to export, if not nil.
+	referrerLister content.ReadOnlyGraphStorage
+	// fetched caches the content of the written manifests to be walked.
+	fetched map[digest.Digest][]byte
+	walked  map[digest.Digest]bool
+}
+
+// Fetch implements content.Fetcher, serving the written manifests from
+// memory so that they are fetched only once.
+func (e *manifestExporter) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if contentBytes, ok := e.fetched[target.Digest]; ok {
+		return io.NopCloser(bytes.NewReader(contentBytes)), nil
+	}
+	return e.fetcher.Fetch(ctx, target)
+}
+
+// write fetches the content of desc and writes it to the output directory,
+// returning the file name.
+func (e *manifestExporter) write(ctx context.Context, desc ocispec.Descriptor) (string, error) {
+	contentBytes, err := content.FetchAll(ctx, e, desc)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", desc.Digest, err)
+	}
+	file := desc.Digest.Algorithm().String() + "-" + desc.Digest.Encoded() + ".json"
+	if err := os.WriteFile(filepath.Join(e.outputDir, file), contentBytes, 0666); err != nil {
+		return "", err
+	}
+	if descriptor.IsManifest(desc) {
+		e.fetched[desc.Digest] = contentBytes
+	}
+	return file, nil
+}
+
+// walk exports the config, the manifests and the referrers of node
+// recursively.
+func (e *manifestExporter) walk(ctx context.Context, node ocispec.Descriptor) error {
+	if e.walked[node.Digest] {
+		return nil
+	}
+	e.walked[node.Digest] = true
+
+	successors, _, config, err := graph.Successors(ctx, e, node)
+	if err != nil {
+		return err
+	}
+	if config != nil {
+		if err := e.export(ctx, *config, node, metadata.RelationConfig); err != nil {
+			return err
+		}
+	}
+	if descriptor.IsIndex(node) {
+		// the successors of an index are its manifests, while the successors
+		// of a manifest are layers which are not exported
+		for _, manifest := range successors {
+			if err := e.export(ctx, manifest, node, metadata.RelationManifest); err != nil {
+				return err
+			}
+		}
+	}
+	if e.referrerLister != nil {
+		referrers, err := registry.Referrers(ctx, e.referrerLister, node, "")
+		if err != nil {
+			return err
+		}
+		for _, referrer := range referrers {
+			if err := e.export(ctx, referrer, node, metadata.RelationReferrer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// export writes desc related to parent and walks it if it is a manifest.
+func (e *manifestExporter) export(ctx context.Context, desc ocispec.Descriptor, parent ocispec.Descriptor, relation string) error {
+	file, err := e.write(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if err := e.handler.OnExported(desc, parent, relation, file); err != nil {
+		return err
+	}
+	if relation == metadata.RelationConfig {
+		return nil
+	}
+	return e.walk(ctx, desc)
+}
@@ -16,10 +16,19 @@ limitations under the License.
 package manifest
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 )
@@ -41,3 +50,141 @@ func Test_fetchManifest_errType(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+type exportRecorder struct {
+	exported []string
+}
+
+func (r *exportRecorder) OnRootExported(_ string, _ ocispec.Descriptor, file string) error {
+	r.exported = append(r.exported, "root "+file)
+	return nil
+}
+
+func (r *exportRecorder) OnExported(_ ocispec.Descriptor, parent ocispec.Descriptor, relation string, file string) error {
+	r.exported = append(r.exported, relation+" "+file+" of "+exportFileName(parent.Digest))
+	return nil
+}
+
+func (r *exportRecorder) Render() error { return nil }
+
+func exportFileName(dgst digest.Digest) string {
+	return dgst.Algorithm().String() + "-" + dgst.Encoded() + ".json"
+}
+
+func Test_manifestExporter(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	push := func(mediaType string, v any) ocispec.Descriptor {
+		b, ok := v.([]byte)
+		if !ok {
+			var err error
+			if b, err = json.Marshal(v); err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+		}
+		desc := content.NewDescriptorFromBytes(mediaType, b)
+		if err := store.Push(ctx, desc, bytes.NewReader(b)); err != nil {
+			t.Fatalf("failed to push: %v", err)
+		}
+		return desc
+	}
+	layer := push("test/layer", []byte("layer"))
+	newManifest := func(artifactType string, config ocispec.Descriptor, subject *ocispec.Descriptor) ocispec.Descriptor {
+		return push(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: artifactType,
+			Config:       config,
+			Layers:       []ocispec.Descriptor{layer},
+			Subject:      subject,
+		})
+	}
+	configAMD64 := push(ocispec.MediaTypeImageConfig, []byte(`{"architecture":"amd64","os":"linux"}`))
+	configARM64 := push(ocispec.MediaTypeImageConfig, []byte(`{"architecture":"arm64","os":"linux"}`))
+	amd64 := newManifest("", configAMD64, nil)
+	arm64 := newManifest("", configARM64, nil)
+	amd64.Platform = &ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	arm64.Platform = &ocispec.Platform{OS: "linux", Architecture: "arm64"}
+	index := push(ocispec.MediaTypeImageIndex, ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{amd64, arm64},
+	})
+	empty := push(ocispec.MediaTypeEmptyJSON, ocispec.DescriptorEmptyJSON.Data)
+	sig := newManifest("test/sig", empty, &amd64)
+
+	tests := []struct {
+		name      string
+		referrers bool
+		want      []string
+	}{
+		{
+			name: "manifests and configs",
+			want: []string{
+				"root " + exportFileName(index.Digest),
+				"manifest " + exportFileName(amd64.Digest) + " of " + exportFileName(index.Digest),
+				"config " + exportFileName(configAMD64.Digest) + " of " + exportFileName(amd64.Digest),
+				"manifest " + exportFileName(arm64.Digest) + " of " + exportFileName(index.Digest),
+				"config " + exportFileName(configARM64.Digest) + " of " + exportFileName(arm64.Digest),
+			},
+		},
+		{
+			name:      "with referrers",
+			referrers: true,
+			want: []string{
+				"root " + exportFileName(index.Digest),
+				"manifest " + exportFileName(amd64.Digest) + " of " + exportFileName(index.Digest),
+				"config " + exportFileName(configAMD64.Digest) + " of " + exportFileName(amd64.Digest),
+				"referrer " + exportFileName(sig.Digest) + " of " + exportFileName(amd64.Digest),
+				"config " + exportFileName(empty.Digest) + " of " + exportFileName(sig.Digest),
+				"manifest " + exportFileName(arm64.Digest) + " of " + exportFileName(index.Digest),
+				"config " + exportFileName(configARM64.Digest) + " of " + exportFileName(arm64.Digest),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &exportRecorder{}
+			e := &manifestExporter{
+				fetcher:   store,
+				outputDir: t.TempDir(),
+				handler:   recorder,
+				fetched:   make(map[digest.Digest][]byte),
+				walked:    make(map[digest.Digest]bool),
+			}
+			if tt.referrers {
+				e.referrerLister = store
+			}
+			file, err := e.write(ctx, index)
+			if err != nil {
+				t.Fatalf("manifestExporter.write() error = %v", err)
+			}
+			if err := recorder.OnRootExported("", index, file); err != nil {
+				t.Fatal(err)
+			}
+			if err := e.walk(ctx, index); err != nil {
+				t.Fatalf("manifestExporter.walk() error = %v", err)
+			}
+			if !reflect.DeepEqual(recorder.exported, tt.want) {
+				t.Errorf("exported = %v, want %v", recorder.exported, tt.want)
+			}
+
+			// check the written files
+			entries, err := os.ReadDir(e.outputDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != len(tt.want) {
+				t.Errorf("written %d files, want %d", len(entries), len(tt.want))
+			}
+			got, err := os.ReadFile(filepath.Join(e.outputDir, exportFileName(configAMD64.Digest)))
+			if err != nil {
+				t.Fatalf("failed to read the written config: %v", err)
+			}
+			if want := `{"architecture":"amd64","os":"linux"}`; string(got) != want {
+				t.Errorf("written config = %s, want %s", got, want)
+			}
+			if _, err := os.Stat(filepath.Join(e.outputDir, exportFileName(layer.Digest))); !os.IsNotExist(err) {
+				t.Errorf("layer is written, error = %v", err)
+			}
+		})
+	}
+}
@@ -20,7 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/opencontainers/image-spec/specs-go"
@@ -35,19 +35,17 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/status"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/contentutil"
 	"oras.land/oras/internal/descriptor"
 	"oras.land/oras/internal/listener"
+	"oras.land/oras/internal/platform"
+	"oras.land/oras/internal/validate"
 )
 
 var maxConfigSize int64 = 4 * 1024 * 1024 // 4 MiB
 
-// mediaTypeRegexp is the regular expression pattern required for a valid
-// media type, as defined in the image spec schema:
-// - https://github.com/opencontainers/image-spec/blob/v1.1.1/schema/defs-descriptor.json#L7
-var mediaTypeRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}/[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}$`)
-
 type createOptions struct {
 	option.Common
 	option.Target
@@ -58,6 +56,11 @@ type createOptions struct {
 	sources      []string
 	extraRefs    []string
 	outputPath   string
+	validate     bool
+
+	platformFromConfig      bool
+	sourcePlatformArguments []string
+	sourcePlatforms         map[string]*ocispec.Platform
 }
 
 func createCmd() *cobra.Command {
@@ -67,6 +70,9 @@ func createCmd() *cobra.Command {
 		Short: "[Experimental] Create and push an index from provided manifests",
 		Long: `[Experimental] Create and push an index from provided manifests. All manifests should be in the same repository
 
+The platforms of image manifests are read from their configs, unless overridden with --source-platform. If two
+sources are of the same platform, the later source replaces the earlier one in the index.
+
 Example - Create an index from source manifests tagged 'linux-amd64' and 'linux-arm64', and push without tagging:
   oras manifest index create localhost:5000/hello linux-amd64 linux-arm64
 
@@ -88,6 +94,18 @@ Example - Create an index with a specified artifact type:
 Example - Create an index and push to an OCI image layout folder 'layout-dir' and tag with 'v1':
   oras manifest index create layout-dir:v1 linux-amd64 sha256:99e4703fbf30916f549cd6bfa9cdbab614b5392fbe64fdee971359a77073cdf9 --oci-layout
 
+Example - Create an index from source manifests without reading their platforms from the configs:
+  oras manifest index create --platform-from-config=false localhost:5000/hello:v1 linux-amd64 linux-arm64
+
+Example - Create an index with the platform of the source manifest tagged 'arm-build' overridden as linux/arm/v7:
+  oras manifest index create --source-platform arm-build=linux/arm/v7 localhost:5000/hello:v1 linux-amd64 arm-build
+
+Example - Create an index where a later source replaces an earlier source of the same platform:
+  oras manifest index create localhost:5000/hello:v1 linux-amd64 linux-arm64 linux-arm64-patched
+
+Example - Create an index and validate it against the image spec before pushing:
+  oras manifest index create --validate localhost:5000/hello:v1 linux-amd64 linux-arm64
+
 Example - Create an index and save it locally to index.json, auto push will be disabled:
   oras manifest index create localhost:5000/hello linux-amd64 linux-arm64 --output index.json
 
@@ -100,6 +118,9 @@ Example - Create an index and output the index to stdout, auto push will be disa
 			opts.RawReference = refs[0]
 			opts.extraRefs = refs[1:]
 			opts.sources = args[1:]
+			if err := opts.parseSourcePlatforms(); err != nil {
+				return err
+			}
 			return option.Parse(cmd, &opts)
 		},
 		Aliases: []string{"pack"},
@@ -109,6 +130,9 @@ Example - Create an index and output the index to stdout, auto push will be disa
 	}
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "artifact type for overall index")
 	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "file `path` to write the created index to, use - for stdout")
+	cmd.Flags().BoolVarP(&opts.validate, "validate", "", false, "validate the index before pushing or writing it, see 'oras manifest validate'")
+	cmd.Flags().BoolVarP(&opts.platformFromConfig, "platform-from-config", "", true, "read the platforms of image manifests from their configs")
+	cmd.Flags().StringArrayVarP(&opts.sourcePlatformArguments, "source-platform", "", nil, "override the platform of a source manifest in the index, in the form of `<source>=<platform>` where the platform is os/arch[/variant][:os_version]")
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
@@ -125,7 +149,7 @@ func createIndex(cmd *cobra.Command, opts createOptions) error {
 		}
 	}
 	displayStatus, displayMetadata, displayContent := display.NewManifestIndexCreateHandler(opts.outputPath, opts.Printer, opts.Pretty.Pretty)
-	manifests, err := fetchSourceManifests(ctx, displayStatus, target, opts.sources)
+	manifests, err := fetchSourceManifests(ctx, displayStatus, target, opts.sources, opts.platformFromConfig, opts.sourcePlatforms)
 	if err != nil {
 		return err
 	}
@@ -143,6 +167,11 @@ func createIndex(cmd *cobra.Command, opts createOptions) error {
 		return err
 	}
 	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, indexBytes)
+	if opts.validate {
+		if err := manifest.Validate(ctx, target, desc.MediaType, indexBytes); err != nil {
+			return err
+		}
+	}
 	if err := displayStatus.OnIndexPacked(desc); err != nil {
 		return err
 	}
@@ -158,7 +187,36 @@ func createIndex(cmd *cobra.Command, opts createOptions) error {
 	return displayMetadata.Render()
 }
 
-func fetchSourceManifests(ctx context.Context, displayStatus status.ManifestIndexCreateHandler, target oras.ReadOnlyTarget, sources []string) ([]ocispec.Descriptor, error) {
+// parseSourcePlatforms parses the platforms overriding the platforms of the
+// sources.
+func (opts *createOptions) parseSourcePlatforms() error {
+	if len(opts.sourcePlatformArguments) == 0 {
+		return nil
+	}
+	opts.sourcePlatforms = make(map[string]*ocispec.Platform, len(opts.sourcePlatformArguments))
+	for _, arg := range opts.sourcePlatformArguments {
+		source, platformArg, ok := strings.Cut(arg, "=")
+		if !ok || source == "" || platformArg == "" {
+			return fmt.Errorf("source-platform: %q is not in the form of <source>=<platform>", arg)
+		}
+		if !slices.Contains(opts.sources, source) {
+			return fmt.Errorf("source-platform: %s is not a source of the index", source)
+		}
+		p, err := option.ParsePlatformSelector(platformArg)
+		if err != nil {
+			return fmt.Errorf("source-platform: %w", err)
+		}
+		if p.Architecture == "" {
+			return fmt.Errorf("source-platform: the architecture of %q must be specified", platformArg)
+		}
+		opts.sourcePlatforms[source] = p
+	}
+	return nil
+}
+
+// fetchSourceManifests fetches the manifests of the sources. The platforms of
+// the sources in sourcePlatforms are overridden.
+func fetchSourceManifests(ctx context.Context, displayStatus status.ManifestIndexCreateHandler, target oras.ReadOnlyTarget, sources []string, platformFromConfig bool, sourcePlatforms map[string]*ocispec.Platform) ([]ocispec.Descriptor, error) {
 	resolved := []ocispec.Descriptor{}
 	for _, source := range sources {
 		if err := displayStatus.OnFetching(source); err != nil {
@@ -174,14 +232,41 @@ func fetchSourceManifests(ctx context.Context, displayStatus status.ManifestInde
 		if err := displayStatus.OnFetched(source, desc); err != nil {
 			return nil, err
 		}
-		if desc, err = enrichDescriptor(ctx, target, desc, content); err != nil {
+		_, overridden := sourcePlatforms[source]
+		if desc, err = enrichDescriptor(ctx, target, desc, content, platformFromConfig && !overridden); err != nil {
+			return nil, err
+		}
+		if overridden {
+			desc.Platform = sourcePlatforms[source]
+		}
+		if resolved, err = appendManifest(displayStatus, resolved, source, desc); err != nil {
 			return nil, err
 		}
-		resolved = append(resolved, desc)
 	}
 	return resolved, nil
 }
 
+// appendManifest appends desc resolved from ref to manifests. If a manifest
+// of the same platform is already in manifests, it is replaced with desc in
+// place.
+func appendManifest(displayStatus status.ManifestIndexCreateHandler, manifests []ocispec.Descriptor, ref string, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	if desc.Platform == nil {
+		return append(manifests, desc), nil
+	}
+	key := platform.String(desc.Platform)
+	for i, m := range manifests {
+		if m.Platform == nil || platform.String(m.Platform) != key {
+			continue
+		}
+		manifests[i] = desc
+		if m.Digest == desc.Digest {
+			return manifests, nil
+		}
+		return manifests, displayStatus.OnManifestReplaced(ref, m, desc)
+	}
+	return append(manifests, desc), nil
+}
+
 func getPlatform(ctx context.Context, target oras.ReadOnlyTarget, manifest *ocispec.Manifest) (*ocispec.Platform, error) {
 	// if config size is larger than 4 MiB, discontinue the fetch
 	if manifest.Config.Size > maxConfigSize {
@@ -228,7 +313,7 @@ func pushIndex(ctx context.Context, displayStatus status.ManifestIndexCreateHand
 	return nil
 }
 
-func enrichDescriptor(ctx context.Context, target oras.ReadOnlyTarget, desc ocispec.Descriptor, manifestBytes []byte) (ocispec.Descriptor, error) {
+func enrichDescriptor(ctx context.Context, target oras.ReadOnlyTarget, desc ocispec.Descriptor, manifestBytes []byte, platformFromConfig bool) (ocispec.Descriptor, error) {
 	desc = descriptor.Plain(desc)
 	if descriptor.IsImageManifest(desc) {
 		var err error
@@ -236,9 +321,11 @@ func enrichDescriptor(ctx context.Context, target oras.ReadOnlyTarget, desc ocis
 		if err = json.Unmarshal(manifestBytes, &manifest); err != nil {
 			return ocispec.Descriptor{}, err
 		}
-		desc.Platform, err = getPlatform(ctx, target, &manifest)
-		if err != nil {
-			return ocispec.Descriptor{}, err
+		if platformFromConfig {
+			desc.Platform, err = getPlatform(ctx, target, &manifest)
+			if err != nil {
+				return ocispec.Descriptor{}, err
+			}
 		}
 		desc.ArtifactType = manifest.ArtifactType
 	} else if descriptor.IsIndex(desc) {
@@ -254,7 +341,7 @@ func enrichDescriptor(ctx context.Context, target oras.ReadOnlyTarget, desc ocis
 // validateMediaType checks whether mediaType uses valid media type syntax,
 // returning a non-nil error if not.
 func validateMediaType(mediaType string) error {
-	if !mediaTypeRegexp.MatchString(mediaType) {
+	if !validate.MediaTypeRegexp.MatchString(mediaType) {
 		return fmt.Errorf("%s: %w", mediaType, errdef.ErrInvalidMediaType)
 	}
 	return nil
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/opencontainers/go-digest"
@@ -53,10 +54,12 @@ func NewTestReadOnlyTarget(text string) oras.ReadOnlyTarget {
 }
 
 type testCreateDisplayStatus struct {
-	onFetchingError    bool
-	onFetchedError     bool
-	onIndexPackedError bool
-	onIndexPushedError bool
+	onFetchingError         bool
+	onFetchedError          bool
+	onManifestReplacedError bool
+	onIndexPackedError      bool
+	onIndexPushedError      bool
+	replaced                []digest.Digest
 }
 
 func (tds *testCreateDisplayStatus) OnFetching(_ string) error {
@@ -73,6 +76,14 @@ func (tds *testCreateDisplayStatus) OnFetched(_ string, _ ocispec.Descriptor) er
 	return nil
 }
 
+func (tds *testCreateDisplayStatus) OnManifestReplaced(_ string, old ocispec.Descriptor, _ ocispec.Descriptor) error {
+	if tds.onManifestReplacedError {
+		return fmt.Errorf("OnManifestReplaced error")
+	}
+	tds.replaced = append(tds.replaced, old.Digest)
+	return nil
+}
+
 func (tds *testCreateDisplayStatus) OnIndexPacked(_ ocispec.Descriptor) error {
 	if tds.onIndexPackedError {
 		return fmt.Errorf("error")
@@ -89,15 +100,33 @@ func (tds *testCreateDisplayStatus) OnIndexPushed(_ string) error {
 
 func Test_fetchSourceManifests(t *testing.T) {
 	testContext := context.Background()
+	// the config is not fetched for the manifest with the platform overridden
+	overriddenManifest := `{"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:1111111111111111111111111111111111111111111111111111111111111111","size":1}}`
+	armV7 := &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}
 	tests := []struct {
-		name          string
-		ctx           context.Context
-		displayStatus status.ManifestIndexCreateHandler
-		target        oras.ReadOnlyTarget
-		sources       []string
-		want          []ocispec.Descriptor
-		wantErr       bool
+		name            string
+		ctx             context.Context
+		displayStatus   status.ManifestIndexCreateHandler
+		target          oras.ReadOnlyTarget
+		sources         []string
+		sourcePlatforms map[string]*ocispec.Platform
+		want            []ocispec.Descriptor
+		wantErr         bool
 	}{
+		{
+			name:            "platform overridden",
+			ctx:             testContext,
+			displayStatus:   &testCreateDisplayStatus{},
+			target:          NewTestReadOnlyTarget(overriddenManifest),
+			sources:         []string{"test"},
+			sourcePlatforms: map[string]*ocispec.Platform{"test": armV7},
+			want: []ocispec.Descriptor{{
+				MediaType: ocispec.MediaTypeImageManifest,
+				Digest:    digest.FromString(overriddenManifest),
+				Size:      int64(len(overriddenManifest)),
+				Platform:  armV7,
+			}},
+		},
 		{
 			name:          "OnFetching error",
 			ctx:           testContext,
@@ -128,7 +157,7 @@ func Test_fetchSourceManifests(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := fetchSourceManifests(tt.ctx, tt.displayStatus, tt.target, tt.sources)
+			got, err := fetchSourceManifests(tt.ctx, tt.displayStatus, tt.target, tt.sources, true, tt.sourcePlatforms)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("fetchSourceManifests() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -140,6 +169,104 @@ func Test_fetchSourceManifests(t *testing.T) {
 	}
 }
 
+func Test_createOptions_parseSourcePlatforms(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    map[string]*ocispec.Platform
+		wantErr bool
+	}{
+		{name: "no override"},
+		{
+			name: "override",
+			args: []string{"arm-build=linux/arm/v7"},
+			want: map[string]*ocispec.Platform{"arm-build": {OS: "linux", Architecture: "arm", Variant: "v7"}},
+		},
+		{name: "invalid form", args: []string{"arm-build"}, wantErr: true},
+		{name: "unknown source", args: []string{"other=linux/arm64"}, wantErr: true},
+		{name: "no architecture", args: []string{"arm-build=linux"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := createOptions{
+				sources:                 []string{"linux-amd64", "arm-build"},
+				sourcePlatformArguments: tt.args,
+			}
+			err := opts.parseSourcePlatforms()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSourcePlatforms() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(opts.sourcePlatforms, tt.want) {
+				t.Errorf("parseSourcePlatforms() = %v, want %v", opts.sourcePlatforms, tt.want)
+			}
+		})
+	}
+}
+
+func Test_appendManifest(t *testing.T) {
+	withPlatform := func(desc ocispec.Descriptor, p string) ocispec.Descriptor {
+		os, arch, _ := strings.Cut(p, "/")
+		desc.Platform = &ocispec.Platform{OS: os, Architecture: arch}
+		return desc
+	}
+	amd64A := withPlatform(A, "linux/amd64")
+	arm64B := withPlatform(B, "linux/arm64")
+	arm64C := withPlatform(C, "linux/arm64")
+	tests := []struct {
+		name         string
+		manifests    []ocispec.Descriptor
+		desc         ocispec.Descriptor
+		want         []ocispec.Descriptor
+		wantReplaced []digest.Digest
+	}{
+		{
+			name:      "new platform",
+			manifests: []ocispec.Descriptor{amd64A},
+			desc:      arm64B,
+			want:      []ocispec.Descriptor{amd64A, arm64B},
+		},
+		{
+			name:         "same platform",
+			manifests:    []ocispec.Descriptor{arm64B, amd64A},
+			desc:         arm64C,
+			want:         []ocispec.Descriptor{arm64C, amd64A},
+			wantReplaced: []digest.Digest{B.Digest},
+		},
+		{
+			name:      "same manifest",
+			manifests: []ocispec.Descriptor{arm64B, amd64A},
+			desc:      arm64B,
+			want:      []ocispec.Descriptor{arm64B, amd64A},
+		},
+		{
+			name:      "no platform",
+			manifests: []ocispec.Descriptor{A},
+			desc:      A,
+			want:      []ocispec.Descriptor{A, A},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			displayStatus := &testCreateDisplayStatus{}
+			got, err := appendManifest(displayStatus, tt.manifests, "test", tt.desc)
+			if err != nil {
+				t.Fatalf("appendManifest() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("appendManifest() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(displayStatus.replaced, tt.wantReplaced) {
+				t.Errorf("appendManifest() replaced %v, want %v", displayStatus.replaced, tt.wantReplaced)
+			}
+		})
+	}
+
+	displayStatus := &testCreateDisplayStatus{onManifestReplacedError: true}
+	if _, err := appendManifest(displayStatus, []ocispec.Descriptor{arm64B}, "test", arm64C); err == nil {
+		t.Error("appendManifest() error = nil, want handler error")
+	}
+}
+
 func Test_enrichDescriptor(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -273,7 +400,7 @@ func Test_enrichDescriptor(t *testing.T) {
 				Digest:    digest.FromBytes(tt.manifestBytes),
 				Size:      int64(len(tt.manifestBytes)),
 			}
-			gotDesc, err := enrichDescriptor(t.Context(), tt.target, inputDesc, tt.manifestBytes)
+			gotDesc, err := enrichDescriptor(t.Context(), tt.target, inputDesc, tt.manifestBytes, true)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("enrichDescriptor() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -19,6 +19,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -31,9 +33,11 @@ import (
 	"oras.land/oras/cmd/oras/internal/display"
 	"oras.land/oras/cmd/oras/internal/display/status"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/contentutil"
 	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/platform"
 )
 
 type updateOptions struct {
@@ -47,12 +51,25 @@ type updateOptions struct {
 	removeArguments []string
 	tags            []string
 	outputPath      string
+	validate        bool
+
+	removePlatformArguments  []string
+	replacePlatformArguments []string
+	removePlatforms          []*ocispec.Platform
+	replacements             []platformReplacement
+}
+
+// platformReplacement replaces the manifest of platform in an index with the
+// manifest referenced by ref.
+type platformReplacement struct {
+	platform *ocispec.Platform
+	ref      string
 }
 
 func updateCmd() *cobra.Command {
 	var opts updateOptions
 	cmd := &cobra.Command{
-		Use:   "update <index>{:<tag>|@<digest>} [{--add|--merge|--remove} <manifest>{<tag>|<digest>}] [...]",
+		Use:   "update <index>{:<tag>|@<digest>} [{--add|--merge|--remove} <manifest>{<tag>|<digest>}] [{--remove-platform|--replace-platform} <platform>[=<manifest>]] [...]",
 		Short: "[Experimental] Update and push an image index",
 		Long: `[Experimental] Update and push an image index. All manifests should be in the same repository
 
@@ -62,6 +79,15 @@ Example - Remove a manifest and add two manifests from an index tagged 'v1'. The
 Example - Create a new index by updating an existing index specified by its digest:
   oras manifest index update localhost:5000/hello@sha256:99e4703fbf30916f549cd6bfa9cdbab614b5392fbe64fdee971359a77073cdf9 --add linux-amd64 --remove sha256:fd6ed2f36b5465244d5dc86cb4e7df0ab8a9d24adc57825099f522fe009a22bb
 
+Example - Remove the linux/s390x manifest from the index tagged 'v1':
+  oras manifest index update localhost:5000/hello:v1 --remove-platform linux/s390x
+
+Example - Remove the windows manifests of all architectures from the index tagged 'v1':
+  oras manifest index update localhost:5000/hello:v1 --remove-platform windows
+
+Example - Replace the linux/arm64 manifest in the index tagged 'v1' with the manifest tagged 'linux-arm64-patched':
+  oras manifest index update localhost:5000/hello:v1 --replace-platform linux/arm64=linux-arm64-patched
+
 Example - Merge manifests from the index 'v2-windows' to the index 'v2':
   oras manifest index update localhost:5000/hello:v2 --merge v2-windows
 
@@ -71,6 +97,9 @@ Example - Update an index and tag the updated index as 'v2.1.0' and 'v2':
 Example - Update an index and push to an OCI image layout folder 'layout-dir' and tag with 'v2':
   oras manifest index update layout-dir@99e4703fbf30916f549cd6bfa9cdbab614b5392fbe64fdee971359a77073cdf9 --add linux-arm64 --tag "v2" --oci-layout
 
+Example - Update an index and validate it against the image spec before pushing:
+  oras manifest index update --validate localhost:5000/hello:v1 --add linux-arm64
+
 Example - Update an index and save it locally to index.json, auto push will be disabled:
   oras manifest index update localhost:5000/hello:v2 --add v2-linux-amd64 --output index.json
 
@@ -94,6 +123,27 @@ Example - Update an index to remove any existing artifact type:
 					return fmt.Errorf("remove: %s is not a digest", manifestRef)
 				}
 			}
+			for _, platformArg := range opts.removePlatformArguments {
+				p, err := option.ParsePlatformSelector(platformArg)
+				if err != nil {
+					return fmt.Errorf("remove-platform: %w", err)
+				}
+				opts.removePlatforms = append(opts.removePlatforms, p)
+			}
+			for _, replaceArg := range opts.replacePlatformArguments {
+				platformArg, ref, ok := strings.Cut(replaceArg, "=")
+				if !ok || ref == "" {
+					return fmt.Errorf("replace-platform: %q is not in the form of <platform>=<manifest>", replaceArg)
+				}
+				p, err := option.ParsePlatformSelector(platformArg)
+				if err != nil {
+					return fmt.Errorf("replace-platform: %w", err)
+				}
+				if p.Architecture == "" {
+					return fmt.Errorf("replace-platform: the architecture of %q must be specified to replace a single manifest", platformArg)
+				}
+				opts.replacements = append(opts.replacements, platformReplacement{platform: p, ref: ref})
+			}
 			return option.Parse(cmd, &opts)
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -105,8 +155,11 @@ Example - Update an index to remove any existing artifact type:
 	cmd.Flags().StringArrayVarP(&opts.addArguments, "add", "", nil, "manifests to add to the index")
 	cmd.Flags().StringArrayVarP(&opts.mergeArguments, "merge", "", nil, "indexes to be merged into the index")
 	cmd.Flags().StringArrayVarP(&opts.removeArguments, "remove", "", nil, "manifests to remove from the index, must be digests")
+	cmd.Flags().StringArrayVarP(&opts.removePlatformArguments, "remove-platform", "", nil, "platforms of the manifests to remove from the index, in the form of `os[/arch][/variant][:os_version]`, manifests of all architectures are removed if arch is omitted")
+	cmd.Flags().StringArrayVarP(&opts.replacePlatformArguments, "replace-platform", "", nil, "replace the only manifest of a platform in the index, in the form of `<platform>=<manifest>` where the platform is os/arch[/variant][:os_version]")
 	cmd.Flags().StringArrayVarP(&opts.tags, "tag", "", nil, "extra tags for the updated index")
 	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "file `path` to write the created index to, use - for stdout")
+	cmd.Flags().BoolVarP(&opts.validate, "validate", "", false, "validate the index before pushing or writing it, see 'oras manifest validate'")
 	return oerrors.Command(cmd, &opts.Target)
 }
 
@@ -141,6 +194,14 @@ func updateIndex(cmd *cobra.Command, opts updateOptions) error {
 	if err != nil {
 		return err
 	}
+	manifests, err = removePlatforms(displayStatus, manifests, opts.removePlatforms, opts.Reference)
+	if err != nil {
+		return err
+	}
+	manifests, err = replacePlatforms(ctx, displayStatus, manifests, target, opts.replacements, opts.Reference)
+	if err != nil {
+		return err
+	}
 	manifests, err = addManifests(ctx, displayStatus, manifests, target, opts.addArguments)
 	if err != nil {
 		return err
@@ -155,6 +216,11 @@ func updateIndex(cmd *cobra.Command, opts updateOptions) error {
 		return err
 	}
 	desc := content.NewDescriptorFromBytes(index.MediaType, indexBytes)
+	if opts.validate {
+		if err := manifest.Validate(ctx, target, desc.MediaType, indexBytes); err != nil {
+			return err
+		}
+	}
 	if err := displayStatus.OnIndexPacked(desc); err != nil {
 		return err
 	}
@@ -207,7 +273,7 @@ func addManifests(ctx context.Context, displayStatus status.ManifestIndexUpdateH
 		if err := displayStatus.OnFetched(manifestRef, desc); err != nil {
 			return nil, err
 		}
-		if desc, err = enrichDescriptor(ctx, target, desc, content); err != nil {
+		if desc, err = enrichDescriptor(ctx, target, desc, content, true); err != nil {
 			return nil, err
 		}
 		manifests = append(manifests, desc)
@@ -274,8 +340,95 @@ func doRemoveManifests(originalManifests []ocispec.Descriptor, digestToRemove ma
 	return manifests, nil
 }
 
+// removePlatforms removes the manifests matching any of platforms from
+// manifests. An error is returned if no manifest matches a platform.
+func removePlatforms(handler status.ManifestIndexUpdateHandler, manifests []ocispec.Descriptor, platforms []*ocispec.Platform, indexRef string) ([]ocispec.Descriptor, error) {
+	for _, p := range platforms {
+		kept := []ocispec.Descriptor{}
+		var removed []ocispec.Descriptor
+		for _, m := range manifests {
+			if platform.Match(m.Platform, p) {
+				removed = append(removed, m)
+			} else {
+				kept = append(kept, m)
+			}
+		}
+		if len(removed) == 0 {
+			return nil, fmt.Errorf("no manifest of platform %s exists in the index %s", platform.String(p), indexRef)
+		}
+		for _, m := range removed {
+			if err := handler.OnManifestRemoved(m.Digest); err != nil {
+				return nil, err
+			}
+		}
+		manifests = kept
+	}
+	return manifests, nil
+}
+
+// replacePlatforms replaces the manifest matching the platform of each
+// replacement with the manifest referenced by the replacement. An error is
+// returned if no manifest or more than one manifest matches the platform, or
+// if the platform of the new manifest does not match.
+func replacePlatforms(ctx context.Context, handler status.ManifestIndexUpdateHandler, manifests []ocispec.Descriptor, target oras.ReadOnlyTarget, replacements []platformReplacement, indexRef string) ([]ocispec.Descriptor, error) {
+	for _, r := range replacements {
+		var matched []int
+		for i, m := range manifests {
+			if platform.Match(m.Platform, r.platform) {
+				matched = append(matched, i)
+			}
+		}
+		switch len(matched) {
+		case 0:
+			return nil, fmt.Errorf("no manifest of platform %s exists in the index %s", platform.String(r.platform), indexRef)
+		case 1:
+		default:
+			platforms := make([]string, len(matched))
+			for j, i := range matched {
+				platforms[j] = platform.String(manifests[i].Platform)
+			}
+			return nil, &oerrors.Error{
+				Err:            fmt.Errorf("the platform %s matches %d manifests in the index %s: %s", platform.String(r.platform), len(matched), indexRef, strings.Join(platforms, ", ")),
+				Recommendation: "Specify the variant of the platform to replace a single manifest, or remove the other manifests with --remove.",
+			}
+		}
+		i := matched[0]
+
+		if err := handler.OnFetching(r.ref); err != nil {
+			return nil, err
+		}
+		desc, content, err := oras.FetchBytes(ctx, target, r.ref, oras.DefaultFetchBytesOptions)
+		if err != nil {
+			return nil, fmt.Errorf("could not find the manifest %s: %w", r.ref, err)
+		}
+		if !descriptor.IsManifest(desc) {
+			return nil, fmt.Errorf("%s is not a manifest", r.ref)
+		}
+		if err := handler.OnFetched(r.ref, desc); err != nil {
+			return nil, err
+		}
+		if desc, err = enrichDescriptor(ctx, target, desc, content, true); err != nil {
+			return nil, err
+		}
+		if desc.Platform == nil {
+			desc.Platform = r.platform
+		} else if !platform.Match(desc.Platform, r.platform) {
+			return nil, fmt.Errorf("the platform %s of %s does not match the platform %s to replace", platform.String(desc.Platform), r.ref, platform.String(r.platform))
+		}
+
+		old := manifests[i]
+		manifests = slices.Clone(manifests)
+		manifests[i] = desc
+		if err := handler.OnManifestReplaced(r.ref, old, desc); err != nil {
+			return nil, err
+		}
+	}
+	return manifests, nil
+}
+
 func updateFlagsUsed(flags *pflag.FlagSet) bool {
-	return flags.Changed("add") || flags.Changed("remove") || flags.Changed("merge") || flags.Changed("artifact-type")
+	return flags.Changed("add") || flags.Changed("remove") || flags.Changed("merge") || flags.Changed("artifact-type") ||
+		flags.Changed("remove-platform") || flags.Changed("replace-platform")
 }
 
 func getPushPath(rawReference string, targetType string, reference string, path string) string {
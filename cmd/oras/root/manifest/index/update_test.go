@@ -16,14 +16,22 @@ limitations under the License.
 package index
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras/cmd/oras/internal/display/status"
 )
 
@@ -37,6 +45,10 @@ type testUpdateDisplayStatus struct {
 	onIndexMergedError     bool
 }
 
+func (tds *testUpdateDisplayStatus) OnManifestReplaced(_ string, _ ocispec.Descriptor, _ ocispec.Descriptor) error {
+	return nil
+}
+
 func (tds *testUpdateDisplayStatus) OnFetching(_ string) error {
 	if tds.onFetchingError {
 		return fmt.Errorf("OnFetching error")
@@ -301,3 +313,159 @@ func Test_mergeIndexes(t *testing.T) {
 		})
 	}
 }
+
+func Test_removePlatforms(t *testing.T) {
+	amd64 := &ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	s390x := &ocispec.Platform{OS: "linux", Architecture: "s390x"}
+	amd64A := A
+	amd64A.Platform = amd64
+	s390xB := B
+	s390xB.Platform = s390x
+	tests := []struct {
+		name          string
+		manifests     []ocispec.Descriptor
+		platforms     []*ocispec.Platform
+		displayStatus status.ManifestIndexUpdateHandler
+		want          []ocispec.Descriptor
+		wantErr       bool
+	}{
+		{
+			name:          "remove one platform",
+			manifests:     []ocispec.Descriptor{amd64A, s390xB, C},
+			platforms:     []*ocispec.Platform{s390x},
+			displayStatus: &testUpdateDisplayStatus{},
+			want:          []ocispec.Descriptor{amd64A, C},
+		},
+		{
+			name:          "remove all platforms",
+			manifests:     []ocispec.Descriptor{amd64A, s390xB},
+			platforms:     []*ocispec.Platform{s390x, amd64},
+			displayStatus: &testUpdateDisplayStatus{},
+			want:          []ocispec.Descriptor{},
+		},
+		{
+			name:          "remove all architectures of an OS",
+			manifests:     []ocispec.Descriptor{amd64A, s390xB, C},
+			platforms:     []*ocispec.Platform{{OS: "linux"}},
+			displayStatus: &testUpdateDisplayStatus{},
+			want:          []ocispec.Descriptor{C},
+		},
+		{
+			name:          "platform not found",
+			manifests:     []ocispec.Descriptor{amd64A, C},
+			platforms:     []*ocispec.Platform{s390x},
+			displayStatus: &testUpdateDisplayStatus{},
+			wantErr:       true,
+		},
+		{
+			name:          "handler error",
+			manifests:     []ocispec.Descriptor{amd64A, s390xB},
+			platforms:     []*ocispec.Platform{s390x},
+			displayStatus: &testUpdateDisplayStatus{onManifestRemovedError: true},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := removePlatforms(tt.displayStatus, tt.manifests, tt.platforms, "test")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("removePlatforms() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("removePlatforms() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replacePlatforms(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	pushManifest := func(p *ocispec.Platform, tag string) ocispec.Descriptor {
+		t.Helper()
+		configBytes := []byte("{}")
+		if p != nil {
+			configBytes, _ = json.Marshal(p)
+		}
+		config := content.NewDescriptorFromBytes(ocispec.MediaTypeImageConfig, configBytes)
+		if err := store.Push(ctx, config, bytes.NewReader(configBytes)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+			t.Fatal(err)
+		}
+		manifestBytes, _ := json.Marshal(ocispec.Manifest{
+			Versioned:   specs.Versioned{SchemaVersion: 2},
+			MediaType:   ocispec.MediaTypeImageManifest,
+			Config:      config,
+			Layers:      []ocispec.Descriptor{},
+			Annotations: map[string]string{"tag": tag},
+		})
+		desc, err := oras.TagBytes(ctx, store, ocispec.MediaTypeImageManifest, manifestBytes, tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc.Platform = p
+		return desc
+	}
+	amd64 := &ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	arm64 := &ocispec.Platform{OS: "linux", Architecture: "arm64"}
+	arm64V8 := &ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}
+	amd64Old := pushManifest(amd64, "amd64-old")
+	arm64Old := pushManifest(arm64V8, "arm64-old")
+	arm64Extra := pushManifest(arm64, "arm64-extra")
+	arm64V8New := pushManifest(arm64V8, "arm64-v8-new")
+	pushManifest(arm64, "arm64-new")
+	noPlatform := pushManifest(nil, "no-platform")
+	manifests := []ocispec.Descriptor{arm64Old, amd64Old, arm64Extra}
+
+	tests := []struct {
+		name         string
+		replacements []platformReplacement
+		want         []ocispec.Descriptor
+		wantErr      bool
+	}{
+		{
+			name:         "replace the variant",
+			replacements: []platformReplacement{{platform: arm64V8, ref: "arm64-v8-new"}},
+			want:         []ocispec.Descriptor{arm64V8New, amd64Old, arm64Extra},
+		},
+		{
+			name:         "platform matching multiple manifests",
+			replacements: []platformReplacement{{platform: arm64, ref: "arm64-new"}},
+			wantErr:      true,
+		},
+		{
+			name:         "set the platform of the new manifest",
+			replacements: []platformReplacement{{platform: amd64, ref: "no-platform"}},
+			want: []ocispec.Descriptor{arm64Old, func() ocispec.Descriptor {
+				desc := noPlatform
+				desc.Platform = amd64
+				return desc
+			}(), arm64Extra},
+		},
+		{
+			name:         "platform mismatch",
+			replacements: []platformReplacement{{platform: amd64, ref: "arm64-new"}},
+			wantErr:      true,
+		},
+		{
+			name:         "platform not found",
+			replacements: []platformReplacement{{platform: &ocispec.Platform{OS: "linux", Architecture: "s390x"}, ref: "arm64-new"}},
+			wantErr:      true,
+		},
+		{
+			name:         "manifest not found",
+			replacements: []platformReplacement{{platform: arm64, ref: "missing"}},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := replacePlatforms(ctx, &testUpdateDisplayStatus{}, slices.Clone(manifests), store, tt.replacements, "test")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replacePlatforms() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replacePlatforms() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
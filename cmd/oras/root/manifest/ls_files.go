@@ -0,0 +1,148 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/descriptor"
+	orasio "oras.land/oras/internal/io"
+)
+
+type listFilesOptions struct {
+	option.Cache
+	option.Common
+	option.Format
+	option.Platform
+	option.Target
+	option.TarEntryFilter
+}
+
+func listFilesCmd() *cobra.Command {
+	var opts listFilesOptions
+	cmd := &cobra.Command{
+		Use:   "ls-files [flags] <name>{:<tag>|@<digest>}",
+		Short: "[Preview] List entries of the tar layers of a manifest",
+		Long: `[Preview] List entries of the tar layers of a manifest in a registry or an OCI image layout
+
+Each layer is streamed through the gzip, zstd or plain tar decoder without being
+written to disk, and verified against its digest after all entries are listed.
+Layers which are not tar archives are skipped. Layers are read from the cache
+if ORAS_CACHE is set and the layers are cached.
+
+Example - List entries of the layers of a manifest:
+  oras manifest ls-files localhost:5000/hello:v1
+
+Example - Find the layers containing '/usr/bin/foo':
+  oras manifest ls-files --match /usr/bin/foo localhost:5000/hello:v1
+
+Example - List entries of the layers with the name matching any of the patterns:
+  oras manifest ls-files --match '*.so' --match '*.a' localhost:5000/hello:v1
+
+Example - List entries of the layers of the linux/amd64 manifest of an index:
+  oras manifest ls-files --platform linux/amd64 localhost:5000/hello:v1
+
+Example - List entries of the layers of a manifest in JSON format:
+  oras manifest ls-files --format json localhost:5000/hello:v1
+
+Example - List entries of the layers of a manifest in OCI image layout folder 'layout-dir':
+  oras manifest ls-files --oci-layout layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the manifest to list the layer entries of"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return listFiles(cmd, &opts)
+		},
+	}
+
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func listFiles(cmd *cobra.Command, opts *listFilesOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	src, err := opts.CachedTarget(target)
+	if err != nil {
+		return err
+	}
+
+	fetchOpts := oras.DefaultFetchBytesOptions
+	fetchOpts.TargetPlatform = opts.Platform.Platform
+	desc, content, err := oras.FetchBytes(ctx, src, opts.Reference, fetchOpts)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the content of %q: %w", opts.RawReference, err)
+	}
+	if descriptor.IsIndex(desc) {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%s is an index with media type %s", opts.RawReference, desc.MediaType),
+			Recommendation: "Use `--platform` to select a manifest of the index",
+		}
+	}
+	if !descriptor.IsImageManifest(desc) {
+		return fmt.Errorf("unsupported media type %s of %s: only image manifests have layers", desc.MediaType, opts.RawReference)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return fmt.Errorf("failed to parse the manifest of %q: %w", opts.RawReference, err)
+	}
+
+	handler, err := display.NewLayerListHandler(opts.Printer, opts.Format, opts.Path, true)
+	if err != nil {
+		return err
+	}
+	for _, layer := range manifest.Layers {
+		if err := handler.OnLayerListing(layer); err != nil {
+			return err
+		}
+		err := contentutil.WalkLayer(ctx, src, layer, func(hdr *tar.Header) error {
+			if !opts.Match(hdr.Name) {
+				return nil
+			}
+			return handler.OnEntryListed(layer, hdr)
+		})
+		switch {
+		case errors.Is(err, orasio.ErrNotTarArchive):
+			if err := handler.OnLayerSkipped(layer); err != nil {
+				return err
+			}
+		case err != nil:
+			return fmt.Errorf("failed to list layer %s: %w", layer.Digest, err)
+		}
+	}
+	return handler.Render()
+}
@@ -23,14 +23,17 @@ import (
 	"strings"
 
 	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/status"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/manifest"
 	"oras.land/oras/cmd/oras/internal/option"
@@ -48,6 +51,8 @@ type pushOptions struct {
 	extraRefs   []string
 	fileRef     string
 	mediaType   string
+	uploadFrom  string
+	validate    bool
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -59,6 +64,10 @@ func pushCmd() *cobra.Command {
 		Short: "Push a manifest to a registry or an OCI image layout",
 		Long: `Push a manifest to a registry or an OCI image layout
 
+The config, layers and manifests referenced by the manifest must exist in the
+target before the manifest is pushed. Missing content can be uploaded from an
+OCI image layout via the "--upload-from" flag.
+
 Example - Push a manifest to repository 'localhost:5000/hello' and tag with 'v1':
   oras manifest push localhost:5000/hello:v1 manifest.json
 
@@ -78,6 +87,12 @@ Example - Push a manifest to repository 'localhost:5000/hello' and output the pr
 Example - Push a manifest with specified media type to repository 'localhost:5000/hello' and tag with 'v1':
   oras manifest push --media-type application/vnd.cncf.oras.artifact.manifest.v1+json localhost:5000/hello:v1 oras_manifest.json
 
+Example - Validate a manifest against the image spec and the content in repository 'localhost:5000/hello' before pushing it:
+  oras manifest push --validate localhost:5000/hello:v1 manifest.json
+
+Example - Push a manifest and upload its missing config and layers from an OCI image layout folder 'layout-dir':
+  oras manifest push --upload-from layout-dir localhost:5000/hello:v1 manifest.json
+
 Example - Push a manifest to repository 'localhost:5000/hello' and tag with 'tag1', 'tag2', 'tag3':
   oras manifest push localhost:5000/hello:tag1,tag2,tag3 manifest.json
 
@@ -113,6 +128,8 @@ Example - Push a manifest to an OCI image layout folder 'layout-dir' and tag wit
 	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().StringVarP(&opts.mediaType, "media-type", "", "", "media type of manifest")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().StringVarP(&opts.uploadFrom, "upload-from", "", "", "[Experimental] `path` of an OCI image layout to upload the missing referenced content from")
+	cmd.Flags().BoolVarP(&opts.validate, "validate", "", false, "[Experimental] validate the manifest before pushing, see 'oras manifest validate'")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	return oerrors.Command(cmd, &opts.Target)
@@ -126,6 +143,7 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 	if err != nil {
 		return err
 	}
+	storage := target
 	if repo, ok := target.(*remote.Repository); ok {
 		target = repo.Manifests()
 	}
@@ -151,6 +169,11 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 			return err
 		}
 	}
+	if opts.validate {
+		if err := manifest.Validate(ctx, storage, mediaType, contentBytes); err != nil {
+			return err
+		}
+	}
 
 	// prepare manifest descriptor
 	desc := content.NewDescriptorFromBytes(mediaType, contentBytes)
@@ -169,6 +192,9 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 			return err
 		}
 	} else {
+		if err := ensureReferences(ctx, statusHandler, storage, mediaType, contentBytes, opts); err != nil {
+			return err
+		}
 		if err = statusHandler.OnManifestPushing(); err != nil {
 			return err
 		}
@@ -221,3 +247,70 @@ func matchDigest(ctx context.Context, resolver content.Resolver, reference strin
 	}
 	return got.Digest == digest, nil
 }
+
+// ensureReferences checks whether the content referenced by the manifest
+// exists in storage. If the OCI image layout to upload from is specified, the
+// missing content is uploaded from it.
+func ensureReferences(ctx context.Context, handler status.ManifestPushHandler, storage oras.Target, mediaType string, contentBytes []byte, opts pushOptions) error {
+	refs, err := manifest.References(mediaType, contentBytes)
+	if err != nil {
+		return err
+	}
+	missing, err := manifest.FindMissing(ctx, storage, refs, opts.concurrency)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	recommendation := `Push the missing content to the target first, or upload it from an OCI image layout via the "--upload-from" flag`
+	if opts.uploadFrom != "" {
+		if missing, err = uploadMissing(ctx, handler, storage, missing, opts.uploadFrom, opts.concurrency); err != nil {
+			return err
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		recommendation = fmt.Sprintf("Push the missing content to the target, or add it to the OCI image layout %q first", opts.uploadFrom)
+	}
+	return &oerrors.Error{
+		Err:            manifest.MissingContentError(missing),
+		Recommendation: recommendation,
+	}
+}
+
+// uploadMissing uploads the missing content, along with its successors, from
+// the OCI image layout at layoutPath to dst. If any of the missing content is
+// not found in the layout, nothing is uploaded and the content not found is
+// returned.
+func uploadMissing(ctx context.Context, handler status.ManifestPushHandler, dst oras.Target, missing []ocispec.Descriptor, layoutPath string, concurrency int) ([]ocispec.Descriptor, error) {
+	src, err := oci.NewFromFS(ctx, os.DirFS(layoutPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the OCI image layout %q: %w", layoutPath, err)
+	}
+	notFound, err := manifest.FindMissing(ctx, src, missing, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	if len(notFound) != 0 {
+		return notFound, nil
+	}
+
+	copyOpts := oras.DefaultCopyGraphOptions
+	copyOpts.Concurrency = concurrency
+	copyOpts.PreCopy = func(_ context.Context, desc ocispec.Descriptor) error {
+		return handler.OnContentUploading(desc)
+	}
+	copyOpts.PostCopy = func(_ context.Context, desc ocispec.Descriptor) error {
+		return handler.OnContentUploaded(desc)
+	}
+	copyOpts.OnCopySkipped = func(_ context.Context, desc ocispec.Descriptor) error {
+		return handler.OnContentExists(desc)
+	}
+	for _, desc := range missing {
+		if err := oras.CopyGraph(ctx, src, dst, desc, copyOpts); err != nil {
+			return nil, fmt.Errorf("failed to upload %s from %q: %w", desc.Digest, layoutPath, err)
+		}
+	}
+	return nil, nil
+}
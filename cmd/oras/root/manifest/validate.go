@@ -0,0 +1,165 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"errors"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/file"
+	"oras.land/oras/internal/validate"
+)
+
+type validateOptions struct {
+	option.Common
+	option.Platform
+	option.Target
+	option.Format
+
+	fileRef   string
+	mediaType string
+}
+
+func validateCmd() *cobra.Command {
+	var opts validateOptions
+	cmd := &cobra.Command{
+		Use:   "validate [flags] <name>[{:<tag>|@<digest>}] [<file>]",
+		Short: "[Experimental] Validate a manifest or an index",
+		Long: `[Experimental] Validate a manifest or an index
+
+Image manifests and indexes are validated against the JSON schemas of the OCI
+image spec. The media types and the annotation sizes of the manifest and its
+descriptors are checked, and the referenced config, layers and manifests are
+checked to exist in the target. Issues are reported with their JSON paths.
+
+Example - Validate the manifest tagged 'v1' in repository 'localhost:5000/hello':
+  oras manifest validate localhost:5000/hello:v1
+
+Example - Validate the linux/amd64 manifest of a multi-arch image:
+  oras manifest validate --platform linux/amd64 localhost:5000/hello:v1
+
+Example - Validate a local manifest file before pushing it to repository 'localhost:5000/hello':
+  oras manifest validate localhost:5000/hello manifest.json
+
+Example - Validate a manifest read from stdin with a specified media type:
+  oras manifest validate --media-type application/vnd.oci.image.manifest.v1+json localhost:5000/hello -
+
+Example - Validate a manifest in an OCI image layout folder 'layout-dir' and output the result in JSON:
+  oras manifest validate --oci-layout --format json layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Between(1, 2), "the target to validate against and the optional file to read manifest content from"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if len(args) == 2 {
+				opts.fileRef = args[1]
+				if opts.fileRef == "-" {
+					if err := option.CheckStdinConflict(cmd.Flags()); err != nil {
+						return err
+					}
+				}
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return validateManifest(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.mediaType, "media-type", "", "", "media type of the manifest, read from the manifest content by default")
+	opts.SetTypes(
+		option.FormatTypeText,
+		option.FormatTypeJSON,
+	)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func validateManifest(cmd *cobra.Command, opts *validateOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewManifestValidateHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+
+	var desc ocispec.Descriptor
+	var contentBytes []byte
+	if opts.fileRef != "" {
+		contentBytes, err = file.PrepareManifestContent(opts.fileRef)
+		if err != nil {
+			return err
+		}
+		mediaType := opts.mediaType
+		if mediaType == "" {
+			mediaType, err = manifest.ExtractMediaType(contentBytes)
+			if err != nil {
+				if errors.Is(err, manifest.ErrMediaTypeNotFound) {
+					return &oerrors.Error{
+						Err:            fmt.Errorf(`%w via the flag "--media-type" nor in %q`, err, opts.fileRef),
+						Usage:          fmt.Sprintf("%s %s", cmd.Parent().CommandPath(), cmd.Use),
+						Recommendation: `Please specify a valid media type in the manifest JSON or via the "--media-type" flag`,
+					}
+				}
+				return err
+			}
+		}
+		desc = content.NewDescriptorFromBytes(mediaType, contentBytes)
+	} else {
+		if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+			return err
+		}
+		fetchOpts := oras.DefaultFetchBytesOptions
+		fetchOpts.TargetPlatform = opts.Platform.Platform
+		desc, contentBytes, err = oras.FetchBytes(ctx, target, opts.Reference, fetchOpts)
+		if err != nil {
+			return fmt.Errorf("failed to fetch the content of %q: %w", opts.RawReference, err)
+		}
+		if opts.mediaType != "" {
+			desc.MediaType = opts.mediaType
+		}
+	}
+
+	issues, err := validate.Manifest(ctx, target, desc.MediaType, contentBytes)
+	if err != nil {
+		return err
+	}
+	if err := handler.OnValidated(opts.Path, desc, opts.fileRef, issues); err != nil {
+		return err
+	}
+	if err := handler.Render(); err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		source := opts.RawReference
+		if opts.fileRef != "" {
+			source = opts.fileRef
+		}
+		return fmt.Errorf("found %d issue(s) in %q", len(issues), source)
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
@@ -41,6 +42,7 @@ import (
 type pullOptions struct {
 	option.Cache
 	option.Common
+	option.Download
 	option.Platform
 	option.Target
 	option.Format
@@ -85,6 +87,9 @@ Example - Pull files from a registry with certain platform:
 Example - Pull all files with concurrency level tuned:
   oras pull --concurrency 6 localhost:5000/hello:v1
 
+Example - [Preview] Pull all files, downloading blobs larger than 16 MiB in 8 concurrent ranged requests:
+  oras pull --download-parts 8 --download-threshold 16777216 localhost:5000/hello:v1
+
 Example - [Experimental] Pull files and format output in JSON:
   oras pull localhost:5000/hello:v1 --format json
 
@@ -148,7 +153,11 @@ func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
-	src, err := opts.CachedTarget(target)
+	var source oras.ReadOnlyTarget = target
+	if repo, ok := target.(*remote.Repository); ok {
+		source = opts.ParallelTarget(repo, repo)
+	}
+	src, err := opts.CachedTarget(source)
 	if err != nil {
 		return err
 	}
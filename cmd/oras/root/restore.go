@@ -19,20 +19,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/backup"
 	orasio "oras.land/oras/internal/io"
 )
 
@@ -40,28 +47,67 @@ type restoreOptions struct {
 	option.Common
 	option.Remote
 	option.Terminal
+	option.Conflict
+	option.Decryption
 
 	// flags
 	input            string
 	excludeReferrers bool
 	dryRun           bool
 	concurrency      int
+	ociLayout        bool
 
 	// derived options
+	registry   string
 	repository string
 	tags       []string
 }
 
+// restoreItem is a tagged artifact in the OCI image layout to restore.
+type restoreItem struct {
+	// ref is the reference of the artifact in the OCI image layout.
+	ref        string
+	repository string
+	tag        string
+	root       ocispec.Descriptor
+	// fullReference indicates if the artifact is displayed with the full
+	// reference of the restored artifact.
+	fullReference bool
+}
+
+// name returns the name of the restored artifact for display.
+func (item restoreItem) name() string {
+	if item.fullReference {
+		return item.repository + ":" + item.tag
+	}
+	return item.tag
+}
+
 func restoreCmd() *cobra.Command {
 	var opts restoreOptions
 	cmd := &cobra.Command{
-		Use:   "restore [flags] --input <path> <registry>/<repository>[:<ref1>[,<ref2>...]]",
-		Short: "[Experimental] Restore artifacts to a registry from an OCI image layout",
-		Long: `[Experimental] Restore artifacts to a registry from an OCI image layout, which can be either a directory or a tar archive.
+		Use:   "restore [flags] --input <path> {<registry> | {<registry>/<repository> | <path>}[:<ref1>[,<ref2>...]]}",
+		Short: "[Experimental] Restore artifacts to a registry or an OCI image layout from an OCI image layout",
+		Long: `[Experimental] Restore artifacts to a registry or an OCI image layout from an OCI image layout, which can be either a directory or a tar archive.
+The "--oci-layout" flag restores to an OCI image layout directory in the form of <path>[:<ref1>[,<ref2>...]] instead of a registry. The full references of a multi-repository backup are kept in the target OCI image layout.
+Tar archives compressed with gzip or zstd are detected automatically. To restore from a tar archive split into volumes, pass its first volume or its index file, and all volumes are read in sequence.
+Encrypted tar archives are decrypted as a stream with "--decrypt-identity" or "--decrypt-passphrase-stdin", and the decrypted content is only written to a temporary OCI layout removed after the restore.
 
 Example - Restore a single artifact from a tar archive:
   oras restore --input hello.tar localhost:5000/hello:v1
 
+Example - Restore a single artifact from a compressed tar archive:
+  oras restore --input hello.tar.gz localhost:5000/hello:v1
+
+Example - Restore from a tar archive split into volumes:
+  oras restore --input hello.tar.001 localhost:5000/hello
+
+Example - Restore from an encrypted tar archive with the age private keys in a file:
+  oras restore --input hello.tar.gz.age --decrypt-identity key.txt localhost:5000/hello
+
+Example - Restore from an encrypted tar archive with a passphrase read from stdin:
+  oras restore --input hello.tar.age --decrypt-passphrase-stdin localhost:5000/hello < passphrase.txt
+
 Example - Restore a single artifact from a directory:
   oras restore --input hello localhost:5000/hello:v1
 
@@ -91,6 +137,21 @@ Example - Restore to a plain HTTP registry (no TLS):
 
 Example - Set custom concurrency level:
   oras restore --input hello --concurrency 6 localhost:5000/hello:v1
+
+Example - Restore all repositories of a multi-repository backup to a registry, keeping the repository paths:
+  oras restore --input apps.tar localhost:5000
+
+Example - Restore all repositories of a multi-repository backup under a namespace:
+  oras restore --input apps.tar localhost:5000/mirror
+
+Example - Restore without moving tags which already point to different artifacts:
+  oras restore --input hello --on-conflict skip localhost:5000/hello
+
+Example - Fail before restoring anything if any tag already points to a different artifact:
+  oras restore --input hello --on-conflict fail localhost:5000/hello
+
+Example - Restore specific tags to an OCI image layout:
+  oras restore --input hello.tar.gz --oci-layout layout-dir:v1,v2
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the targets to restore to"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -98,11 +159,26 @@ Example - Set custom concurrency level:
 				return err
 			}
 
-			// parse repo and tags
-			var err error
-			opts.repository, opts.tags, err = parseArtifactReferences(args[0])
-			if err != nil {
-				return err
+			// parse the target registry, or the target repository and tags
+			switch {
+			case opts.ociLayout:
+				var err error
+				opts.repository, opts.tags, err = parseLayoutReferences(args[0])
+				if err != nil {
+					return err
+				}
+			case !strings.Contains(args[0], "/"):
+				ref := registry.Reference{Registry: args[0]}
+				if err := ref.ValidateRegistry(); err != nil {
+					return fmt.Errorf("invalid target %q: %w", args[0], err)
+				}
+				opts.registry = args[0]
+			default:
+				var err error
+				opts.repository, opts.tags, err = parseArtifactReferences(args[0])
+				if err != nil {
+					return err
+				}
 			}
 
 			opts.DisableTTY(opts.Debug, false)
@@ -115,12 +191,13 @@ Example - Set custom concurrency level:
 	}
 
 	// required flag
-	cmd.Flags().StringVar(&opts.input, "input", "", "path to the OCI layout, either a tar archive (*.tar) or a directory")
+	cmd.Flags().StringVar(&opts.input, "input", "", "path to the OCI layout, either a tar archive, optionally compressed with gzip or zstd, encrypted or split into volumes, or a directory")
 	_ = cmd.MarkFlagRequired("input")
 	// optional flags
 	cmd.Flags().BoolVar(&opts.excludeReferrers, "exclude-referrers", false, "restore artifacts excluding their referrers")
 	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "simulate the restore process without actually uploading any artifacts")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().BoolVarP(&opts.ociLayout, "oci-layout", "", false, "set the target as an OCI image layout")
 	opts.EnableDistributionSpecFlag()
 	// apply flags
 	option.ApplyFlags(&opts, cmd.Flags())
@@ -134,22 +211,64 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 	startTime := time.Now() // start timing the restore process
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 
-	// prepare the target registry
-	dstRepo, err := opts.NewRepository(opts.repository, opts.Common, logger)
-	if err != nil {
-		return fmt.Errorf("failed to prepare target repository %q: %w", opts.repository, err)
-	}
-	statusHandler, metadataHandler := display.NewRestoreHandler(opts.Printer, opts.TTY, dstRepo, opts.dryRun)
-
 	// prepare the source OCI store
 	var srcOCI oras.ReadOnlyGraphTarget
 	fi, err := os.Stat(opts.input)
 	if err != nil {
 		return fmt.Errorf("failed to access input path %q: %w", opts.input, err)
 	}
+	var isTar bool
+	inputSize := fi.Size()
 	switch {
 	case fi.Mode().IsRegular():
-		isTar, err := orasio.IsTarFile(opts.input)
+		volumeBase, isVolume := backup.VolumeBase(opts.input)
+		var encrypted bool
+		compression := orasio.CompressionNone
+		if isVolume {
+			volumes, err := backup.OpenVolumes(volumeBase)
+			if err != nil {
+				return fmt.Errorf("failed to open the volumes of %q: %w", opts.input, err)
+			}
+			_ = volumes.Close()
+			inputSize = volumes.Size()
+		} else {
+			if encrypted, err = backup.IsEncryptedFile(opts.input); err != nil {
+				return fmt.Errorf("unable to determine if %q is encrypted: %w", opts.input, err)
+			}
+			if compression, err = orasio.DetectFileCompression(opts.input); err != nil {
+				return fmt.Errorf("unable to determine the compression of %q: %w", opts.input, err)
+			}
+		}
+		if isVolume || encrypted || compression != orasio.CompressionNone {
+			// the OCI store requires random access to the tar archive, so the
+			// archive is decrypted, decompressed and extracted as a stream to
+			// a temporary directory
+			isTar = true
+			tempDir, err := os.MkdirTemp("", "oras-restore-*")
+			if err != nil {
+				return fmt.Errorf("failed to create temporary directory for restore: %w", err)
+			}
+			defer func() {
+				if err := os.RemoveAll(tempDir); err != nil {
+					logger.Debugf("failed to remove temporary directory %s: %v", tempDir, err)
+				}
+			}()
+			if err := extractArchive(opts.input, tempDir, opts.Identities); err != nil {
+				if errors.Is(err, backup.ErrEncrypted) {
+					return &oerrors.Error{
+						Err:            fmt.Errorf("failed to load archive %q: %w", opts.input, err),
+						Recommendation: "Use --decrypt-identity or --decrypt-passphrase-stdin to decrypt the archive.",
+					}
+				}
+				return fmt.Errorf("failed to load archive %q: %w", opts.input, err)
+			}
+			srcOCI, err = oci.NewWithContext(ctx, tempDir)
+			if err != nil {
+				return fmt.Errorf("failed to prepare OCI store from archive %q: %w", opts.input, err)
+			}
+			break
+		}
+		isTar, err = orasio.IsTarFile(opts.input)
 		if err != nil {
 			return fmt.Errorf("unable to determine if %q is a tar archive: %w", opts.input, err)
 		}
@@ -160,9 +279,6 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 		if err != nil {
 			return fmt.Errorf("failed to prepare OCI store from tar archive %q: %w", opts.input, err)
 		}
-		if err := metadataHandler.OnTarLoaded(opts.input, fi.Size()); err != nil {
-			return err
-		}
 	case fi.IsDir():
 		srcOCI, err = oci.NewWithContext(ctx, opts.input)
 		if err != nil {
@@ -172,21 +288,66 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 		return fmt.Errorf("input path %q must be a directory or a tar archive", opts.input)
 	}
 
+	statusHandler, metadataHandler := display.NewRestoreHandler(opts.Printer, opts.TTY, srcOCI, opts.dryRun)
+	if isTar {
+		if err := metadataHandler.OnTarLoaded(opts.input, inputSize); err != nil {
+			return err
+		}
+	}
+
 	// resolve tags to restore
-	tags, roots, err := resolveTags(ctx, srcOCI, opts.tags)
+	items, err := planRestore(ctx, srcOCI, opts)
 	if err != nil {
 		return err
 	}
-	if len(tags) == 0 {
+	if len(items) == 0 {
 		return &oerrors.Error{
 			Err:            fmt.Errorf("no tags found in OCI layout %q", opts.input),
 			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags --oci-layout"`, opts.input),
 		}
 	}
-	if err := metadataHandler.OnTagsFound(tags); err != nil {
+	refs := make([]string, len(items))
+	for i, item := range items {
+		refs[i] = item.ref
+	}
+	if err := metadataHandler.OnTagsFound(refs); err != nil {
 		return err
 	}
 
+	// prepare the target repositories
+	dstRepos := make(map[string]oras.GraphTarget)
+	for _, item := range items {
+		if _, ok := dstRepos[item.repository]; ok {
+			continue
+		}
+		dstRepo, err := opts.newTarget(item.repository, logger)
+		if err != nil {
+			return fmt.Errorf("failed to prepare target %q: %w", item.repository, err)
+		}
+		dstRepos[item.repository] = dstRepo
+	}
+
+	// check the destination tags before restoring anything
+	conflicts := make(map[int]ocispec.Descriptor)
+	for i, item := range items {
+		existing, err := resolveExistingTags(ctx, dstRepos[item.repository], []string{item.tag})
+		if err != nil {
+			return fmt.Errorf("failed to check tag %q in %q: %w", item.tag, item.repository, err)
+		}
+		if desc, ok := existing[item.tag]; ok && desc.Digest != item.root.Digest {
+			conflicts[i] = desc
+			if err := metadataHandler.OnTagConflict(item.name(), desc, item.root); err != nil {
+				return err
+			}
+		}
+	}
+	if len(conflicts) > 0 && opts.OnConflict == option.ConflictPolicyFail {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%d tag(s) in the destination point to different artifacts", len(conflicts)),
+			Recommendation: `Use "--on-conflict overwrite" to overwrite the tags or "--on-conflict skip" to skip the conflicting artifacts.`,
+		}
+	}
+
 	// prepare copy options
 	copyOpts := oras.DefaultCopyOptions
 	copyOpts.Concurrency = opts.concurrency
@@ -199,25 +360,39 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 			return registry.Referrers(ctx, src, desc, "")
 		},
 	}
-	for i, tag := range tags {
+	var restoredCount int
+	for i, item := range items {
+		previous, conflicted := conflicts[i]
+		if conflicted && opts.OnConflict == option.ConflictPolicySkip {
+			if err := metadataHandler.OnConflictSkipped(item.name()); err != nil {
+				return err
+			}
+			continue
+		}
 		var referrerCount int
 		if !opts.excludeReferrers {
 			// count referrers from source
-			referrerCount, err = countReferrers(ctx, srcOCI, tag, roots[i], extCopyGraphOpts)
+			referrerCount, err = countReferrers(ctx, srcOCI, item.ref, item.root, extCopyGraphOpts)
 			if err != nil {
-				return fmt.Errorf("failed to count referrers for tag %q: %w", tag, err)
+				return fmt.Errorf("failed to count referrers for tag %q: %w", item.ref, err)
 			}
 		}
 		if opts.dryRun {
-			if err := metadataHandler.OnArtifactPushed(tag, referrerCount); err != nil {
+			if err := metadataHandler.OnArtifactPushed(item.name(), referrerCount); err != nil {
 				return err
 			}
+			if conflicted {
+				if err := metadataHandler.OnTagOverwritten(item.name(), previous, item.root); err != nil {
+					return err
+				}
+			}
+			restoredCount++
 			// dry run, skip actual copy
 			continue
 		}
 
 		if err := func() (retErr error) {
-			trackedDst, err := statusHandler.StartTracking(dstRepo)
+			trackedDst, err := statusHandler.StartTracking(dstRepos[item.repository])
 			if err != nil {
 				return err
 			}
@@ -229,19 +404,173 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 			}()
 
 			if opts.excludeReferrers {
-				_, err := oras.Copy(ctx, srcOCI, tag, trackedDst, tag, copyOpts)
+				_, err := oras.Copy(ctx, srcOCI, item.ref, trackedDst, item.tag, copyOpts)
 				return err
 			}
-			return recursiveCopy(ctx, srcOCI, trackedDst, tag, roots[i], extCopyGraphOpts)
+			return recursiveCopy(ctx, srcOCI, trackedDst, item.tag, item.root, extCopyGraphOpts)
 		}(); err != nil {
-			return fmt.Errorf("failed to restore tag %q from %q to %q: %w", tag, opts.input, opts.repository, oerrors.UnwrapCopyError(err))
+			return fmt.Errorf("failed to restore tag %q from %q to %q: %w", item.tag, opts.input, item.repository, oerrors.UnwrapCopyError(err))
 		}
 
-		if err := metadataHandler.OnArtifactPushed(tag, referrerCount); err != nil {
+		if err := metadataHandler.OnArtifactPushed(item.name(), referrerCount); err != nil {
 			return err
 		}
+		if conflicted {
+			if err := metadataHandler.OnTagOverwritten(item.name(), previous, item.root); err != nil {
+				return err
+			}
+		}
+		restoredCount++
 	}
 
+	target := opts.repository
+	if opts.registry != "" {
+		target = opts.registry
+	}
 	duration := time.Since(startTime)
-	return metadataHandler.OnRestoreCompleted(len(tags), opts.repository, duration)
+	return metadataHandler.OnRestoreCompleted(restoredCount, target, duration)
+}
+
+// newTarget returns the target to restore to, which is either the repository
+// or the OCI image layout at path. An OCI image layout which does not exist is
+// not created in a dry run.
+func (opts *restoreOptions) newTarget(path string, logger logrus.FieldLogger) (oras.GraphTarget, error) {
+	target := option.Target{
+		Remote:       opts.Remote,
+		RawReference: path,
+		Type:         option.TargetTypeRemote,
+		Path:         path,
+	}
+	if opts.ociLayout {
+		target.Type = option.TargetTypeOCILayout
+		if opts.dryRun {
+			if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+				return memory.New(), nil
+			}
+		}
+	}
+	return target.NewTarget(opts.Common, logger)
+}
+
+// planRestore resolves the artifacts in src to restore and maps them to the
+// target repositories.
+// Artifacts referenced by tags are restored to the target repository.
+// Artifacts referenced by full references, as created by multi-repository
+// backups, are restored as follows:
+//   - If the target is a registry, the repository paths are kept.
+//   - If the backup contains a single repository, it is restored to the
+//     target repository.
+//   - If the target is an OCI image layout, the full references are kept.
+//   - Otherwise, the repository paths are prefixed by the target repository.
+//
+// An OCI layout mixing full references with tags is rejected.
+func planRestore(ctx context.Context, src oras.ReadOnlyTarget, opts *restoreOptions) ([]restoreItem, error) {
+	refs, roots, err := resolveTags(ctx, src, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	type fullRefItem struct {
+		ref  registry.Reference
+		root ocispec.Descriptor
+	}
+	var fullRefItems []fullRefItem
+	var repositories []string
+	var plainTags []string
+	for i, ref := range refs {
+		if !strings.Contains(ref, "/") {
+			// tags cannot contain "/"
+			plainTags = append(plainTags, ref)
+			continue
+		}
+		parsed, err := registry.ParseReference(ref)
+		if err == nil {
+			err = parsed.ValidateReferenceAsTag()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference %q in the OCI layout: %w", ref, err)
+		}
+		fullRefItems = append(fullRefItems, fullRefItem{ref: parsed, root: roots[i]})
+		repository := parsed.Registry + "/" + parsed.Repository
+		if !slices.Contains(repositories, repository) {
+			repositories = append(repositories, repository)
+		}
+	}
+
+	if len(fullRefItems) == 0 {
+		// restore tags to the target repository
+		if opts.registry != "" {
+			return nil, &oerrors.Error{
+				Err:            fmt.Errorf("the OCI layout %q does not contain full references to restore to registry %q", opts.input, opts.registry),
+				Recommendation: fmt.Sprintf("Specify the target repository, e.g. %s/<repository>", opts.registry),
+			}
+		}
+		if len(opts.tags) > 0 {
+			if refs, roots, err = resolveTags(ctx, src, opts.tags, nil); err != nil {
+				return nil, err
+			}
+		}
+		items := make([]restoreItem, len(refs))
+		for i, ref := range refs {
+			items[i] = restoreItem{
+				ref:        ref,
+				repository: opts.repository,
+				tag:        ref,
+				root:       roots[i],
+			}
+		}
+		return items, nil
+	}
+
+	if len(plainTags) > 0 {
+		// the target repository of the plain tags is ambiguous
+		return nil, &oerrors.Error{
+			Err:            fmt.Errorf("the OCI layout %q contains both full references and plain tags: %s", opts.input, strings.Join(plainTags, ", ")),
+			Recommendation: "Tag the manifests of the plain tags with full references, or remove the plain tags from the OCI layout before restoring.",
+		}
+	}
+	if opts.registry == "" && len(repositories) > 1 && len(opts.tags) > 0 {
+		return nil, &oerrors.Error{
+			Err:            fmt.Errorf("tags cannot be specified when restoring %d repositories", len(repositories)),
+			Recommendation: fmt.Sprintf("Specify %q to restore all repositories under it.", opts.repository),
+		}
+	}
+	items := make([]restoreItem, 0, len(fullRefItems))
+	for _, fullRef := range fullRefItems {
+		item := restoreItem{
+			ref:           fullRef.ref.String(),
+			tag:           fullRef.ref.Reference,
+			root:          fullRef.root,
+			fullReference: true,
+		}
+		switch {
+		case opts.registry != "":
+			item.repository = opts.registry + "/" + fullRef.ref.Repository
+		case len(repositories) == 1:
+			item.repository = opts.repository
+			item.fullReference = false
+		case opts.ociLayout:
+			item.repository = opts.repository
+			item.tag = item.ref
+			item.fullReference = false
+		default:
+			item.repository = opts.repository + "/" + fullRef.ref.Repository
+		}
+		items = append(items, item)
+	}
+	if len(opts.tags) == 0 {
+		return items, nil
+	}
+
+	// select the specified tags of the single repository
+	selected := make([]restoreItem, 0, len(opts.tags))
+	for _, tag := range opts.tags {
+		i := slices.IndexFunc(items, func(item restoreItem) bool {
+			return item.tag == tag
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("failed to resolve tag %q: %w", tag, errdef.ErrNotFound)
+		}
+		selected = append(selected, items[i])
+	}
+	return selected, nil
 }
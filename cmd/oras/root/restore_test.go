@@ -0,0 +1,221 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func Test_planRestore(t *testing.T) {
+	ctx := context.Background()
+	newStore := func(t *testing.T, refs ...string) *oci.Store {
+		t.Helper()
+		store, err := oci.New(t.TempDir())
+		if err != nil {
+			t.Fatalf("failed to create OCI store: %v", err)
+		}
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "test/artifact", oras.PackManifestOptions{})
+		if err != nil {
+			t.Fatalf("failed to pack manifest: %v", err)
+		}
+		for _, ref := range refs {
+			if err := store.Tag(ctx, desc, ref); err != nil {
+				t.Fatalf("failed to tag %q: %v", ref, err)
+			}
+		}
+		return store
+	}
+	type item struct {
+		ref, repository, tag, name string
+	}
+
+	tests := []struct {
+		name    string
+		refs    []string
+		opts    restoreOptions
+		want    []item
+		wantErr bool
+		errText string // expected in the error message if set
+	}{
+		{
+			name: "tags to repository",
+			refs: []string{"v1", "v2"},
+			opts: restoreOptions{repository: "localhost:5000/repo"},
+			want: []item{
+				{"v1", "localhost:5000/repo", "v1", "v1"},
+				{"v2", "localhost:5000/repo", "v2", "v2"},
+			},
+		},
+		{
+			name: "specified tags to repository",
+			refs: []string{"v1", "v2"},
+			opts: restoreOptions{repository: "localhost:5000/repo", tags: []string{"v2"}},
+			want: []item{
+				{"v2", "localhost:5000/repo", "v2", "v2"},
+			},
+		},
+		{
+			name:    "tags to registry",
+			refs:    []string{"v1"},
+			opts:    restoreOptions{registry: "localhost:5000"},
+			wantErr: true,
+		},
+		{
+			name: "full references to registry",
+			refs: []string{"src.io/ns/repo1:v1", "src.io/repo2:v2"},
+			opts: restoreOptions{registry: "localhost:5000"},
+			want: []item{
+				{"src.io/ns/repo1:v1", "localhost:5000/ns/repo1", "v1", "localhost:5000/ns/repo1:v1"},
+				{"src.io/repo2:v2", "localhost:5000/repo2", "v2", "localhost:5000/repo2:v2"},
+			},
+		},
+		{
+			name: "full references under namespace",
+			refs: []string{"src.io/ns/repo1:v1", "src.io/repo2:v2"},
+			opts: restoreOptions{repository: "localhost:5000/mirror"},
+			want: []item{
+				{"src.io/ns/repo1:v1", "localhost:5000/mirror/ns/repo1", "v1", "localhost:5000/mirror/ns/repo1:v1"},
+				{"src.io/repo2:v2", "localhost:5000/mirror/repo2", "v2", "localhost:5000/mirror/repo2:v2"},
+			},
+		},
+		{
+			name:    "full references of multiple repositories with tags",
+			refs:    []string{"src.io/ns/repo1:v1", "src.io/repo2:v2"},
+			opts:    restoreOptions{repository: "localhost:5000/mirror", tags: []string{"v1"}},
+			wantErr: true,
+		},
+		{
+			name: "full references of single repository to repository",
+			refs: []string{"src.io/repo:v1", "src.io/repo:v2"},
+			opts: restoreOptions{repository: "localhost:5000/hello", tags: []string{"v2"}},
+			want: []item{
+				{"src.io/repo:v2", "localhost:5000/hello", "v2", "v2"},
+			},
+		},
+		{
+			name: "full references of multiple repositories to OCI layout",
+			refs: []string{"src.io/ns/repo1:v1", "src.io/repo2:v2"},
+			opts: restoreOptions{repository: "layout", ociLayout: true},
+			want: []item{
+				{"src.io/ns/repo1:v1", "layout", "src.io/ns/repo1:v1", "src.io/ns/repo1:v1"},
+				{"src.io/repo2:v2", "layout", "src.io/repo2:v2", "src.io/repo2:v2"},
+			},
+		},
+		{
+			name: "full references of single repository to OCI layout",
+			refs: []string{"src.io/repo:v1"},
+			opts: restoreOptions{repository: "layout", ociLayout: true},
+			want: []item{
+				{"src.io/repo:v1", "layout", "v1", "v1"},
+			},
+		},
+		{
+			name:    "full references mixed with plain tags",
+			refs:    []string{"src.io/repo:v1", "v2", "latest"},
+			opts:    restoreOptions{repository: "localhost:5000/hello"},
+			wantErr: true,
+			errText: "latest, v2",
+		},
+		{
+			name:    "full references mixed with plain tags to registry",
+			refs:    []string{"src.io/repo:v1", "v2"},
+			opts:    restoreOptions{registry: "localhost:5000"},
+			wantErr: true,
+		},
+		{
+			name:    "full references of single repository with missing tag",
+			refs:    []string{"src.io/repo:v1"},
+			opts:    restoreOptions{repository: "localhost:5000/hello", tags: []string{"v2"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := planRestore(ctx, newStore(t, tt.refs...), &tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("planRestore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if err != nil && !strings.Contains(err.Error(), tt.errText) {
+					t.Errorf("planRestore() error = %v, want containing %q", err, tt.errText)
+				}
+				return
+			}
+			got := make([]item, len(items))
+			for i, it := range items {
+				got[i] = item{it.ref, it.repository, it.tag, it.name()}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planRestore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_restoreOptions_newTarget(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "layout")
+
+	// a missing OCI layout is not created in a dry run
+	opts := &restoreOptions{ociLayout: true, dryRun: true}
+	target, err := opts.newTarget(path, logrus.New())
+	if err != nil {
+		t.Fatalf("newTarget() error = %v", err)
+	}
+	if _, err := target.Resolve(ctx, "v1"); !errors.Is(err, errdef.ErrNotFound) {
+		t.Errorf("Resolve() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expect %q not to be created in a dry run, got %v", path, err)
+	}
+
+	// the OCI layout is created otherwise
+	opts.dryRun = false
+	target, err = opts.newTarget(path, logrus.New())
+	if err != nil {
+		t.Fatalf("newTarget() error = %v", err)
+	}
+	desc, err := oras.PackManifest(ctx, target, oras.PackManifestVersion1_1, "test/artifact", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatalf("failed to pack manifest: %v", err)
+	}
+	if err := target.Tag(ctx, desc, "v1"); err != nil {
+		t.Fatalf("failed to tag: %v", err)
+	}
+	store, err := oci.New(path)
+	if err != nil {
+		t.Fatalf("failed to open OCI layout: %v", err)
+	}
+	got, err := store.Resolve(ctx, "v1")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got.Digest != desc.Digest {
+		t.Errorf("Resolve() = %v, want %v", got.Digest, desc.Digest)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"filippo.io/age"
+)
+
+// EncryptedExtension is the extension of an encrypted backup archive.
+const EncryptedExtension = ".age"
+
+// encryptionMagic is the beginning of the header of an age encrypted file.
+var encryptionMagic = []byte("age-encryption.org/")
+
+// ErrEncrypted is returned when reading an encrypted archive without any
+// identity to decrypt it.
+var ErrEncrypted = errors.New("the archive is encrypted")
+
+// IsEncrypted reports whether the content with the magic bytes is encrypted.
+func IsEncrypted(magic []byte) bool {
+	return bytes.HasPrefix(magic, encryptionMagic)
+}
+
+// IsEncryptedFile reports whether the file at path is encrypted.
+func IsEncryptedFile(path string) (bool, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	defer func() {
+		_ = fp.Close()
+	}()
+
+	magic := make([]byte, len(encryptionMagic))
+	n, err := io.ReadFull(fp, magic)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, fmt.Errorf("failed to read magic bytes from file %q: %w", path, err)
+	}
+	return IsEncrypted(magic[:n]), nil
+}
+
+// NewEncryptWriter returns a writer encrypting the content written to w to
+// the recipients. The content is authenticated, so that any modification or
+// truncation is detected on decryption. Closing the returned writer flushes
+// the last chunk but does not close w.
+func NewEncryptWriter(w io.Writer, recipients []age.Recipient) (io.WriteCloser, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("no recipients to encrypt to")
+	}
+	ew, err := age.Encrypt(w, recipients...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	return ew, nil
+}
+
+// NewDecryptReader returns a reader decrypting the content read from r with
+// the identities if the content is encrypted. The content is returned as is
+// if it is not encrypted. ErrEncrypted is returned if the content is
+// encrypted but no identity is provided.
+func NewDecryptReader(r io.Reader, identities []age.Identity) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(encryptionMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read magic bytes: %w", err)
+	}
+	if !IsEncrypted(magic) {
+		return br, nil
+	}
+	if len(identities) == 0 {
+		return nil, ErrEncrypted
+	}
+	dr, err := age.Decrypt(br, identities...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return dr, nil
+}
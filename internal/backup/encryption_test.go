@@ -0,0 +1,146 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func encrypt(t *testing.T, plaintext []byte, recipients ...age.Recipient) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewEncryptWriter(&buf, recipients)
+	if err != nil {
+		t.Fatalf("NewEncryptWriter() error = %v", err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestEncryption(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("failed to generate identity: %v", err)
+	}
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("failed to generate identity: %v", err)
+	}
+	plaintext := bytes.Repeat([]byte("hello world\n"), 10000)
+	ciphertext := encrypt(t, plaintext, identity.Recipient())
+	if !IsEncrypted(ciphertext) {
+		t.Fatal("IsEncrypted() = false for encrypted content")
+	}
+	if bytes.Contains(ciphertext, []byte("hello world")) {
+		t.Fatal("encrypted content contains the plaintext")
+	}
+
+	// decrypt
+	r, err := NewDecryptReader(bytes.NewReader(ciphertext), []age.Identity{other, identity})
+	if err != nil {
+		t.Fatalf("NewDecryptReader() error = %v", err)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatal("decrypted content does not match the plaintext")
+	}
+
+	// no identity
+	if _, err := NewDecryptReader(bytes.NewReader(ciphertext), nil); !errors.Is(err, ErrEncrypted) {
+		t.Errorf("NewDecryptReader() error = %v, want %v", err, ErrEncrypted)
+	}
+
+	// wrong identity
+	if _, err := NewDecryptReader(bytes.NewReader(ciphertext), []age.Identity{other}); err == nil {
+		t.Error("NewDecryptReader() error = nil for a wrong identity")
+	}
+
+	// truncated and tampered content
+	tampered := bytes.Clone(ciphertext)
+	tampered[len(tampered)-100] ^= 0xff
+	for name, content := range map[string][]byte{
+		"truncated": ciphertext[:len(ciphertext)-100],
+		"tampered":  tampered,
+	} {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewDecryptReader(bytes.NewReader(content), []age.Identity{identity})
+			if err != nil {
+				return
+			}
+			if _, err := io.ReadAll(r); err == nil {
+				t.Error("failed to detect the modification of the encrypted content")
+			}
+		})
+	}
+}
+
+func TestNewDecryptReader_plaintext(t *testing.T) {
+	plaintext := []byte("not encrypted")
+	r, err := NewDecryptReader(bytes.NewReader(plaintext), nil)
+	if err != nil {
+		t.Fatalf("NewDecryptReader() error = %v", err)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil || !bytes.Equal(got, plaintext) {
+		t.Errorf("NewDecryptReader() content = %q, %v, want %q", got, err, plaintext)
+	}
+}
+
+func TestNewEncryptWriter_noRecipients(t *testing.T) {
+	if _, err := NewEncryptWriter(io.Discard, nil); err == nil {
+		t.Error("NewEncryptWriter() error = nil, want error for no recipients")
+	}
+}
+
+func TestIsEncryptedFile(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("failed to generate identity: %v", err)
+	}
+	dir := t.TempDir()
+	encrypted := filepath.Join(dir, "backup.tar.age")
+	if err := os.WriteFile(encrypted, encrypt(t, []byte("hello"), identity.Recipient()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	plain := filepath.Join(dir, "backup.tar")
+	if err := os.WriteFile(plain, []byte("age"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := IsEncryptedFile(encrypted); err != nil || !got {
+		t.Errorf("IsEncryptedFile(encrypted) = %v, %v, want true", got, err)
+	}
+	if got, err := IsEncryptedFile(plain); err != nil || got {
+		t.Errorf("IsEncryptedFile(plain) = %v, %v, want false", got, err)
+	}
+	if _, err := IsEncryptedFile(filepath.Join(dir, "missing")); err == nil {
+		t.Error("IsEncryptedFile() error = nil for a missing file")
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// JournalFileName is the name of the backup journal file stored at the root of
+// the OCI image layout.
+const JournalFileName = "oras-backup-journal.jsonl"
+
+// TagChange records the change of a tag between two backup runs.
+type TagChange struct {
+	Tag            string        `json:"tag"`
+	Digest         digest.Digest `json:"digest,omitempty"`
+	PreviousDigest digest.Digest `json:"previousDigest,omitempty"`
+}
+
+// JournalEntry records what changed in an OCI image layout during a backup run.
+type JournalEntry struct {
+	Repository  string      `json:"repository"`
+	StartedAt   time.Time   `json:"startedAt"`
+	CompletedAt time.Time   `json:"completedAt"`
+	Added       []TagChange `json:"added,omitempty"`
+	Updated     []TagChange `json:"updated,omitempty"`
+	Unchanged   []TagChange `json:"unchanged,omitempty"`
+	Pruned      []TagChange `json:"pruned,omitempty"`
+}
+
+// AppendJournal appends entry to the journal file at the root of the OCI image
+// layout, creating the file if it does not exist.
+func AppendJournal(root string, entry JournalEntry) (appendErr error) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal backup journal entry: %w", err)
+	}
+	path := filepath.Join(root, JournalFileName)
+	fp, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return fmt.Errorf("failed to open backup journal %s: %w", path, err)
+	}
+	defer func() {
+		if err := fp.Close(); appendErr == nil {
+			appendErr = err
+		}
+	}()
+	if _, err := fp.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write backup journal %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAppendJournal(t *testing.T) {
+	root := t.TempDir()
+	startedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []JournalEntry{
+		{
+			Repository:  "localhost:5000/hello",
+			StartedAt:   startedAt,
+			CompletedAt: startedAt.Add(time.Minute),
+			Added: []TagChange{
+				{Tag: "v1", Digest: "sha256:d5b7c742df27379894518554b73f7a3a03b4440ea435151a8b525a8d2555a0b2"},
+			},
+		},
+		{
+			Repository:  "localhost:5000/hello",
+			StartedAt:   startedAt.Add(time.Hour),
+			CompletedAt: startedAt.Add(time.Hour + time.Minute),
+			Updated: []TagChange{
+				{
+					Tag:            "v1",
+					Digest:         "sha256:a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447",
+					PreviousDigest: "sha256:d5b7c742df27379894518554b73f7a3a03b4440ea435151a8b525a8d2555a0b2",
+				},
+			},
+			Pruned: []TagChange{
+				{Tag: "v0", PreviousDigest: "sha256:d5b7c742df27379894518554b73f7a3a03b4440ea435151a8b525a8d2555a0b2"},
+			},
+		},
+	}
+	for _, entry := range entries {
+		if err := AppendJournal(root, entry); err != nil {
+			t.Fatalf("AppendJournal() error = %v", err)
+		}
+	}
+
+	fp, err := os.Open(filepath.Join(root, JournalFileName))
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	defer fp.Close()
+	var got []JournalEntry
+	for decoder := json.NewDecoder(fp); decoder.More(); {
+		var entry JournalEntry
+		if err := decoder.Decode(&entry); err != nil {
+			t.Fatalf("failed to decode journal: %v", err)
+		}
+		got = append(got, entry)
+	}
+	if !reflect.DeepEqual(got, entries) {
+		t.Errorf("journal entries = %v, want %v", got, entries)
+	}
+}
+
+func TestAppendJournal_invalidRoot(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "not-exist")
+	if err := AppendJournal(root, JournalEntry{}); err == nil {
+		t.Error("AppendJournal() error = nil, wantErr true")
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/version"
+)
+
+// ReportFileName is the name of the integrity report file stored at the root
+// of the OCI image layout.
+const ReportFileName = "oras-backup-report.json"
+
+// TagRecord records a backed up tag in the integrity report.
+type TagRecord struct {
+	Reference string        `json:"reference"`
+	MediaType string        `json:"mediaType"`
+	Digest    digest.Digest `json:"digest"`
+	Size      int64         `json:"size"`
+	Referrers int           `json:"referrers"`
+}
+
+// BlobRecord records a blob in the integrity report.
+type BlobRecord struct {
+	Digest digest.Digest `json:"digest"`
+	Size   int64         `json:"size"`
+}
+
+// Report is the integrity report of a backup, listing every backed up tag and
+// the blobs required by their graphs.
+type Report struct {
+	ORASVersion string       `json:"orasVersion"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	Tags        []TagRecord  `json:"tags"`
+	Blobs       []BlobRecord `json:"blobs"`
+}
+
+// NewReport creates the integrity report of the tagged artifacts in the
+// storage. The graph of each artifact, including the referrers of any
+// manifest in the graph, is walked to list the blobs. Only manifests are
+// fetched from the storage.
+func NewReport(ctx context.Context, storage content.ReadOnlyGraphStorage, tags map[string]ocispec.Descriptor) (*Report, error) {
+	report := &Report{
+		ORASVersion: version.GetVersion(),
+		CreatedAt:   time.Now().UTC(),
+		Tags:        make([]TagRecord, 0, len(tags)),
+	}
+	blobs := make(map[digest.Digest]int64)
+	for _, ref := range slices.Sorted(maps.Keys(tags)) {
+		root := tags[ref]
+		nodes, referrers, err := walkGraph(ctx, storage, root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk the graph of %q: %w", ref, err)
+		}
+		for _, node := range nodes {
+			blobs[node.Digest] = node.Size
+		}
+		report.Tags = append(report.Tags, TagRecord{
+			Reference: ref,
+			MediaType: root.MediaType,
+			Digest:    root.Digest,
+			Size:      root.Size,
+			Referrers: referrers,
+		})
+	}
+	report.Blobs = make([]BlobRecord, 0, len(blobs))
+	for _, dgst := range slices.Sorted(maps.Keys(blobs)) {
+		report.Blobs = append(report.Blobs, BlobRecord{Digest: dgst, Size: blobs[dgst]})
+	}
+	return report, nil
+}
+
+// walkGraph returns all nodes in the graph rooted at root, along with the
+// referrers of the manifests in the graph, and the number of referrers found.
+func walkGraph(ctx context.Context, storage content.ReadOnlyGraphStorage, root ocispec.Descriptor) ([]ocispec.Descriptor, int, error) {
+	var nodes []ocispec.Descriptor
+	var referrerCount int
+	visited := map[digest.Digest]bool{root.Digest: true}
+	queue := []ocispec.Descriptor{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		nodes = append(nodes, node)
+		if !descriptor.IsManifest(node) {
+			continue
+		}
+		successors, err := content.Successors(ctx, storage, node)
+		if err != nil {
+			return nil, 0, err
+		}
+		referrers, err := registry.Referrers(ctx, storage, node, "")
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, successor := range successors {
+			if !visited[successor.Digest] {
+				visited[successor.Digest] = true
+				queue = append(queue, successor)
+			}
+		}
+		for _, referrer := range referrers {
+			if !visited[referrer.Digest] {
+				visited[referrer.Digest] = true
+				queue = append(queue, referrer)
+				referrerCount++
+			}
+		}
+	}
+	return nodes, referrerCount, nil
+}
+
+// Marshal returns the JSON encoding of the report.
+func (r *Report) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal backup report: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// WriteReport writes the report to the root of the OCI image layout,
+// replacing the existing one.
+func WriteReport(root string, report *Report) error {
+	data, err := report.Marshal()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(root, ReportFileName)
+	if err := os.WriteFile(path, data, 0666); err != nil {
+		return fmt.Errorf("failed to write backup report %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadReport reads the report at the root of the OCI image layout. It returns
+// nil if the report does not exist.
+func ReadReport(root string) (*Report, error) {
+	path := filepath.Join(root, ReportFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read backup report %s: %w", path, err)
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse backup report %s: %w", path, err)
+	}
+	return &report, nil
+}
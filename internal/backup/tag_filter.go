@@ -0,0 +1,149 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+// TagFilter selects the tags to back up.
+// A nil or zero TagFilter selects all tags.
+type TagFilter struct {
+	// Pattern selects the tags matching the regular expression.
+	Pattern *regexp.Regexp
+	// Constraint selects the tags which are semantic versions satisfying the
+	// constraint.
+	Constraint *semver.Constraints
+	// Since selects the tags whose manifests are created at or after the time,
+	// according to the created annotation.
+	Since time.Time
+	// Latest selects at most the latest N tags if positive. Tags are ordered
+	// by semantic version if Constraint is set, or by the created annotation
+	// of their manifests otherwise.
+	Latest int
+}
+
+// IsEmpty returns true if the filter selects all tags.
+func (f *TagFilter) IsEmpty() bool {
+	return f == nil || (f.Pattern == nil && f.Constraint == nil && f.Since.IsZero() && f.Latest <= 0)
+}
+
+// MatchName returns true if the tag name matches the regular expression and
+// the semantic version constraint of the filter.
+func (f *TagFilter) MatchName(tag string) bool {
+	if f == nil {
+		return true
+	}
+	if f.Pattern != nil && !f.Pattern.MatchString(tag) {
+		return false
+	}
+	if f.Constraint != nil {
+		version, err := semver.NewVersion(tag)
+		if err != nil || !f.Constraint.Check(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// Select returns the tags and their descriptors selected by the filter, in
+// the original order. The manifests are fetched from the fetcher only if the
+// filter depends on their created annotations.
+func (f *TagFilter) Select(ctx context.Context, fetcher content.Fetcher, tags []string, descs []ocispec.Descriptor) ([]string, []ocispec.Descriptor, error) {
+	if f.IsEmpty() {
+		return tags, descs, nil
+	}
+
+	type candidate struct {
+		index   int
+		version *semver.Version
+		created time.Time
+	}
+	byCreated := !f.Since.IsZero() || (f.Latest > 0 && f.Constraint == nil)
+	candidates := make([]candidate, 0, len(tags))
+	for i, tag := range tags {
+		if !f.MatchName(tag) {
+			continue
+		}
+		c := candidate{index: i}
+		if f.Constraint != nil {
+			c.version, _ = semver.NewVersion(tag) // validated by MatchName
+		}
+		if byCreated {
+			created, err := fetchCreated(ctx, fetcher, descs[i])
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get the creation time of tag %q: %w", tag, err)
+			}
+			if !f.Since.IsZero() && (created.IsZero() || created.Before(f.Since)) {
+				continue
+			}
+			c.created = created
+		}
+		candidates = append(candidates, c)
+	}
+
+	if f.Latest > 0 && len(candidates) > f.Latest {
+		// sort the candidates from the latest to the oldest
+		slices.SortStableFunc(candidates, func(a, b candidate) int {
+			if f.Constraint != nil {
+				return b.version.Compare(a.version)
+			}
+			return b.created.Compare(a.created)
+		})
+		candidates = candidates[:f.Latest]
+		slices.SortFunc(candidates, func(a, b candidate) int {
+			return cmp.Compare(a.index, b.index)
+		})
+	}
+
+	selectedTags := make([]string, 0, len(candidates))
+	selectedDescs := make([]ocispec.Descriptor, 0, len(candidates))
+	for _, c := range candidates {
+		selectedTags = append(selectedTags, tags[c.index])
+		selectedDescs = append(selectedDescs, descs[c.index])
+	}
+	return selectedTags, selectedDescs, nil
+}
+
+// fetchCreated returns the time in the created annotation of the manifest.
+// A zero time is returned if the manifest is not annotated with a valid time.
+func fetchCreated(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) (time.Time, error) {
+	data, err := content.FetchAll(ctx, fetcher, desc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var manifest struct {
+		Annotations map[string]string `json:"annotations"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse manifest %s: %w", desc.Digest, err)
+	}
+	created, err := time.Parse(time.RFC3339, manifest.Annotations[ocispec.AnnotationCreated])
+	if err != nil {
+		return time.Time{}, nil
+	}
+	return created, nil
+}
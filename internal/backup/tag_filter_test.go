@@ -0,0 +1,167 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestTagFilter_MatchName(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *TagFilter
+		tag    string
+		want   bool
+	}{
+		{name: "nil filter", tag: "latest", want: true},
+		{name: "empty filter", filter: &TagFilter{}, tag: "latest", want: true},
+		{name: "regex matched", filter: &TagFilter{Pattern: regexp.MustCompile(`^release-`)}, tag: "release-1", want: true},
+		{name: "regex not matched", filter: &TagFilter{Pattern: regexp.MustCompile(`^release-`)}, tag: "ci-123", want: false},
+		{name: "semver satisfied", filter: &TagFilter{Constraint: mustConstraint(t, ">=1.2 <2")}, tag: "v1.4.0", want: true},
+		{name: "semver not satisfied", filter: &TagFilter{Constraint: mustConstraint(t, ">=1.2 <2")}, tag: "2.0.0", want: false},
+		{name: "not a semver", filter: &TagFilter{Constraint: mustConstraint(t, ">=1.2 <2")}, tag: "latest", want: false},
+		{
+			name:   "regex and semver",
+			filter: &TagFilter{Pattern: regexp.MustCompile(`^v`), Constraint: mustConstraint(t, ">=1.2 <2")},
+			tag:    "1.4.0",
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.MatchName(tt.tag); got != tt.want {
+				t.Errorf("TagFilter.MatchName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagFilter_Select(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	pushManifest := func(created string) ocispec.Descriptor {
+		manifest := ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    ocispec.DescriptorEmptyJSON,
+			Layers:    []ocispec.Descriptor{},
+		}
+		if created != "" {
+			manifest.Annotations = map[string]string{ocispec.AnnotationCreated: created}
+		}
+		data, err := json.Marshal(manifest)
+		if err != nil {
+			t.Fatalf("failed to marshal manifest: %v", err)
+		}
+		desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, data)
+		if err := store.Push(ctx, desc, bytes.NewReader(data)); err != nil {
+			t.Fatalf("failed to push manifest: %v", err)
+		}
+		return desc
+	}
+	tags := []string{"v1.0.0", "v1.2.0", "v1.10.0", "v2.0.0", "latest"}
+	descs := []ocispec.Descriptor{
+		pushManifest("2025-01-01T00:00:00Z"),
+		pushManifest("2025-03-01T00:00:00Z"),
+		pushManifest("2025-02-01T00:00:00Z"),
+		pushManifest(""),
+		pushManifest("2025-04-01T00:00:00Z"),
+	}
+
+	tests := []struct {
+		name     string
+		filter   *TagFilter
+		wantTags []string
+	}{
+		{
+			name:     "no filter",
+			wantTags: tags,
+		},
+		{
+			name:     "semver",
+			filter:   &TagFilter{Constraint: mustConstraint(t, ">=1.2 <2")},
+			wantTags: []string{"v1.2.0", "v1.10.0"},
+		},
+		{
+			name:     "latest by semver",
+			filter:   &TagFilter{Constraint: mustConstraint(t, "*"), Latest: 2},
+			wantTags: []string{"v1.10.0", "v2.0.0"},
+		},
+		{
+			name:     "latest by created",
+			filter:   &TagFilter{Latest: 2},
+			wantTags: []string{"v1.2.0", "latest"},
+		},
+		{
+			name:     "latest more than tags",
+			filter:   &TagFilter{Latest: 10},
+			wantTags: tags,
+		},
+		{
+			name:     "since",
+			filter:   &TagFilter{Since: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+			wantTags: []string{"v1.2.0", "v1.10.0", "latest"},
+		},
+		{
+			name: "regex, since and latest",
+			filter: &TagFilter{
+				Pattern: regexp.MustCompile(`^v1\.`),
+				Since:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+				Latest:  1,
+			},
+			wantTags: []string{"v1.2.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTags, gotDescs, err := tt.filter.Select(ctx, store, tags, descs)
+			if err != nil {
+				t.Fatalf("TagFilter.Select() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotTags, tt.wantTags) {
+				t.Fatalf("TagFilter.Select() tags = %v, want %v", gotTags, tt.wantTags)
+			}
+			for i, tag := range gotTags {
+				want := descs[slices.Index(tags, tag)]
+				if gotDescs[i].Digest != want.Digest {
+					t.Errorf("TagFilter.Select() descriptor of %q = %v, want %v", tag, gotDescs[i].Digest, want.Digest)
+				}
+			}
+		})
+	}
+}
+
+func mustConstraint(t *testing.T, constraint string) *semver.Constraints {
+	t.Helper()
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		t.Fatalf("failed to parse constraint %q: %v", constraint, err)
+	}
+	return c
+}
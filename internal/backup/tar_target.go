@@ -0,0 +1,385 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras/internal/descriptor"
+)
+
+// errTargetClosed is returned when the TarTarget is used after being closed.
+var errTargetClosed = errors.New("the tar target is closed")
+
+// TarTarget is an oras.GraphTarget writing an OCI image layout into a tar
+// archive as a stream. Blobs are appended to the archive as they are pushed,
+// and the index.json and oci-layout files are written when the target is
+// closed.
+// Only manifests are kept in memory so that they can be fetched back and
+// their predecessors can be found. Fetching other blobs is not supported.
+// Blobs pushed concurrently are spooled to temporary files while another blob
+// is being written, as the archive is written sequentially.
+type TarTarget struct {
+	lock         sync.Mutex
+	tw           *tar.Writer
+	err          error // sticky error corrupting the archive
+	closed       bool
+	dirs         map[string]bool
+	blobs        map[digest.Digest]ocispec.Descriptor
+	manifests    map[digest.Digest][]byte
+	predecessors map[digest.Digest][]ocispec.Descriptor
+	pushed       []ocispec.Descriptor // manifests in pushed order
+	tags         map[string]ocispec.Descriptor
+	tagOrder     []string
+}
+
+// NewTarTarget returns a TarTarget writing the tar archive to w.
+func NewTarTarget(w io.Writer) *TarTarget {
+	return &TarTarget{
+		tw:           tar.NewWriter(w),
+		dirs:         make(map[string]bool),
+		blobs:        make(map[digest.Digest]ocispec.Descriptor),
+		manifests:    make(map[digest.Digest][]byte),
+		predecessors: make(map[digest.Digest][]ocispec.Descriptor),
+		tags:         make(map[string]ocispec.Descriptor),
+	}
+}
+
+// Fetch fetches the manifest identified by the descriptor.
+func (t *TarTarget) Fetch(_ context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if data, ok := t.manifests[target.Digest]; ok {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	if _, ok := t.blobs[target.Digest]; ok {
+		return nil, fmt.Errorf("%s: fetching a blob written to the tar archive: %w", target.Digest, errdef.ErrUnsupported)
+	}
+	return nil, fmt.Errorf("%s: %s: %w", target.Digest, target.MediaType, errdef.ErrNotFound)
+}
+
+// Push appends the content to the tar archive, verifying it against the
+// expected descriptor.
+func (t *TarTarget) Push(ctx context.Context, expected ocispec.Descriptor, r io.Reader) error {
+	var data []byte
+	switch {
+	case descriptor.IsManifest(expected):
+		// keep manifests in memory for Fetch and Predecessors
+		var err error
+		if data, err = content.ReadAll(r, expected); err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+		t.lock.Lock()
+	case !t.lock.TryLock():
+		// another blob is being written, spool the content so that it is
+		// downloaded concurrently
+		spool, err := spoolBlob(expected, r)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = spool.Close()
+			_ = os.Remove(spool.Name())
+		}()
+		r = spool
+		t.lock.Lock()
+	}
+	defer t.lock.Unlock()
+
+	if err := t.checkWritable(); err != nil {
+		return err
+	}
+	if _, ok := t.blobs[expected.Digest]; ok {
+		return fmt.Errorf("%s: %s: %w", expected.Digest, expected.MediaType, errdef.ErrAlreadyExists)
+	}
+	if err := t.writeBlob(expected, r); err != nil {
+		// the archive is corrupted by a partially written entry
+		t.err = err
+		return err
+	}
+	t.blobs[expected.Digest] = expected
+	if data == nil {
+		return nil
+	}
+
+	t.manifests[expected.Digest] = data
+	t.pushed = append(t.pushed, expected)
+	successors, err := content.Successors(ctx, manifestFetcher{desc: expected, data: data}, expected)
+	if err != nil {
+		return err
+	}
+	for _, successor := range successors {
+		t.predecessors[successor.Digest] = append(t.predecessors[successor.Digest], expected)
+	}
+	return nil
+}
+
+// Exists returns true if the described content has been written to the tar
+// archive.
+func (t *TarTarget) Exists(_ context.Context, target ocispec.Descriptor) (bool, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	_, ok := t.blobs[target.Digest]
+	return ok, nil
+}
+
+// Predecessors returns the manifests directly pointing to the node.
+func (t *TarTarget) Predecessors(_ context.Context, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return append([]ocispec.Descriptor(nil), t.predecessors[node.Digest]...), nil
+}
+
+// Tag tags the manifest identified by the descriptor with the reference.
+func (t *TarTarget) Tag(_ context.Context, desc ocispec.Descriptor, reference string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.checkWritable(); err != nil {
+		return err
+	}
+	if reference == "" {
+		return errdef.ErrMissingReference
+	}
+	if _, ok := t.manifests[desc.Digest]; !ok {
+		return fmt.Errorf("%s: %s: %w", desc.Digest, desc.MediaType, errdef.ErrNotFound)
+	}
+	if _, ok := t.tags[reference]; !ok {
+		t.tagOrder = append(t.tagOrder, reference)
+	}
+	t.tags[reference] = desc
+	return nil
+}
+
+// Resolve resolves a reference to a descriptor.
+func (t *TarTarget) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if desc, ok := t.tags[reference]; ok {
+		return desc, nil
+	}
+	if dgst, err := digest.Parse(reference); err == nil {
+		if desc, ok := t.blobs[dgst]; ok && t.manifests[dgst] != nil {
+			return descriptor.Plain(desc), nil
+		}
+	}
+	return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+}
+
+// AddFile writes a regular file with the data at the root of the archive.
+func (t *TarTarget) AddFile(name string, data []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.checkWritable(); err != nil {
+		return err
+	}
+	if err := t.writeFile(name, data); err != nil {
+		t.err = err
+		return err
+	}
+	return nil
+}
+
+// Close writes the index.json and oci-layout files and the footer of the tar
+// archive. It does not close the underlying writer.
+func (t *TarTarget) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.checkWritable(); err != nil {
+		return err
+	}
+	t.closed = true
+
+	indexJSON, err := json.Marshal(t.index())
+	if err != nil {
+		return fmt.Errorf("failed to marshal index file: %w", err)
+	}
+	if err := t.writeFile(ocispec.ImageIndexFile, indexJSON); err != nil {
+		return err
+	}
+	layoutJSON, err := json.Marshal(ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion})
+	if err != nil {
+		return fmt.Errorf("failed to marshal OCI layout file: %w", err)
+	}
+	if err := t.writeFile(ocispec.ImageLayoutFile, layoutJSON); err != nil {
+		return err
+	}
+	return t.tw.Close()
+}
+
+// index returns the index of the OCI image layout, listing the tagged
+// manifests and then the untagged ones.
+func (t *TarTarget) index() ocispec.Index {
+	manifests := make([]ocispec.Descriptor, 0, len(t.tagOrder)+len(t.pushed))
+	tagged := make(map[digest.Digest]bool)
+	for _, ref := range t.tagOrder {
+		desc := t.tags[ref]
+		annotations := make(map[string]string, len(desc.Annotations)+1)
+		maps.Copy(annotations, desc.Annotations)
+		annotations[ocispec.AnnotationRefName] = ref
+		desc.Annotations = annotations
+		manifests = append(manifests, desc)
+		tagged[desc.Digest] = true
+	}
+	for _, desc := range t.pushed {
+		if !tagged[desc.Digest] {
+			manifests = append(manifests, descriptor.Plain(desc))
+		}
+	}
+	return ocispec.Index{
+		Versioned: specs.Versioned{
+			SchemaVersion: 2, // historical value
+		},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: manifests,
+	}
+}
+
+// checkWritable returns an error if the archive cannot be written anymore.
+func (t *TarTarget) checkWritable() error {
+	if t.closed {
+		return errTargetClosed
+	}
+	if t.err != nil {
+		return fmt.Errorf("the tar archive is corrupted: %w", t.err)
+	}
+	return nil
+}
+
+// writeBlob writes the content read from r to the blob path of desc.
+func (t *TarTarget) writeBlob(desc ocispec.Descriptor, r io.Reader) error {
+	if err := desc.Digest.Validate(); err != nil {
+		return fmt.Errorf("%s: %s: %w", desc.Digest, desc.MediaType, errdef.ErrInvalidDigest)
+	}
+	algDir := path.Join(ocispec.ImageBlobsDir, desc.Digest.Algorithm().String())
+	if err := t.writeDir(ocispec.ImageBlobsDir); err != nil {
+		return err
+	}
+	if err := t.writeDir(algDir); err != nil {
+		return err
+	}
+
+	name := path.Join(algDir, desc.Digest.Encoded())
+	if err := t.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     desc.Size,
+		Mode:     0644,
+		ModTime:  time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write tar header of %s: %w", name, err)
+	}
+	vr := content.NewVerifyReader(r, desc)
+	if _, err := io.Copy(t.tw, vr); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return vr.Verify()
+}
+
+// spoolBlob copies the content read from r into a temporary file, and returns
+// the file rewound to the start. The content is verified when it is written
+// to the archive.
+func spoolBlob(desc ocispec.Descriptor, r io.Reader) (spool *os.File, spoolErr error) {
+	fp, err := os.CreateTemp("", "oras_backup_blob_*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to spool %s: %w", desc.Digest, err)
+	}
+	defer func() {
+		if spoolErr != nil {
+			_ = fp.Close()
+			_ = os.Remove(fp.Name())
+		}
+	}()
+	// read one more byte than expected for the size to be verified
+	if _, err := io.Copy(fp, io.LimitReader(r, desc.Size+1)); err != nil {
+		return nil, fmt.Errorf("failed to spool %s: %w", desc.Digest, err)
+	}
+	if _, err := fp.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to spool %s: %w", desc.Digest, err)
+	}
+	return fp, nil
+}
+
+// writeDir writes the directory entry of name if not written.
+func (t *TarTarget) writeDir(name string) error {
+	if t.dirs[name] {
+		return nil
+	}
+	if err := t.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     name + "/",
+		Mode:     0755,
+		ModTime:  time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write tar header of %s: %w", name, err)
+	}
+	t.dirs[name] = true
+	return nil
+}
+
+// writeFile writes a regular file with the data.
+func (t *TarTarget) writeFile(name string, data []byte) error {
+	if err := t.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     int64(len(data)),
+		Mode:     0644,
+		ModTime:  time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write tar header of %s: %w", name, err)
+	}
+	if _, err := t.tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// manifestFetcher fetches the content of a single manifest.
+type manifestFetcher struct {
+	desc ocispec.Descriptor
+	data []byte
+}
+
+// Fetch implements content.Fetcher.
+func (f manifestFetcher) Fetch(_ context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if target.Digest != f.desc.Digest {
+		return nil, fmt.Errorf("%s: %s: %w", target.Digest, target.MediaType, errdef.ErrNotFound)
+	}
+	return io.NopCloser(bytes.NewReader(f.data)), nil
+}
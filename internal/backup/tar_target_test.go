@@ -0,0 +1,265 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func pushBlob(t *testing.T, target *TarTarget, mediaType string, data []byte) ocispec.Descriptor {
+	t.Helper()
+	desc := content.NewDescriptorFromBytes(mediaType, data)
+	if err := target.Push(context.Background(), desc, bytes.NewReader(data)); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	return desc
+}
+
+func pushManifest(t *testing.T, target *TarTarget, subject *ocispec.Descriptor, layers ...ocispec.Descriptor) ocispec.Descriptor {
+	t.Helper()
+	config := ocispec.DescriptorEmptyJSON
+	manifest := ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    layers,
+		Subject:   subject,
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("failed to marshal manifest: %v", err)
+	}
+	return pushBlob(t, target, ocispec.MediaTypeImageManifest, data)
+}
+
+func TestTarTarget(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	target := NewTarTarget(&buf)
+
+	config := pushBlob(t, target, ocispec.DescriptorEmptyJSON.MediaType, ocispec.DescriptorEmptyJSON.Data)
+	layer := pushBlob(t, target, "application/vnd.test", []byte("hello"))
+	root := pushManifest(t, target, nil, layer)
+	referrer := pushManifest(t, target, &root)
+
+	// duplicated push
+	err := target.Push(ctx, layer, bytes.NewReader([]byte("hello")))
+	if !errors.Is(err, errdef.ErrAlreadyExists) {
+		t.Fatalf("Push() error = %v, want %v", err, errdef.ErrAlreadyExists)
+	}
+
+	// exists
+	for _, desc := range []ocispec.Descriptor{config, layer, root, referrer} {
+		exists, err := target.Exists(ctx, desc)
+		if err != nil || !exists {
+			t.Fatalf("Exists(%s) = %v, %v, want true", desc.Digest, exists, err)
+		}
+	}
+	if exists, _ := target.Exists(ctx, content.NewDescriptorFromBytes("test", []byte("foo"))); exists {
+		t.Fatal("Exists() = true for a blob not pushed")
+	}
+
+	// fetch
+	rc, err := target.Fetch(ctx, root)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if _, err := content.ReadAll(rc, root); err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	_ = rc.Close()
+	if _, err := target.Fetch(ctx, layer); !errors.Is(err, errdef.ErrUnsupported) {
+		t.Fatalf("Fetch() error = %v, want %v", err, errdef.ErrUnsupported)
+	}
+
+	// predecessors
+	predecessors, err := target.Predecessors(ctx, root)
+	if err != nil {
+		t.Fatalf("Predecessors() error = %v", err)
+	}
+	if len(predecessors) != 1 || predecessors[0].Digest != referrer.Digest {
+		t.Fatalf("Predecessors() = %v, want [%s]", predecessors, referrer.Digest)
+	}
+
+	// tag and resolve
+	if err := target.Tag(ctx, layer, "v1"); !errors.Is(err, errdef.ErrNotFound) {
+		t.Fatalf("Tag() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+	if err := target.Tag(ctx, root, "v1"); err != nil {
+		t.Fatalf("Tag() error = %v", err)
+	}
+	if got, err := target.Resolve(ctx, "v1"); err != nil || got.Digest != root.Digest {
+		t.Fatalf("Resolve() = %v, %v, want %s", got, err, root.Digest)
+	}
+	if got, err := target.Resolve(ctx, referrer.Digest.String()); err != nil || got.Digest != referrer.Digest {
+		t.Fatalf("Resolve() = %v, %v, want %s", got, err, referrer.Digest)
+	}
+	if _, err := target.Resolve(ctx, "v2"); !errors.Is(err, errdef.ErrNotFound) {
+		t.Fatalf("Resolve() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+
+	// report
+	report, err := NewReport(ctx, target, map[string]ocispec.Descriptor{"v1": root})
+	if err != nil {
+		t.Fatalf("NewReport() error = %v", err)
+	}
+	if len(report.Tags) != 1 || report.Tags[0].Referrers != 1 || len(report.Blobs) != 4 {
+		t.Fatalf("NewReport() = %+v, want 1 tag with 1 referrer and 4 blobs", report)
+	}
+
+	// close and read the archive back
+	if err := target.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := target.Tag(ctx, root, "v2"); !errors.Is(err, errTargetClosed) {
+		t.Fatalf("Tag() error = %v, want %v", err, errTargetClosed)
+	}
+	path := filepath.Join(t.TempDir(), "backup.tar")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := oci.NewFromTar(ctx, path)
+	if err != nil {
+		t.Fatalf("oci.NewFromTar() error = %v", err)
+	}
+	got, err := store.Resolve(ctx, "v1")
+	if err != nil || got.Digest != root.Digest {
+		t.Fatalf("Resolve() from archive = %v, %v, want %s", got, err, root.Digest)
+	}
+	for _, desc := range []ocispec.Descriptor{config, layer, root, referrer} {
+		if exists, err := store.Exists(ctx, desc); err != nil || !exists {
+			t.Fatalf("Exists(%s) from archive = %v, %v, want true", desc.Digest, exists, err)
+		}
+	}
+	predecessors, err = store.Predecessors(ctx, root)
+	if err != nil || len(predecessors) != 1 || predecessors[0].Digest != referrer.Digest {
+		t.Fatalf("Predecessors() from archive = %v, %v, want [%s]", predecessors, err, referrer.Digest)
+	}
+}
+
+// notifyReader closes done when the content is read to the end.
+type notifyReader struct {
+	*bytes.Reader
+	done chan struct{}
+}
+
+func (r *notifyReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		close(r.done)
+	}
+	return n, err
+}
+
+func TestTarTarget_Push_concurrent(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	target := NewTarTarget(&buf)
+
+	// the first blob holds the archive until its content is fully sent
+	first := []byte("hello world")
+	firstDesc := content.NewDescriptorFromBytes("application/vnd.test", first)
+	pr, pw := io.Pipe()
+	firstErr := make(chan error, 1)
+	go func() {
+		firstErr <- target.Push(ctx, firstDesc, pr)
+	}()
+	if _, err := pw.Write(first[:5]); err != nil {
+		t.Fatal(err)
+	}
+
+	// the second blob is downloaded while the first one is being written
+	second := []byte("foo")
+	secondDesc := content.NewDescriptorFromBytes("application/vnd.test", second)
+	secondReader := &notifyReader{Reader: bytes.NewReader(second), done: make(chan struct{})}
+	secondErr := make(chan error, 1)
+	go func() {
+		secondErr <- target.Push(ctx, secondDesc, secondReader)
+	}()
+	select {
+	case <-secondReader.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second blob is not read while the first blob is being written")
+	}
+
+	if _, err := pw.Write(first[5:]); err != nil {
+		t.Fatal(err)
+	}
+	_ = pw.Close()
+	for _, errc := range []chan error{firstErr, secondErr} {
+		if err := <-errc; err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+	if err := target.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.tar")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := oci.NewFromTar(ctx, path)
+	if err != nil {
+		t.Fatalf("oci.NewFromTar() error = %v", err)
+	}
+	for _, desc := range []ocispec.Descriptor{firstDesc, secondDesc} {
+		rc, err := store.Fetch(ctx, desc)
+		if err != nil {
+			t.Fatalf("Fetch(%s) from archive error = %v", desc.Digest, err)
+		}
+		if _, err := content.ReadAll(rc, desc); err != nil {
+			t.Errorf("failed to read %s from archive: %v", desc.Digest, err)
+		}
+		_ = rc.Close()
+	}
+}
+
+func TestTarTarget_Push_corrupted(t *testing.T) {
+	ctx := context.Background()
+	target := NewTarTarget(&bytes.Buffer{})
+
+	desc := ocispec.Descriptor{
+		MediaType: "application/vnd.test",
+		Digest:    digest.FromString("foo"),
+		Size:      3,
+	}
+	if err := target.Push(ctx, desc, bytes.NewReader([]byte("bar"))); err == nil {
+		t.Fatal("Push() error = nil, want digest mismatch")
+	}
+	// the archive cannot be written after a partial entry
+	if err := target.Push(ctx, desc, bytes.NewReader([]byte("foo"))); err == nil {
+		t.Fatal("Push() error = nil after corruption")
+	}
+	if err := target.Close(); err == nil {
+		t.Fatal("Close() error = nil after corruption")
+	}
+}
@@ -0,0 +1,406 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras/internal/descriptor"
+)
+
+// IssueKind is the kind of an integrity issue.
+type IssueKind string
+
+const (
+	// IssueMissing indicates that content required by a tag is missing.
+	IssueMissing IssueKind = "missing"
+	// IssueCorrupted indicates that content does not match its digest or
+	// its descriptor.
+	IssueCorrupted IssueKind = "corrupted"
+	// IssueMismatched indicates that a tag does not match the integrity
+	// report.
+	IssueMismatched IssueKind = "mismatched"
+)
+
+// Issue is an integrity issue found in a backup.
+type Issue struct {
+	Kind IssueKind `json:"kind"`
+	// Reference is the tag affected by the issue, if any.
+	Reference string        `json:"reference,omitempty"`
+	Digest    digest.Digest `json:"digest,omitempty"`
+	Detail    string        `json:"detail"`
+}
+
+// VerifyResult is the result of verifying a backup.
+type VerifyResult struct {
+	// Tags are the tags found in the backup.
+	Tags []TagRecord
+	// Blobs is the number of blobs verified.
+	Blobs int
+	// Report is the integrity report embedded in the backup, or nil if the
+	// backup has no integrity report.
+	Report *Report
+	// Issues are the integrity issues found.
+	Issues []Issue
+}
+
+// Verify verifies the integrity of the backup stored as an OCI image layout
+// at root. Every blob is re-hashed, the graph of every tag including the
+// referrers is checked to be complete, and the tags and blobs are checked
+// against the integrity report if the backup has one.
+func Verify(ctx context.Context, root string) (*VerifyResult, error) {
+	v := &verifier{
+		root:       root,
+		blobs:      make(map[digest.Digest]int64),
+		corrupted:  make(map[digest.Digest]bool),
+		referrers:  make(map[digest.Digest][]ocispec.Descriptor),
+		reported:   make(map[digest.Digest]bool),
+		successors: make(map[digest.Digest][]ocispec.Descriptor),
+	}
+	index, err := readIndex(root)
+	if err != nil {
+		return nil, err
+	}
+	result := &VerifyResult{}
+	if result.Report, err = ReadReport(root); err != nil {
+		return nil, err
+	}
+
+	// re-hash every blob
+	if err := v.hashBlobs(); err != nil {
+		return nil, err
+	}
+	result.Blobs = len(v.blobs) + len(v.corrupted)
+
+	// index the referrers of all manifests in the layout
+	for _, desc := range index.Manifests {
+		if err := v.indexManifest(ctx, desc); err != nil {
+			return nil, err
+		}
+	}
+
+	// check the graph of every tag
+	tags := make(map[string]ocispec.Descriptor)
+	for _, desc := range index.Manifests {
+		if ref := desc.Annotations[ocispec.AnnotationRefName]; ref != "" {
+			tags[ref] = descriptor.Plain(desc)
+		}
+	}
+	for _, ref := range slices.Sorted(maps.Keys(tags)) {
+		desc := tags[ref]
+		referrers, err := v.checkGraph(ctx, ref, desc)
+		if err != nil {
+			return nil, err
+		}
+		result.Tags = append(result.Tags, TagRecord{
+			Reference: ref,
+			MediaType: desc.MediaType,
+			Digest:    desc.Digest,
+			Size:      desc.Size,
+			Referrers: referrers,
+		})
+	}
+
+	// check against the integrity report
+	if result.Report != nil {
+		v.checkReport(result.Report, result.Tags)
+	}
+	result.Issues = v.issues
+	return result, nil
+}
+
+// verifier verifies an OCI image layout.
+type verifier struct {
+	root string
+	// blobs are the sizes of the blobs matching their digests.
+	blobs map[digest.Digest]int64
+	// corrupted are the blobs not matching their digests.
+	corrupted map[digest.Digest]bool
+	// successors are the successors of the valid manifests.
+	successors map[digest.Digest][]ocispec.Descriptor
+	// referrers maps a subject to the manifests referring to it.
+	referrers map[digest.Digest][]ocispec.Descriptor
+	// reported are the digests already reported as missing or corrupted.
+	reported map[digest.Digest]bool
+	issues   []Issue
+}
+
+// hashBlobs re-hashes every blob under the blobs directory.
+func (v *verifier) hashBlobs() error {
+	blobsDir := filepath.Join(v.root, ocispec.ImageBlobsDir)
+	return filepath.WalkDir(blobsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == blobsDir {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(blobsDir, path)
+		if err != nil {
+			return err
+		}
+		alg, encoded := filepath.Split(rel)
+		dgst := digest.NewDigestFromEncoded(digest.Algorithm(filepath.Clean(alg)), encoded)
+		if err := dgst.Validate(); err != nil {
+			// not a blob of the layout
+			return nil
+		}
+		actual, size, err := hashFile(path, dgst.Algorithm())
+		if err != nil {
+			return err
+		}
+		if actual != dgst {
+			v.corrupted[dgst] = true
+			v.addIssue(Issue{
+				Kind:   IssueCorrupted,
+				Digest: dgst,
+				Detail: fmt.Sprintf("content digest %s does not match", actual),
+			})
+			return nil
+		}
+		v.blobs[dgst] = size
+		return nil
+	})
+}
+
+// hashFile returns the digest and the size of the file at path.
+func hashFile(path string, alg digest.Algorithm) (digest.Digest, int64, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		_ = fp.Close()
+	}()
+	digester := alg.Digester()
+	size, err := io.Copy(digester.Hash(), fp)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return digester.Digest(), size, nil
+}
+
+// indexManifest records the successors of the manifest and indexes it as a
+// referrer of its subject.
+func (v *verifier) indexManifest(ctx context.Context, desc ocispec.Descriptor) error {
+	if _, ok := v.successors[desc.Digest]; ok || !v.isValid(desc) || !descriptor.IsManifest(desc) {
+		return nil
+	}
+	data, err := content.FetchAll(ctx, v, desc)
+	if err != nil {
+		return err
+	}
+	var manifest struct {
+		Subject *ocispec.Descriptor `json:"subject,omitempty"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		v.addIssue(Issue{
+			Kind:   IssueCorrupted,
+			Digest: desc.Digest,
+			Detail: fmt.Sprintf("invalid manifest: %v", err),
+		})
+		return nil
+	}
+	successors, err := content.Successors(ctx, v, desc)
+	if err != nil {
+		v.addIssue(Issue{
+			Kind:   IssueCorrupted,
+			Digest: desc.Digest,
+			Detail: fmt.Sprintf("invalid manifest: %v", err),
+		})
+		return nil
+	}
+	v.successors[desc.Digest] = successors
+	if manifest.Subject != nil {
+		v.referrers[manifest.Subject.Digest] = append(v.referrers[manifest.Subject.Digest], descriptor.Plain(desc))
+	}
+	for _, successor := range successors {
+		if err := v.indexManifest(ctx, successor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkGraph checks that the graph of the tag, including the referrers, is
+// complete. It returns the number of referrers found.
+func (v *verifier) checkGraph(ctx context.Context, ref string, root ocispec.Descriptor) (int, error) {
+	var referrerCount int
+	visited := map[digest.Digest]bool{root.Digest: true}
+	queue := []ocispec.Descriptor{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if !v.checkNode(ref, node) || !descriptor.IsManifest(node) {
+			continue
+		}
+		if err := v.indexManifest(ctx, node); err != nil {
+			return 0, err
+		}
+		for _, successor := range v.successors[node.Digest] {
+			if !visited[successor.Digest] {
+				visited[successor.Digest] = true
+				queue = append(queue, successor)
+			}
+		}
+		for _, referrer := range v.referrers[node.Digest] {
+			if !visited[referrer.Digest] {
+				visited[referrer.Digest] = true
+				queue = append(queue, referrer)
+				referrerCount++
+			}
+		}
+	}
+	return referrerCount, nil
+}
+
+// checkNode checks that the content of the node exists and matches the
+// descriptor. It returns true if the content is valid.
+func (v *verifier) checkNode(ref string, node ocispec.Descriptor) bool {
+	if v.corrupted[node.Digest] {
+		return false
+	}
+	size, ok := v.blobs[node.Digest]
+	if !ok {
+		v.addIssue(Issue{
+			Kind:      IssueMissing,
+			Reference: ref,
+			Digest:    node.Digest,
+			Detail:    fmt.Sprintf("%s of size %d is missing", node.MediaType, node.Size),
+		})
+		return false
+	}
+	if size != node.Size {
+		v.addIssue(Issue{
+			Kind:      IssueCorrupted,
+			Reference: ref,
+			Digest:    node.Digest,
+			Detail:    fmt.Sprintf("content size %d does not match the descriptor size %d", size, node.Size),
+		})
+		return false
+	}
+	return true
+}
+
+// checkReport checks the tags and blobs against the integrity report.
+func (v *verifier) checkReport(report *Report, tags []TagRecord) {
+	found := make(map[string]TagRecord, len(tags))
+	for _, tag := range tags {
+		found[tag.Reference] = tag
+	}
+	for _, expected := range report.Tags {
+		actual, ok := found[expected.Reference]
+		switch {
+		case !ok:
+			v.issues = append(v.issues, Issue{
+				Kind:      IssueMissing,
+				Reference: expected.Reference,
+				Digest:    expected.Digest,
+				Detail:    "tag is missing from the index",
+			})
+		case actual.Digest != expected.Digest:
+			v.issues = append(v.issues, Issue{
+				Kind:      IssueMismatched,
+				Reference: expected.Reference,
+				Digest:    actual.Digest,
+				Detail:    fmt.Sprintf("tag points to %s instead of %s", actual.Digest, expected.Digest),
+			})
+		case actual.Referrers < expected.Referrers:
+			v.issues = append(v.issues, Issue{
+				Kind:      IssueMissing,
+				Reference: expected.Reference,
+				Digest:    expected.Digest,
+				Detail:    fmt.Sprintf("found %d referrer(s), expected %d", actual.Referrers, expected.Referrers),
+			})
+		}
+	}
+	for _, blob := range report.Blobs {
+		if v.corrupted[blob.Digest] {
+			continue
+		}
+		size, ok := v.blobs[blob.Digest]
+		switch {
+		case !ok:
+			v.addIssue(Issue{
+				Kind:   IssueMissing,
+				Digest: blob.Digest,
+				Detail: fmt.Sprintf("blob of size %d is missing", blob.Size),
+			})
+		case size != blob.Size:
+			v.addIssue(Issue{
+				Kind:   IssueCorrupted,
+				Digest: blob.Digest,
+				Detail: fmt.Sprintf("content size %d does not match the reported size %d", size, blob.Size),
+			})
+		}
+	}
+}
+
+// addIssue adds the issue unless an issue of the same digest was reported.
+func (v *verifier) addIssue(issue Issue) {
+	if v.reported[issue.Digest] {
+		return
+	}
+	v.reported[issue.Digest] = true
+	v.issues = append(v.issues, issue)
+}
+
+// isValid returns true if the content of desc exists and matches desc.
+func (v *verifier) isValid(desc ocispec.Descriptor) bool {
+	size, ok := v.blobs[desc.Digest]
+	return ok && size == desc.Size
+}
+
+// Fetch implements content.Fetcher for the verified blobs.
+func (v *verifier) Fetch(_ context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if !v.isValid(target) {
+		return nil, fmt.Errorf("%s: %s: %w", target.Digest, target.MediaType, errdef.ErrNotFound)
+	}
+	return os.Open(filepath.Join(v.root, ocispec.ImageBlobsDir, target.Digest.Algorithm().String(), target.Digest.Encoded()))
+}
+
+// readIndex reads the index.json file of the OCI image layout at root.
+func readIndex(root string) (*ocispec.Index, error) {
+	path := filepath.Join(root, ocispec.ImageIndexFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%s is not an OCI image layout: %w", root, err)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &index, nil
+}
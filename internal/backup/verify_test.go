@@ -0,0 +1,219 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+// testLayout is an OCI image layout with a tagged image and its referrer.
+type testLayout struct {
+	root     string
+	layer    ocispec.Descriptor
+	image    ocispec.Descriptor
+	referrer ocispec.Descriptor
+}
+
+func newTestLayout(t *testing.T) *testLayout {
+	t.Helper()
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := oci.New(root)
+	if err != nil {
+		t.Fatalf("failed to create OCI store: %v", err)
+	}
+	push := func(mediaType string, data []byte) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(mediaType, data)
+		if err := store.Push(ctx, desc, bytes.NewReader(data)); err != nil {
+			t.Fatalf("failed to push %s: %v", mediaType, err)
+		}
+		return desc
+	}
+	pushManifest := func(subject *ocispec.Descriptor, layers ...ocispec.Descriptor) ocispec.Descriptor {
+		manifest := ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    ocispec.DescriptorEmptyJSON,
+			Layers:    layers,
+			Subject:   subject,
+		}
+		if layers == nil {
+			manifest.Layers = []ocispec.Descriptor{}
+		}
+		data, err := json.Marshal(manifest)
+		if err != nil {
+			t.Fatalf("failed to marshal manifest: %v", err)
+		}
+		return push(ocispec.MediaTypeImageManifest, data)
+	}
+
+	layout := &testLayout{root: root}
+	push(ocispec.DescriptorEmptyJSON.MediaType, ocispec.DescriptorEmptyJSON.Data)
+	layout.layer = push("application/vnd.test", []byte("hello"))
+	layout.image = pushManifest(nil, layout.layer)
+	layout.referrer = pushManifest(&layout.image)
+	if err := store.Tag(ctx, layout.image, "v1"); err != nil {
+		t.Fatalf("failed to tag manifest: %v", err)
+	}
+
+	report, err := NewReport(ctx, store, map[string]ocispec.Descriptor{"v1": layout.image})
+	if err != nil {
+		t.Fatalf("NewReport() error = %v", err)
+	}
+	if err := WriteReport(root, report); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	return layout
+}
+
+func (l *testLayout) blobPath(desc ocispec.Descriptor) string {
+	return filepath.Join(l.root, ocispec.ImageBlobsDir, desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+}
+
+func TestNewReport(t *testing.T) {
+	layout := newTestLayout(t)
+	report, err := ReadReport(layout.root)
+	if err != nil {
+		t.Fatalf("ReadReport() error = %v", err)
+	}
+	if report == nil {
+		t.Fatal("ReadReport() = nil, want report")
+	}
+	if len(report.Tags) != 1 {
+		t.Fatalf("report tags = %v, want 1 tag", report.Tags)
+	}
+	if tag := report.Tags[0]; tag.Reference != "v1" || tag.Digest != layout.image.Digest || tag.Referrers != 1 {
+		t.Errorf("report tag = %+v, want v1 %s with 1 referrer", tag, layout.image.Digest)
+	}
+	// config, layer, image and referrer
+	if len(report.Blobs) != 4 {
+		t.Errorf("report blobs = %v, want 4 blobs", report.Blobs)
+	}
+	if report.ORASVersion == "" || report.CreatedAt.IsZero() {
+		t.Errorf("report version = %q, created at = %v, want both set", report.ORASVersion, report.CreatedAt)
+	}
+}
+
+func TestReadReport_notExist(t *testing.T) {
+	report, err := ReadReport(t.TempDir())
+	if err != nil {
+		t.Fatalf("ReadReport() error = %v", err)
+	}
+	if report != nil {
+		t.Errorf("ReadReport() = %v, want nil", report)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		corrupt    func(t *testing.T, l *testLayout)
+		wantIssues []IssueKind
+	}{
+		{
+			name: "valid backup",
+		},
+		{
+			name: "valid backup without report",
+			corrupt: func(t *testing.T, l *testLayout) {
+				if err := os.Remove(filepath.Join(l.root, ReportFileName)); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "corrupted layer",
+			corrupt: func(t *testing.T, l *testLayout) {
+				if err := os.WriteFile(l.blobPath(l.layer), []byte("world"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantIssues: []IssueKind{IssueCorrupted},
+		},
+		{
+			name: "missing layer",
+			corrupt: func(t *testing.T, l *testLayout) {
+				if err := os.Remove(l.blobPath(l.layer)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantIssues: []IssueKind{IssueMissing},
+		},
+		{
+			name: "missing referrer",
+			corrupt: func(t *testing.T, l *testLayout) {
+				if err := os.Remove(l.blobPath(l.referrer)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			// the referrer is no longer found from the subject
+			wantIssues: []IssueKind{IssueMissing, IssueMissing},
+		},
+		{
+			name: "tag moved",
+			corrupt: func(t *testing.T, l *testLayout) {
+				store, err := oci.New(l.root)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := store.Tag(context.Background(), l.referrer, "v1"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantIssues: []IssueKind{IssueMismatched},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := newTestLayout(t)
+			if tt.corrupt != nil {
+				tt.corrupt(t, layout)
+			}
+			result, err := Verify(ctx, layout.root)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if len(result.Issues) != len(tt.wantIssues) {
+				t.Fatalf("Verify() issues = %+v, want %v", result.Issues, tt.wantIssues)
+			}
+			for i, issue := range result.Issues {
+				if issue.Kind != tt.wantIssues[i] {
+					t.Errorf("Verify() issue[%d] = %+v, want kind %s", i, issue, tt.wantIssues[i])
+				}
+			}
+			if len(result.Tags) != 1 || result.Tags[0].Reference != "v1" {
+				t.Errorf("Verify() tags = %v, want [v1]", result.Tags)
+			}
+		})
+	}
+}
+
+func TestVerify_notLayout(t *testing.T) {
+	if _, err := Verify(context.Background(), t.TempDir()); err == nil {
+		t.Error("Verify() error = nil, want error for a directory without index.json")
+	}
+}
@@ -0,0 +1,404 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// volumeIndexSuffix is the suffix of the index file of a split archive.
+const volumeIndexSuffix = ".index.json"
+
+// errVolumeWriterClosed is returned when writing to a closed volume writer.
+var errVolumeWriterClosed = errors.New("the volume writer is closed")
+
+// volumePattern matches the path of a volume, e.g. "backup.tar.001".
+var volumePattern = regexp.MustCompile(`^(.+)\.(\d{3,})$`)
+
+// Volume describes a volume of a split archive.
+type Volume struct {
+	Name   string        `json:"name"`
+	Size   int64         `json:"size"`
+	Digest digest.Digest `json:"digest,omitempty"`
+}
+
+// VolumeIndex describes the volumes of a split archive.
+type VolumeIndex struct {
+	VolumeSize int64    `json:"volumeSize"`
+	Size       int64    `json:"size"`
+	Volumes    []Volume `json:"volumes"`
+}
+
+// VolumePath returns the path of the n-th volume of the split archive at
+// base, counting from 1.
+func VolumePath(base string, n int) string {
+	return fmt.Sprintf("%s.%03d", base, n)
+}
+
+// VolumeIndexPath returns the path of the index file of the split archive at
+// base.
+func VolumeIndexPath(base string) string {
+	return base + volumeIndexSuffix
+}
+
+// VolumeBase returns the path of the split archive which the path belongs to,
+// if the path is a volume or the index file of a split archive.
+func VolumeBase(path string) (string, bool) {
+	if base, ok := strings.CutSuffix(path, volumeIndexSuffix); ok && base != "" {
+		return base, true
+	}
+	matches := volumePattern.FindStringSubmatch(path)
+	if matches == nil {
+		return "", false
+	}
+	if n, err := strconv.Atoi(matches[2]); err != nil || n < 1 {
+		return "", false
+	}
+	return matches[1], true
+}
+
+// ReadVolumeIndex reads the index file of the split archive at base. It
+// returns nil if the index file does not exist.
+func ReadVolumeIndex(base string) (*VolumeIndex, error) {
+	path := VolumeIndexPath(base)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read volume index %s: %w", path, err)
+	}
+	var index VolumeIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse volume index %s: %w", path, err)
+	}
+	if len(index.Volumes) == 0 {
+		return nil, fmt.Errorf("volume index %s lists no volumes", path)
+	}
+	for _, v := range index.Volumes {
+		if v.Name != filepath.Base(v.Name) {
+			return nil, fmt.Errorf("volume index %s contains an invalid volume name %q", path, v.Name)
+		}
+	}
+	return &index, nil
+}
+
+// VolumeWriter writes an archive sequentially into volumes of a fixed size,
+// named after base with a numeric suffix, e.g. "backup.tar.001". The volumes
+// are staged in a temporary directory next to base, and moved to base along
+// with the index file describing the volumes only when the writer is closed,
+// so that a previous archive at base is kept if the writer is aborted.
+type VolumeWriter struct {
+	// OnVolumeCompleted is called when a volume is completely written.
+	OnVolumeCompleted func(path string, size int64) error
+
+	base       string
+	volumeSize int64
+	index      VolumeIndex
+	staging    string // the directory staging the volumes, created on demand
+	file       *os.File
+	digester   digest.Digester
+	written    int64 // bytes written to the current volume
+	closed     bool
+}
+
+// NewVolumeWriter creates a writer splitting the archive at base into
+// volumes of volumeSize bytes.
+func NewVolumeWriter(base string, volumeSize int64) (*VolumeWriter, error) {
+	if volumeSize <= 0 {
+		return nil, fmt.Errorf("invalid volume size %d", volumeSize)
+	}
+	return &VolumeWriter{
+		base:       base,
+		volumeSize: volumeSize,
+		index:      VolumeIndex{VolumeSize: volumeSize},
+	}, nil
+}
+
+// Write implements io.Writer. A new volume is created only when there is
+// content to write into it.
+func (w *VolumeWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errVolumeWriterClosed
+	}
+	var n int
+	for len(p) > 0 {
+		if w.file == nil {
+			if err := w.nextVolume(); err != nil {
+				return n, err
+			}
+		}
+		chunk := p[:min(int64(len(p)), w.volumeSize-w.written)]
+		written, err := w.file.Write(chunk)
+		w.digester.Hash().Write(chunk[:written])
+		w.written += int64(written)
+		n += written
+		if err != nil {
+			return n, err
+		}
+		if w.written == w.volumeSize {
+			if err := w.completeVolume(); err != nil {
+				return n, err
+			}
+		}
+		p = p[written:]
+	}
+	return n, nil
+}
+
+// Close completes the last volume, moves the volumes to base, removes the
+// stale volumes left by a previous archive at the same path, and writes the
+// index file.
+func (w *VolumeWriter) Close() (closeErr error) {
+	if w.closed {
+		return errVolumeWriterClosed
+	}
+	w.closed = true
+	defer func() {
+		if err := os.RemoveAll(w.staging); closeErr == nil {
+			closeErr = err
+		}
+	}()
+	if w.file != nil || len(w.index.Volumes) == 0 {
+		// always write at least one volume
+		if w.file == nil {
+			if err := w.nextVolume(); err != nil {
+				return err
+			}
+		}
+		if err := w.completeVolume(); err != nil {
+			return err
+		}
+	}
+	dir := filepath.Dir(w.base)
+	for _, v := range w.index.Volumes {
+		if err := os.Rename(filepath.Join(w.staging, v.Name), filepath.Join(dir, v.Name)); err != nil {
+			return fmt.Errorf("failed to move volume %s: %w", v.Name, err)
+		}
+	}
+	for n := len(w.index.Volumes) + 1; ; n++ {
+		if err := os.Remove(VolumePath(w.base, n)); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				break
+			}
+			return fmt.Errorf("failed to remove stale volume: %w", err)
+		}
+	}
+	data, err := json.MarshalIndent(w.index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal volume index: %w", err)
+	}
+	path := VolumeIndexPath(w.base)
+	staged := filepath.Join(w.staging, filepath.Base(path))
+	if err := os.WriteFile(staged, append(data, '\n'), 0666); err != nil {
+		return fmt.Errorf("failed to write volume index %s: %w", path, err)
+	}
+	if err := os.Rename(staged, path); err != nil {
+		return fmt.Errorf("failed to write volume index %s: %w", path, err)
+	}
+	return nil
+}
+
+// Abort closes the writer and removes the volumes written. A previous archive
+// at the same path is left intact.
+func (w *VolumeWriter) Abort() error {
+	w.closed = true
+	if w.file != nil {
+		_ = w.file.Close()
+		w.file = nil
+	}
+	if w.staging == "" {
+		return nil
+	}
+	return os.RemoveAll(w.staging)
+}
+
+// nextVolume creates the next volume in the staging directory.
+func (w *VolumeWriter) nextVolume() error {
+	if w.staging == "" {
+		staging, err := os.MkdirTemp(filepath.Dir(w.base), "."+filepath.Base(w.base)+".*")
+		if err != nil {
+			return fmt.Errorf("failed to create staging directory for volumes: %w", err)
+		}
+		w.staging = staging
+	}
+	path := VolumePath(w.base, len(w.index.Volumes)+1)
+	fp, err := os.Create(filepath.Join(w.staging, filepath.Base(path)))
+	if err != nil {
+		return fmt.Errorf("failed to create volume %s: %w", path, err)
+	}
+	w.file = fp
+	w.digester = digest.Canonical.Digester()
+	w.written = 0
+	return nil
+}
+
+// completeVolume closes the current volume and records it in the index.
+func (w *VolumeWriter) completeVolume() error {
+	path := VolumePath(w.base, len(w.index.Volumes)+1)
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close volume %s: %w", path, err)
+	}
+	w.file = nil
+	w.index.Volumes = append(w.index.Volumes, Volume{
+		Name:   filepath.Base(path),
+		Size:   w.written,
+		Digest: w.digester.Digest(),
+	})
+	w.index.Size += w.written
+	if w.OnVolumeCompleted != nil {
+		return w.OnVolumeCompleted(path, w.written)
+	}
+	return nil
+}
+
+// VolumeReader reads the volumes of a split archive sequentially as a single
+// stream. If the volumes are described by an index file, the size and the
+// digest of each volume are verified while reading.
+type VolumeReader struct {
+	dir      string
+	volumes  []Volume
+	size     int64
+	current  int
+	file     *os.File
+	reader   io.Reader
+	verifier digest.Verifier
+	read     int64 // bytes read from the current volume
+}
+
+// OpenVolumes opens the split archive at base for reading. The volumes are
+// listed in the index file, or discovered by their numeric suffixes if the
+// index file does not exist.
+func OpenVolumes(base string) (*VolumeReader, error) {
+	index, err := ReadVolumeIndex(base)
+	if err != nil {
+		return nil, err
+	}
+	r := &VolumeReader{dir: filepath.Dir(base)}
+	if index != nil {
+		r.volumes = index.Volumes
+		r.size = index.Size
+	} else {
+		for n := 1; ; n++ {
+			path := VolumePath(base, n)
+			fi, err := os.Stat(path)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					break
+				}
+				return nil, fmt.Errorf("failed to access volume %s: %w", path, err)
+			}
+			r.volumes = append(r.volumes, Volume{Name: filepath.Base(path), Size: fi.Size()})
+			r.size += fi.Size()
+		}
+		if len(r.volumes) == 0 {
+			return nil, fmt.Errorf("no volumes found for %s: %w", base, fs.ErrNotExist)
+		}
+	}
+	return r, nil
+}
+
+// Size returns the total size of the volumes.
+func (r *VolumeReader) Size() int64 {
+	return r.size
+}
+
+// Read implements io.Reader.
+func (r *VolumeReader) Read(p []byte) (int, error) {
+	for {
+		if r.file == nil {
+			if r.current >= len(r.volumes) {
+				return 0, io.EOF
+			}
+			if err := r.openVolume(); err != nil {
+				return 0, err
+			}
+		}
+		n, err := r.reader.Read(p)
+		r.read += int64(n)
+		if err == io.EOF {
+			if err := r.closeVolume(); err != nil {
+				return n, err
+			}
+			if n == 0 {
+				continue
+			}
+			return n, nil
+		}
+		return n, err
+	}
+}
+
+// Close implements io.Closer.
+func (r *VolumeReader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// openVolume opens the current volume.
+func (r *VolumeReader) openVolume() error {
+	v := r.volumes[r.current]
+	path := filepath.Join(r.dir, v.Name)
+	fp, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open volume %s: %w", path, err)
+	}
+	r.file = fp
+	r.reader = fp
+	r.verifier = nil
+	r.read = 0
+	if v.Digest != "" {
+		if err := v.Digest.Validate(); err != nil {
+			return fmt.Errorf("invalid digest of volume %s: %w", path, err)
+		}
+		r.verifier = v.Digest.Verifier()
+		// read one more byte to detect a volume larger than expected
+		r.reader = io.TeeReader(io.LimitReader(fp, v.Size+1), r.verifier)
+	}
+	return nil
+}
+
+// closeVolume verifies and closes the current volume.
+func (r *VolumeReader) closeVolume() error {
+	v := r.volumes[r.current]
+	path := filepath.Join(r.dir, v.Name)
+	if err := r.Close(); err != nil {
+		return fmt.Errorf("failed to close volume %s: %w", path, err)
+	}
+	r.current++
+	if r.read != v.Size {
+		return fmt.Errorf("volume %s has %d bytes, expected %d", path, r.read, v.Size)
+	}
+	if r.verifier != nil && !r.verifier.Verified() {
+		return fmt.Errorf("volume %s does not match digest %s", path, v.Digest)
+	}
+	return nil
+}
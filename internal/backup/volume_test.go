@@ -0,0 +1,237 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestVolumeBase(t *testing.T) {
+	tests := []struct {
+		path     string
+		wantBase string
+		wantOK   bool
+	}{
+		{path: "backup.tar.001", wantBase: "backup.tar", wantOK: true},
+		{path: "dir/backup.tar.gz.012", wantBase: "dir/backup.tar.gz", wantOK: true},
+		{path: "backup.tar.1000", wantBase: "backup.tar", wantOK: true},
+		{path: "backup.tar.index.json", wantBase: "backup.tar", wantOK: true},
+		{path: "backup.tar.000", wantOK: false},
+		{path: "backup.tar.01", wantOK: false},
+		{path: "backup.tar", wantOK: false},
+		{path: ".index.json", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			base, ok := VolumeBase(tt.path)
+			if ok != tt.wantOK || base != tt.wantBase {
+				t.Errorf("VolumeBase() = %q, %v, want %q, %v", base, ok, tt.wantBase, tt.wantOK)
+			}
+		})
+	}
+}
+
+// writeVolumes writes data into volumes of volumeSize bytes with multiple
+// writes and returns the paths of the completed volumes.
+func writeVolumes(t *testing.T, base string, volumeSize int64, data []byte) []string {
+	t.Helper()
+	w, err := NewVolumeWriter(base, volumeSize)
+	if err != nil {
+		t.Fatalf("NewVolumeWriter() error = %v", err)
+	}
+	var completed []string
+	w.OnVolumeCompleted = func(path string, size int64) error {
+		completed = append(completed, path)
+		return nil
+	}
+	for chunk := range slices.Chunk(data, 7) {
+		if _, err := w.Write(chunk); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return completed
+}
+
+func readVolumes(base string) ([]byte, error) {
+	r, err := OpenVolumes(base)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func TestVolumeWriter(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 5)
+	tests := []struct {
+		name        string
+		data        []byte
+		volumeSize  int64
+		wantVolumes int
+	}{
+		{name: "multiple volumes", data: data, volumeSize: 16, wantVolumes: 4},
+		{name: "exact volumes", data: data, volumeSize: 25, wantVolumes: 2},
+		{name: "single volume", data: data, volumeSize: 100, wantVolumes: 1},
+		{name: "empty archive", data: nil, volumeSize: 16, wantVolumes: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := filepath.Join(t.TempDir(), "backup.tar")
+			completed := writeVolumes(t, base, tt.volumeSize, tt.data)
+			if len(completed) != tt.wantVolumes {
+				t.Fatalf("completed volumes = %v, want %d volumes", completed, tt.wantVolumes)
+			}
+			// no staging directory is left
+			if entries, _ := os.ReadDir(filepath.Dir(base)); len(entries) != tt.wantVolumes+1 {
+				t.Errorf("files written = %v, want the volumes and index", entries)
+			}
+			index, err := ReadVolumeIndex(base)
+			if err != nil {
+				t.Fatalf("ReadVolumeIndex() error = %v", err)
+			}
+			if index.Size != int64(len(tt.data)) || index.VolumeSize != tt.volumeSize || len(index.Volumes) != tt.wantVolumes {
+				t.Fatalf("ReadVolumeIndex() = %+v", index)
+			}
+			for i, v := range index.Volumes {
+				if v.Name != filepath.Base(VolumePath(base, i+1)) || v.Size > tt.volumeSize {
+					t.Errorf("volume[%d] = %+v", i, v)
+				}
+			}
+			got, err := readVolumes(base)
+			if err != nil {
+				t.Fatalf("failed to read volumes: %v", err)
+			}
+			if !bytes.Equal(got, tt.data) {
+				t.Errorf("read volumes = %q, want %q", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestVolumeWriter_staleVolumes(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "backup.tar")
+	writeVolumes(t, base, 10, bytes.Repeat([]byte("a"), 35))
+	writeVolumes(t, base, 10, bytes.Repeat([]byte("b"), 15))
+	if _, err := os.Stat(VolumePath(base, 3)); !os.IsNotExist(err) {
+		t.Errorf("stale volume is not removed: %v", err)
+	}
+	if err := os.Remove(VolumeIndexPath(base)); err != nil {
+		t.Fatal(err)
+	}
+	// volumes are discovered without the index
+	got, err := readVolumes(base)
+	if err != nil {
+		t.Fatalf("failed to read volumes: %v", err)
+	}
+	if want := bytes.Repeat([]byte("b"), 15); !bytes.Equal(got, want) {
+		t.Errorf("read volumes = %q, want %q", got, want)
+	}
+}
+
+func TestVolumeWriter_Abort(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "backup.tar")
+	previous := bytes.Repeat([]byte("p"), 15)
+	writeVolumes(t, base, 10, previous)
+	w, err := NewVolumeWriter(base, 10)
+	if err != nil {
+		t.Fatalf("NewVolumeWriter() error = %v", err)
+	}
+	if _, err := w.Write(bytes.Repeat([]byte("a"), 15)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Abort(); err != nil {
+		t.Fatalf("Abort() error = %v", err)
+	}
+	// the previous archive is kept
+	if entries, _ := os.ReadDir(dir); len(entries) != 3 {
+		t.Errorf("files left after Abort() = %v, want the previous volumes and index", entries)
+	}
+	got, err := readVolumes(base)
+	if err != nil {
+		t.Fatalf("failed to read volumes: %v", err)
+	}
+	if !bytes.Equal(got, previous) {
+		t.Errorf("read volumes = %q, want %q", got, previous)
+	}
+	if _, err := w.Write([]byte("a")); err == nil {
+		t.Error("Write() error = nil after Abort()")
+	}
+}
+
+func TestNewVolumeWriter_invalidSize(t *testing.T) {
+	if _, err := NewVolumeWriter("backup.tar", 0); err == nil {
+		t.Error("NewVolumeWriter() error = nil, want error for zero volume size")
+	}
+}
+
+func TestVolumeReader_corrupted(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(base string) error
+	}{
+		{
+			name: "modified volume",
+			corrupt: func(base string) error {
+				return os.WriteFile(VolumePath(base, 2), bytes.Repeat([]byte("b"), 10), 0644)
+			},
+		},
+		{
+			name: "truncated volume",
+			corrupt: func(base string) error {
+				return os.Truncate(VolumePath(base, 2), 5)
+			},
+		},
+		{
+			name: "extended volume",
+			corrupt: func(base string) error {
+				return os.WriteFile(VolumePath(base, 3), bytes.Repeat([]byte("a"), 11), 0644)
+			},
+		},
+		{
+			name: "missing volume",
+			corrupt: func(base string) error {
+				return os.Remove(VolumePath(base, 2))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := filepath.Join(t.TempDir(), "backup.tar")
+			writeVolumes(t, base, 10, bytes.Repeat([]byte("a"), 30))
+			if err := tt.corrupt(base); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := readVolumes(base); err == nil {
+				t.Error("failed to detect the corrupted volume")
+			}
+		})
+	}
+}
+
+func TestOpenVolumes_notFound(t *testing.T) {
+	if _, err := OpenVolumes(filepath.Join(t.TempDir(), "backup.tar")); err == nil {
+		t.Error("OpenVolumes() error = nil, want error for missing volumes")
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package contentutil
+
+import (
+	"context"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+// FetchRange fetches length bytes of the content of desc from offset.
+// The content is read at offset if the fetched reader supports ReadAt or
+// Seek, or the bytes before offset are skipped otherwise.
+func FetchRange(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor, offset int64, length int64) (io.ReadCloser, error) {
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	return ReadRange(rc, offset, length)
+}
+
+// ReadRange returns a reader of length bytes of rc from offset, which closes
+// rc when closed. rc is closed if the range cannot be read.
+func ReadRange(rc io.ReadCloser, offset int64, length int64) (io.ReadCloser, error) {
+	var r io.Reader
+	switch f := rc.(type) {
+	case io.ReaderAt:
+		r = io.NewSectionReader(f, offset, length)
+	case io.Seeker:
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			_ = rc.Close()
+			return nil, err
+		}
+		r = io.LimitReader(rc, length)
+	default:
+		if _, err := io.CopyN(io.Discard, rc, offset); err != nil {
+			_ = rc.Close()
+			return nil, err
+		}
+		r = io.LimitReader(rc, length)
+	}
+	return &rangeReadCloser{Reader: r, Closer: rc}, nil
+}
+
+// rangeReadCloser reads a range of the content and closes the underlying
+// reader.
+type rangeReadCloser struct {
+	io.Reader
+	io.Closer
+}
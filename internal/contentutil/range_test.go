@@ -0,0 +1,77 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package contentutil
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+type testFetcher struct {
+	open func() (io.ReadCloser, error)
+}
+
+func (f *testFetcher) Fetch(_ context.Context, _ ocispec.Descriptor) (io.ReadCloser, error) {
+	return f.open()
+}
+
+func TestFetchRange(t *testing.T) {
+	blob := []byte("hello world")
+	desc := ocispec.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	path := filepath.Join(t.TempDir(), "blob")
+	if err := os.WriteFile(path, blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		open func() (io.ReadCloser, error)
+	}{
+		{
+			name: "reader at",
+			open: func() (io.ReadCloser, error) { return os.Open(path) },
+		},
+		{
+			name: "reader only",
+			open: func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(blob)), nil },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, err := FetchRange(context.Background(), &testFetcher{open: tt.open}, desc, 6, 3)
+			if err != nil {
+				t.Fatalf("FetchRange() error = %v", err)
+			}
+			defer func() { _ = rc.Close() }()
+			got, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "wor"; string(got) != want {
+				t.Errorf("FetchRange() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package contentutil
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	orasio "oras.land/oras/internal/io"
+)
+
+// WalkLayer streams the tar layer described by desc from fetcher and calls fn
+// for the header of each entry, without storing the layer. The layer is read
+// to the end and verified against desc after all entries are walked.
+func WalkLayer(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor, fn func(hdr *tar.Header) error) (walkErr error) {
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// the cached content is committed on close
+		if err := rc.Close(); walkErr == nil {
+			walkErr = err
+		}
+	}()
+	vr := content.NewVerifyReader(rc, desc)
+	if err := orasio.WalkTar(vr, fn); err != nil {
+		return err
+	}
+	// drain the trailing content, such as the padding of the tar archive
+	if _, err := io.Copy(io.Discard, vr); err != nil {
+		return err
+	}
+	return vr.Verify()
+}
@@ -0,0 +1,70 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package contentutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestWalkLayer(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "foo", Typeflag: tar.TypeReg, Mode: 0644}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	layer := buf.Bytes()
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    digest.FromBytes(layer),
+		Size:      int64(len(layer)),
+	}
+	ctx := context.Background()
+	store := memory.New()
+	if err := store.Push(ctx, desc, bytes.NewReader(layer)); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	if err := WalkLayer(ctx, store, desc, func(hdr *tar.Header) error {
+		got = append(got, hdr.Name)
+		return nil
+	}); err != nil {
+		t.Fatalf("WalkLayer() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != "foo" {
+		t.Errorf("WalkLayer() entries = %v, want [foo]", got)
+	}
+
+	// the layer does not match the descriptor
+	tampered := desc
+	tampered.Digest = digest.FromString("tampered")
+	fetcher := &testFetcher{open: func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(layer)), nil
+	}}
+	if err := WalkLayer(ctx, fetcher, tampered, func(*tar.Header) error { return nil }); err == nil {
+		t.Error("WalkLayer() error = nil, want verification error")
+	}
+}
@@ -0,0 +1,389 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diff compares manifests, indexes and their configs.
+package diff
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/internal/platform"
+)
+
+// Kinds of changes.
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// maxConfigSize is the maximum size of a config to be compared property by
+// property.
+const maxConfigSize = 4 * 1024 * 1024
+
+// ValueChange is a change of a named value, such as a manifest field, an
+// annotation or a config property.
+type ValueChange struct {
+	Name   string `json:"name"`
+	Change string `json:"change"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// DescriptorChange is a change of a descriptor identified by a key, such as a
+// layer identified by its title or a manifest identified by its platform.
+type DescriptorChange struct {
+	Key    string              `json:"key"`
+	Change string              `json:"change"`
+	From   *ocispec.Descriptor `json:"from,omitempty"`
+	To     *ocispec.Descriptor `json:"to,omitempty"`
+	// Diff is the difference between the modified manifests, if compared.
+	Diff *Result `json:"diff,omitempty"`
+}
+
+// Result is the difference between two manifests.
+type Result struct {
+	// Fields are the changes of the media type, the artifact type, the
+	// subject and the config descriptor.
+	Fields []ValueChange `json:"fields,omitempty"`
+	// Annotations are the changes of the manifest annotations.
+	Annotations []ValueChange `json:"annotations,omitempty"`
+	// Config are the changes of the config properties, in the form of
+	// compact JSON values keyed by dotted paths, if the configs are JSON.
+	Config []ValueChange `json:"config,omitempty"`
+	// Layers are the changes of the layers, matched by title.
+	Layers []DescriptorChange `json:"layers,omitempty"`
+	// Manifests are the changes of the manifests of an index, matched by
+	// platform.
+	Manifests []DescriptorChange `json:"manifests,omitempty"`
+	// Referrers are the changes of the referrers, matched by artifact type.
+	Referrers []DescriptorChange `json:"referrers,omitempty"`
+}
+
+// Identical returns true if no difference is found.
+func (r *Result) Identical() bool {
+	return len(r.Fields) == 0 && len(r.Annotations) == 0 && len(r.Config) == 0 &&
+		len(r.Layers) == 0 && len(r.Manifests) == 0 && len(r.Referrers) == 0
+}
+
+// Options configures the comparison.
+type Options struct {
+	// Recursive compares the modified manifests of indexes.
+	Recursive bool
+	// Referrers compares the direct referrers of the manifests. Matching
+	// referrers are compared without their own referrers.
+	Referrers bool
+}
+
+// manifest contains the comparable fields of manifests and indexes.
+type manifest struct {
+	MediaType    string               `json:"mediaType"`
+	ArtifactType string               `json:"artifactType"`
+	Config       *ocispec.Descriptor  `json:"config"`
+	Layers       []ocispec.Descriptor `json:"layers"`
+	Manifests    []ocispec.Descriptor `json:"manifests"`
+	Subject      *ocispec.Descriptor  `json:"subject"`
+	Annotations  map[string]string    `json:"annotations"`
+}
+
+// Compare compares the manifest from in src with the manifest to in dst.
+func Compare(ctx context.Context, src oras.ReadOnlyGraphTarget, from ocispec.Descriptor, dst oras.ReadOnlyGraphTarget, to ocispec.Descriptor, opts Options) (*Result, error) {
+	fromManifest, err := fetchManifest(ctx, src, from)
+	if err != nil {
+		return nil, err
+	}
+	toManifest, err := fetchManifest(ctx, dst, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	fromMediaType := cmp.Or(from.MediaType, fromManifest.MediaType)
+	toMediaType := cmp.Or(to.MediaType, toManifest.MediaType)
+	result.Fields = compareValues(
+		fields(fromMediaType, fromManifest),
+		fields(toMediaType, toManifest),
+		[]string{"mediaType", "artifactType", "subject", "config.mediaType", "config.digest"},
+	)
+	result.Annotations = compareValues(fromManifest.Annotations, toManifest.Annotations, nil)
+	if result.Config, err = compareConfigs(ctx, src, fromManifest.Config, dst, toManifest.Config); err != nil {
+		return nil, err
+	}
+	result.Layers = compareDescriptors(keyDescriptors(fromManifest.Layers, layerKey), keyDescriptors(toManifest.Layers, layerKey))
+
+	result.Manifests = compareDescriptors(keyDescriptors(fromManifest.Manifests, manifestKey), keyDescriptors(toManifest.Manifests, manifestKey))
+	if opts.Recursive {
+		for i, change := range result.Manifests {
+			if change.Change != ChangeModified {
+				continue
+			}
+			if result.Manifests[i].Diff, err = Compare(ctx, src, *change.From, dst, *change.To, opts); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if opts.Referrers {
+		if result.Referrers, err = compareReferrers(ctx, src, from, dst, to); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// fetchManifest fetches and parses a manifest or an index.
+func fetchManifest(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) (*manifest, error) {
+	manifestBytes, err := content.FetchAll(ctx, fetcher, desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", desc.Digest, err)
+	}
+	var m manifest
+	if err := json.Unmarshal(manifestBytes, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", desc.Digest, err)
+	}
+	return &m, nil
+}
+
+// fields returns the comparable fields of a manifest.
+func fields(mediaType string, m *manifest) map[string]string {
+	values := map[string]string{
+		"mediaType": mediaType,
+	}
+	if m.ArtifactType != "" {
+		values["artifactType"] = m.ArtifactType
+	}
+	if m.Subject != nil {
+		values["subject"] = m.Subject.Digest.String()
+	}
+	if m.Config != nil {
+		values["config.mediaType"] = m.Config.MediaType
+		values["config.digest"] = m.Config.Digest.String()
+	}
+	return values
+}
+
+// compareValues compares the named values. The changes are ordered as the
+// names if given, or by name otherwise.
+func compareValues(from, to map[string]string, names []string) []ValueChange {
+	if names == nil {
+		names = slices.Sorted(maps.Keys(from))
+		for name := range to {
+			if _, ok := from[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+	}
+	var changes []ValueChange
+	for _, name := range names {
+		fromValue, inFrom := from[name]
+		toValue, inTo := to[name]
+		switch {
+		case inFrom && !inTo:
+			changes = append(changes, ValueChange{Name: name, Change: ChangeRemoved, From: fromValue})
+		case !inFrom && inTo:
+			changes = append(changes, ValueChange{Name: name, Change: ChangeAdded, To: toValue})
+		case inFrom && inTo && fromValue != toValue:
+			changes = append(changes, ValueChange{Name: name, Change: ChangeModified, From: fromValue, To: toValue})
+		}
+	}
+	return changes
+}
+
+// compareConfigs compares the properties of two JSON configs. Configs which
+// are identical, missing, too large or not JSON are not compared.
+func compareConfigs(ctx context.Context, src content.Fetcher, from *ocispec.Descriptor, dst content.Fetcher, to *ocispec.Descriptor) ([]ValueChange, error) {
+	if from == nil || to == nil || from.Digest == to.Digest {
+		return nil, nil
+	}
+	if !isJSON(from.MediaType) || !isJSON(to.MediaType) || from.Size > maxConfigSize || to.Size > maxConfigSize {
+		return nil, nil
+	}
+	fromValues, err := fetchConfig(ctx, src, *from)
+	if err != nil {
+		return nil, err
+	}
+	toValues, err := fetchConfig(ctx, dst, *to)
+	if err != nil {
+		return nil, err
+	}
+	if fromValues == nil || toValues == nil {
+		return nil, nil
+	}
+	return compareValues(fromValues, toValues, nil), nil
+}
+
+// isJSON returns true if the media type is JSON.
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// fetchConfig fetches a JSON config and flattens it into compact JSON values
+// keyed by dotted paths. A nil map is returned if the config is not valid
+// JSON.
+func fetchConfig(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) (map[string]string, error) {
+	configBytes, err := content.FetchAll(ctx, fetcher, desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config %s: %w", desc.Digest, err)
+	}
+	var config any
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return nil, nil
+	}
+	values := make(map[string]string)
+	if err := flatten("", config, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// flatten flattens the objects in a JSON value into values keyed by dotted
+// paths. Arrays and scalars are kept as compact JSON values.
+func flatten(path string, value any, values map[string]string) error {
+	if object, ok := value.(map[string]any); ok && len(object) > 0 {
+		for key, child := range object {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if err := flatten(childPath, child, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	values[path] = string(valueBytes)
+	return nil
+}
+
+// keyedDescriptor is a descriptor identified by a key.
+type keyedDescriptor struct {
+	key  string
+	desc ocispec.Descriptor
+}
+
+// keyDescriptors identifies the descriptors by keys. Duplicated keys are
+// suffixed with their occurrences, such as "key#2".
+func keyDescriptors(descs []ocispec.Descriptor, keyOf func(i int, desc ocispec.Descriptor) string) []keyedDescriptor {
+	keyed := make([]keyedDescriptor, 0, len(descs))
+	occurrences := make(map[string]int)
+	for i, desc := range descs {
+		key := keyOf(i, desc)
+		occurrences[key]++
+		if n := occurrences[key]; n > 1 {
+			key = fmt.Sprintf("%s#%d", key, n)
+		}
+		keyed = append(keyed, keyedDescriptor{key: key, desc: desc})
+	}
+	return keyed
+}
+
+// layerKey identifies a layer by its title, or by its position if untitled.
+func layerKey(i int, desc ocispec.Descriptor) string {
+	if title := desc.Annotations[ocispec.AnnotationTitle]; title != "" {
+		return title
+	}
+	return fmt.Sprintf("#%d", i)
+}
+
+// manifestKey identifies a manifest of an index by its platform, or by its
+// position if the platform is not specified.
+func manifestKey(i int, desc ocispec.Descriptor) string {
+	if desc.Platform != nil {
+		return platform.String(desc.Platform)
+	}
+	return fmt.Sprintf("#%d", i)
+}
+
+// compareDescriptors compares the descriptors matched by keys. Removed
+// descriptors are ordered first as in from, followed by the modified and
+// added ones as in to.
+func compareDescriptors(from, to []keyedDescriptor) []DescriptorChange {
+	toByKey := make(map[string]ocispec.Descriptor, len(to))
+	for _, kd := range to {
+		toByKey[kd.key] = kd.desc
+	}
+	fromByKey := make(map[string]ocispec.Descriptor, len(from))
+	var changes []DescriptorChange
+	for _, kd := range from {
+		fromByKey[kd.key] = kd.desc
+		if _, ok := toByKey[kd.key]; !ok {
+			changes = append(changes, DescriptorChange{Key: kd.key, Change: ChangeRemoved, From: &kd.desc})
+		}
+	}
+	for _, kd := range to {
+		fromDesc, ok := fromByKey[kd.key]
+		switch {
+		case !ok:
+			changes = append(changes, DescriptorChange{Key: kd.key, Change: ChangeAdded, To: &kd.desc})
+		case fromDesc.Digest != kd.desc.Digest || fromDesc.MediaType != kd.desc.MediaType:
+			changes = append(changes, DescriptorChange{Key: kd.key, Change: ChangeModified, From: &fromDesc, To: &kd.desc})
+		}
+	}
+	return changes
+}
+
+// compareReferrers compares the direct referrers of two manifests, matched by
+// artifact type in the order of creation. Matching referrers are compared
+// without their own referrers.
+func compareReferrers(ctx context.Context, src oras.ReadOnlyGraphTarget, from ocispec.Descriptor, dst oras.ReadOnlyGraphTarget, to ocispec.Descriptor) ([]DescriptorChange, error) {
+	fromReferrers, err := registry.Referrers(ctx, src, from, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find referrers of %s: %w", from.Digest, err)
+	}
+	toReferrers, err := registry.Referrers(ctx, dst, to, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find referrers of %s: %w", to.Digest, err)
+	}
+	changes := compareDescriptors(keyReferrers(fromReferrers), keyReferrers(toReferrers))
+	for i, change := range changes {
+		if change.Change != ChangeModified {
+			continue
+		}
+		if changes[i].Diff, err = Compare(ctx, src, *change.From, dst, *change.To, Options{}); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// keyReferrers identifies the referrers by artifact type, ordered by the
+// created annotation.
+func keyReferrers(referrers []ocispec.Descriptor) []keyedDescriptor {
+	referrers = slices.Clone(referrers)
+	slices.SortStableFunc(referrers, func(a, b ocispec.Descriptor) int {
+		return cmp.Or(
+			cmp.Compare(a.ArtifactType, b.ArtifactType),
+			cmp.Compare(a.Annotations[ocispec.AnnotationCreated], b.Annotations[ocispec.AnnotationCreated]),
+		)
+	})
+	return keyDescriptors(referrers, func(_ int, desc ocispec.Descriptor) string {
+		return cmp.Or(desc.ArtifactType, "<unknown>")
+	})
+}
@@ -0,0 +1,244 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+type testStore struct {
+	*memory.Store
+	t *testing.T
+}
+
+func (s testStore) push(mediaType string, v any) ocispec.Descriptor {
+	s.t.Helper()
+	b, ok := v.([]byte)
+	if !ok {
+		var err error
+		if b, err = json.Marshal(v); err != nil {
+			s.t.Fatalf("failed to marshal: %v", err)
+		}
+	}
+	desc := content.NewDescriptorFromBytes(mediaType, b)
+	if err := s.Push(context.Background(), desc, bytes.NewReader(b)); err != nil {
+		s.t.Fatalf("failed to push: %v", err)
+	}
+	return desc
+}
+
+func (s testStore) layer(title, data string) ocispec.Descriptor {
+	desc := s.push("test/layer", []byte(data))
+	if title != "" {
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: title}
+	}
+	return desc
+}
+
+func (s testStore) manifest(artifactType string, config ocispec.Descriptor, layers []ocispec.Descriptor, annotations map[string]string, subject *ocispec.Descriptor) ocispec.Descriptor {
+	return s.push(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: artifactType,
+		Config:       config,
+		Layers:       layers,
+		Subject:      subject,
+		Annotations:  annotations,
+	})
+}
+
+func (s testStore) index(manifests ...ocispec.Descriptor) ocispec.Descriptor {
+	return s.push(ocispec.MediaTypeImageIndex, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: manifests,
+	})
+}
+
+func withPlatform(desc ocispec.Descriptor, os, arch string) ocispec.Descriptor {
+	desc.Platform = &ocispec.Platform{OS: os, Architecture: arch}
+	return desc
+}
+
+func TestCompare_manifest(t *testing.T) {
+	s := testStore{memory.New(), t}
+	configV1 := s.push(ocispec.MediaTypeImageConfig, map[string]any{
+		"architecture": "amd64",
+		"config":       map[string]any{"Env": []string{"A=1"}, "User": "root"},
+	})
+	configV2 := s.push(ocispec.MediaTypeImageConfig, map[string]any{
+		"architecture": "arm64",
+		"config":       map[string]any{"Env": []string{"A=1"}, "WorkingDir": "/app"},
+	})
+	kept := s.layer("kept.txt", "kept")
+	removed := s.layer("removed.txt", "removed")
+	modifiedV1 := s.layer("modified.txt", "v1")
+	modifiedV2 := s.layer("modified.txt", "v2")
+	added := s.layer("added.txt", "added")
+	v1 := s.manifest("", configV1, []ocispec.Descriptor{kept, removed, modifiedV1}, map[string]string{"k": "1", "gone": "x"}, nil)
+	v2 := s.manifest("test/app", configV2, []ocispec.Descriptor{kept, modifiedV2, added}, map[string]string{"k": "2", "new": "y"}, nil)
+
+	got, err := Compare(context.Background(), s, v1, s, v2, Options{})
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	want := &Result{
+		Fields: []ValueChange{
+			{Name: "artifactType", Change: ChangeAdded, To: "test/app"},
+			{Name: "config.digest", Change: ChangeModified, From: configV1.Digest.String(), To: configV2.Digest.String()},
+		},
+		Annotations: []ValueChange{
+			{Name: "gone", Change: ChangeRemoved, From: "x"},
+			{Name: "k", Change: ChangeModified, From: "1", To: "2"},
+			{Name: "new", Change: ChangeAdded, To: "y"},
+		},
+		Config: []ValueChange{
+			{Name: "architecture", Change: ChangeModified, From: `"amd64"`, To: `"arm64"`},
+			{Name: "config.User", Change: ChangeRemoved, From: `"root"`},
+			{Name: "config.WorkingDir", Change: ChangeAdded, To: `"/app"`},
+		},
+		Layers: []DescriptorChange{
+			{Key: "removed.txt", Change: ChangeRemoved, From: &removed},
+			{Key: "modified.txt", Change: ChangeModified, From: &modifiedV1, To: &modifiedV2},
+			{Key: "added.txt", Change: ChangeAdded, To: &added},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() = %+v, want %+v", got, want)
+	}
+	if got.Identical() {
+		t.Error("Identical() = true, want false")
+	}
+
+	identical, err := Compare(context.Background(), s, v1, s, v1, Options{Referrers: true})
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if !identical.Identical() {
+		t.Errorf("Identical() = false for the same manifest: %+v", identical)
+	}
+}
+
+func TestCompare_index(t *testing.T) {
+	s := testStore{memory.New(), t}
+	config := s.push(ocispec.MediaTypeImageConfig, map[string]any{})
+	amd64V1 := withPlatform(s.manifest("", config, []ocispec.Descriptor{s.layer("", "amd64 v1")}, nil, nil), "linux", "amd64")
+	amd64V2 := withPlatform(s.manifest("", config, []ocispec.Descriptor{s.layer("", "amd64 v2")}, nil, nil), "linux", "amd64")
+	arm64 := withPlatform(s.manifest("", config, []ocispec.Descriptor{s.layer("", "arm64")}, nil, nil), "linux", "arm64")
+	s390x := withPlatform(s.manifest("", config, []ocispec.Descriptor{s.layer("", "s390x")}, nil, nil), "linux", "s390x")
+	v1 := s.index(amd64V1, arm64)
+	v2 := s.index(amd64V2, s390x)
+
+	t.Run("platforms", func(t *testing.T) {
+		got, err := Compare(context.Background(), s, v1, s, v2, Options{})
+		if err != nil {
+			t.Fatalf("Compare() error = %v", err)
+		}
+		want := []DescriptorChange{
+			{Key: "linux/arm64", Change: ChangeRemoved, From: &arm64},
+			{Key: "linux/amd64", Change: ChangeModified, From: &amd64V1, To: &amd64V2},
+			{Key: "linux/s390x", Change: ChangeAdded, To: &s390x},
+		}
+		if !reflect.DeepEqual(got.Manifests, want) {
+			t.Errorf("Compare() manifests = %+v, want %+v", got.Manifests, want)
+		}
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		got, err := Compare(context.Background(), s, v1, s, v2, Options{Recursive: true})
+		if err != nil {
+			t.Fatalf("Compare() error = %v", err)
+		}
+		child := got.Manifests[1].Diff
+		if child == nil {
+			t.Fatal("Compare() did not compare the modified manifest")
+		}
+		if len(child.Layers) != 1 || child.Layers[0].Key != "#0" || child.Layers[0].Change != ChangeModified {
+			t.Errorf("Compare() child layers = %+v, want the modified layer #0", child.Layers)
+		}
+		if got.Manifests[0].Diff != nil || got.Manifests[2].Diff != nil {
+			t.Error("Compare() compared added or removed manifests")
+		}
+	})
+}
+
+func TestCompare_referrers(t *testing.T) {
+	ctx := context.Background()
+	s := testStore{memory.New(), t}
+	pack := func(artifactType string, subject *ocispec.Descriptor, annotations map[string]string) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, s, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{
+			Subject:             subject,
+			ManifestAnnotations: annotations,
+		})
+		if err != nil {
+			t.Fatalf("failed to pack manifest: %v", err)
+		}
+		return desc
+	}
+	v1 := pack("test/app", nil, map[string]string{ocispec.AnnotationCreated: "2025-01-01T00:00:00Z"})
+	v2 := pack("test/app", nil, map[string]string{ocispec.AnnotationCreated: "2025-02-01T00:00:00Z"})
+	sbomV1 := pack("test/sbom", &v1, map[string]string{ocispec.AnnotationCreated: "2025-01-01T00:00:00Z", "version": "1"})
+	sigV1 := pack("test/sig", &v1, map[string]string{ocispec.AnnotationCreated: "2025-01-01T00:00:00Z"})
+	sbomV2 := pack("test/sbom", &v2, map[string]string{ocispec.AnnotationCreated: "2025-02-01T00:00:00Z", "version": "2"})
+
+	got, err := Compare(ctx, s, v1, s, v2, Options{Referrers: true})
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if len(got.Referrers) != 2 {
+		t.Fatalf("Compare() referrers = %+v, want 2 changes", got.Referrers)
+	}
+	if removed := got.Referrers[0]; removed.Key != "test/sig" || removed.Change != ChangeRemoved || removed.From.Digest != sigV1.Digest {
+		t.Errorf("Compare() referrers[0] = %+v, want test/sig removed", removed)
+	}
+	modified := got.Referrers[1]
+	if modified.Key != "test/sbom" || modified.Change != ChangeModified || modified.From.Digest != sbomV1.Digest || modified.To.Digest != sbomV2.Digest {
+		t.Fatalf("Compare() referrers[1] = %+v, want test/sbom modified", modified)
+	}
+	wantAnnotations := []ValueChange{
+		{Name: ocispec.AnnotationCreated, Change: ChangeModified, From: "2025-01-01T00:00:00Z", To: "2025-02-01T00:00:00Z"},
+		{Name: "version", Change: ChangeModified, From: "1", To: "2"},
+	}
+	if modified.Diff == nil || !reflect.DeepEqual(modified.Diff.Annotations, wantAnnotations) {
+		t.Errorf("Compare() referrer diff = %+v, want annotations %+v", modified.Diff, wantAnnotations)
+	}
+}
+
+func Test_keyDescriptors(t *testing.T) {
+	descs := []ocispec.Descriptor{
+		{Annotations: map[string]string{ocispec.AnnotationTitle: "a"}},
+		{},
+		{Annotations: map[string]string{ocispec.AnnotationTitle: "a"}},
+	}
+	var got []string
+	for _, kd := range keyDescriptors(descs, layerKey) {
+		got = append(got, kd.key)
+	}
+	if want := []string{"a", "#1", "a#2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("keyDescriptors() = %v, want %v", got, want)
+	}
+}
@@ -105,6 +105,23 @@ func Successors(ctx context.Context, fetcher content.Fetcher, node ocispec.Descr
 	return
 }
 
+// IndexChildren returns the manifests of root if it is an image index or a
+// docker manifest list, or nil otherwise.
+func IndexChildren(ctx context.Context, fetcher content.Fetcher, root ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	if root.MediaType != ocispec.MediaTypeImageIndex && root.MediaType != docker.MediaTypeManifestList {
+		return nil, nil
+	}
+	indexBytes, err := content.FetchAll(ctx, fetcher, root)
+	if err != nil {
+		return nil, err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return nil, err
+	}
+	return index.Manifests, nil
+}
+
 // FindPredecessors returns all predecessors of descs in src concurrently.
 func FindPredecessors(ctx context.Context, src oras.ReadOnlyGraphTarget, descs []ocispec.Descriptor, opts oras.ExtendedCopyGraphOptions) ([]ocispec.Descriptor, error) {
 	var predecessors []ocispec.Descriptor
@@ -76,6 +76,32 @@ func TestSuccessors(t *testing.T) {
 	}
 }
 
+func TestIndexChildren(t *testing.T) {
+	mockFetcher := testutils.NewMockFetcher()
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		root    ocispec.Descriptor
+		want    []ocispec.Descriptor
+		wantErr bool
+	}{
+		{"should get manifests of an index", mockFetcher.Index, []ocispec.Descriptor{mockFetcher.Subject}, false},
+		{"should get nothing for an image", mockFetcher.OciImage, nil, false},
+		{"should failed to get non-existent index", ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IndexChildren(ctx, mockFetcher.Fetcher, tt.root)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IndexChildren() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IndexChildren() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDescriptor_GetSuccessors(t *testing.T) {
 	mockFetcher := testutils.NewMockFetcher()
 
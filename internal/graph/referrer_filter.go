@@ -0,0 +1,190 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// SortByCreated sorts referrers from the latest to the oldest, according
+	// to the created annotation.
+	SortByCreated = "created"
+	// SortByArtifactType sorts referrers by artifact type.
+	SortByArtifactType = "artifactType"
+)
+
+// AnnotationMatcher matches the value of an annotation.
+type AnnotationMatcher struct {
+	Key string
+	// Value is the expected value of the annotation if Pattern is nil.
+	Value string
+	// Pattern matches the value of the annotation if set.
+	Pattern *regexp.Regexp
+}
+
+// ParseAnnotationMatcher parses an annotation matcher in the form of
+// key=value, or key~=regex for a regular expression.
+func ParseAnnotationMatcher(s string) (AnnotationMatcher, error) {
+	i := strings.IndexByte(s, '=')
+	if i < 0 {
+		return AnnotationMatcher{}, fmt.Errorf("annotation filter %q is not in the form of key=value or key~=regex", s)
+	}
+	key, value := s[:i], s[i+1:]
+	var pattern *regexp.Regexp
+	if k, ok := strings.CutSuffix(key, "~"); ok {
+		var err error
+		if pattern, err = regexp.Compile(value); err != nil {
+			return AnnotationMatcher{}, fmt.Errorf("invalid regular expression in annotation filter %q: %w", s, err)
+		}
+		key = k
+	}
+	if key == "" {
+		return AnnotationMatcher{}, fmt.Errorf("empty annotation key in annotation filter %q", s)
+	}
+	return AnnotationMatcher{Key: key, Value: value, Pattern: pattern}, nil
+}
+
+// Match returns true if the annotations contain a matching annotation.
+func (m AnnotationMatcher) Match(annotations map[string]string) bool {
+	value, ok := annotations[m.Key]
+	if !ok {
+		return false
+	}
+	if m.Pattern != nil {
+		return m.Pattern.MatchString(value)
+	}
+	return value == m.Value
+}
+
+// ReferrerFilter selects and orders the referrers to discover.
+// A nil or zero ReferrerFilter selects all referrers in the original order.
+type ReferrerFilter struct {
+	// ArtifactTypes selects the referrers of any of the artifact types.
+	ArtifactTypes []string
+	// ExcludedArtifactTypes excludes the referrers of the artifact types.
+	ExcludedArtifactTypes []string
+	// Annotations selects the referrers matching all the matchers.
+	Annotations []AnnotationMatcher
+	// Latest selects at most the latest N referrers of each artifact type if
+	// positive, according to the created annotation.
+	Latest int
+	// Sort orders the selected referrers by SortByCreated or
+	// SortByArtifactType if set.
+	Sort string
+}
+
+// ArtifactType returns the artifact type which the referrers can be filtered
+// by on the server side, or an empty string if they cannot.
+func (f *ReferrerFilter) ArtifactType() string {
+	if f == nil || len(f.ArtifactTypes) != 1 {
+		return ""
+	}
+	return f.ArtifactTypes[0]
+}
+
+// Streamable returns true if the referrers can be selected page by page, that
+// is, the selection of a referrer does not depend on the other referrers.
+func (f *ReferrerFilter) Streamable() bool {
+	return f == nil || (f.Latest <= 0 && f.Sort == "")
+}
+
+// Apply returns the referrers selected by the filter, ordered as specified.
+func (f *ReferrerFilter) Apply(referrers []ocispec.Descriptor) []ocispec.Descriptor {
+	if f == nil {
+		return referrers
+	}
+	selected := slices.DeleteFunc(slices.Clone(referrers), func(referrer ocispec.Descriptor) bool {
+		return !f.match(referrer)
+	})
+
+	if f.Latest > 0 {
+		// keep the latest N referrers of each artifact type in order
+		order := make([]int, len(selected))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return compareCreated(selected[a], selected[b])
+		})
+		kept := make(map[string]int)
+		keep := make([]bool, len(selected))
+		for _, i := range order {
+			if artifactType := selected[i].ArtifactType; kept[artifactType] < f.Latest {
+				kept[artifactType]++
+				keep[i] = true
+			}
+		}
+		latest := make([]ocispec.Descriptor, 0, len(selected))
+		for i, referrer := range selected {
+			if keep[i] {
+				latest = append(latest, referrer)
+			}
+		}
+		selected = latest
+	}
+
+	switch f.Sort {
+	case SortByCreated:
+		slices.SortStableFunc(selected, compareCreated)
+	case SortByArtifactType:
+		slices.SortStableFunc(selected, func(a, b ocispec.Descriptor) int {
+			return cmp.Compare(a.ArtifactType, b.ArtifactType)
+		})
+	}
+	return selected
+}
+
+// match returns true if the referrer matches the artifact types and the
+// annotations of the filter.
+func (f *ReferrerFilter) match(referrer ocispec.Descriptor) bool {
+	if len(f.ArtifactTypes) > 0 && !slices.Contains(f.ArtifactTypes, referrer.ArtifactType) {
+		return false
+	}
+	if slices.Contains(f.ExcludedArtifactTypes, referrer.ArtifactType) {
+		return false
+	}
+	for _, m := range f.Annotations {
+		if !m.Match(referrer.Annotations) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareCreated orders descriptors from the latest to the oldest according
+// to the created annotation. Descriptors without a valid created annotation
+// are ordered last.
+func compareCreated(a, b ocispec.Descriptor) int {
+	return created(b).Compare(created(a))
+}
+
+// created returns the time in the created annotation of the descriptor, or a
+// zero time if the annotation is missing or invalid.
+func created(desc ocispec.Descriptor) time.Time {
+	t, err := time.Parse(time.RFC3339, desc.Annotations[ocispec.AnnotationCreated])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
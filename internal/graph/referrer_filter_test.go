@@ -0,0 +1,197 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestParseAnnotationMatcher(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantKey     string
+		wantValue   string
+		wantPattern string
+		wantErr     bool
+	}{
+		{input: "key=value", wantKey: "key", wantValue: "value"},
+		{input: "key=", wantKey: "key"},
+		{input: "key=a=b", wantKey: "key", wantValue: "a=b"},
+		{input: "key~=^v[0-9]+", wantKey: "key", wantValue: "^v[0-9]+", wantPattern: "^v[0-9]+"},
+		{input: "key", wantErr: true},
+		{input: "=value", wantErr: true},
+		{input: "~=value", wantErr: true},
+		{input: "key~=[", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAnnotationMatcher(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAnnotationMatcher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Key != tt.wantKey || got.Value != tt.wantValue {
+				t.Errorf("ParseAnnotationMatcher() = %q=%q, want %q=%q", got.Key, got.Value, tt.wantKey, tt.wantValue)
+			}
+			var gotPattern string
+			if got.Pattern != nil {
+				gotPattern = got.Pattern.String()
+			}
+			if gotPattern != tt.wantPattern {
+				t.Errorf("ParseAnnotationMatcher() pattern = %q, want %q", gotPattern, tt.wantPattern)
+			}
+		})
+	}
+}
+
+func TestReferrerFilter_ArtifactType(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *ReferrerFilter
+		want   string
+	}{
+		{name: "nil filter"},
+		{name: "single artifact type", filter: &ReferrerFilter{ArtifactTypes: []string{"sig"}}, want: "sig"},
+		{name: "multiple artifact types", filter: &ReferrerFilter{ArtifactTypes: []string{"sig", "sbom"}}},
+		{name: "excluded artifact type", filter: &ReferrerFilter{ExcludedArtifactTypes: []string{"sig"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.ArtifactType(); got != tt.want {
+				t.Errorf("ReferrerFilter.ArtifactType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferrerFilter_Streamable(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *ReferrerFilter
+		want   bool
+	}{
+		{name: "nil filter", want: true},
+		{name: "annotations", filter: &ReferrerFilter{ArtifactTypes: []string{"sig"}, Annotations: []AnnotationMatcher{{Key: "k", Value: "v"}}}, want: true},
+		{name: "latest", filter: &ReferrerFilter{Latest: 1}},
+		{name: "sort", filter: &ReferrerFilter{Sort: SortByCreated}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Streamable(); got != tt.want {
+				t.Errorf("ReferrerFilter.Streamable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferrerFilter_Apply(t *testing.T) {
+	referrer := func(name, artifactType, created string, annotations ...string) ocispec.Descriptor {
+		desc := ocispec.Descriptor{
+			MediaType:    ocispec.MediaTypeImageManifest,
+			Digest:       digest.FromString(name),
+			ArtifactType: artifactType,
+			Annotations:  map[string]string{},
+		}
+		if created != "" {
+			desc.Annotations[ocispec.AnnotationCreated] = created
+		}
+		for i := 0; i+1 < len(annotations); i += 2 {
+			desc.Annotations[annotations[i]] = annotations[i+1]
+		}
+		return desc
+	}
+	sig1 := referrer("sig1", "sig", "2025-01-01T00:00:00Z", "signer", "alice")
+	sig2 := referrer("sig2", "sig", "2025-03-01T00:00:00Z", "signer", "bob")
+	sig3 := referrer("sig3", "sig", "2025-02-01T00:00:00Z", "signer", "alice")
+	sbom := referrer("sbom", "sbom", "2025-01-15T00:00:00Z", "version", "v1.2")
+	other := referrer("other", "other", "")
+	referrers := []ocispec.Descriptor{sig1, sig2, sig3, sbom, other}
+
+	tests := []struct {
+		name   string
+		filter *ReferrerFilter
+		want   []ocispec.Descriptor
+	}{
+		{name: "nil filter", want: referrers},
+		{name: "empty filter", filter: &ReferrerFilter{}, want: referrers},
+		{
+			name:   "artifact types",
+			filter: &ReferrerFilter{ArtifactTypes: []string{"sbom", "other"}},
+			want:   []ocispec.Descriptor{sbom, other},
+		},
+		{
+			name:   "excluded artifact types",
+			filter: &ReferrerFilter{ExcludedArtifactTypes: []string{"sig"}},
+			want:   []ocispec.Descriptor{sbom, other},
+		},
+		{
+			name:   "annotation value",
+			filter: &ReferrerFilter{Annotations: []AnnotationMatcher{{Key: "signer", Value: "alice"}}},
+			want:   []ocispec.Descriptor{sig1, sig3},
+		},
+		{
+			name:   "annotation pattern",
+			filter: &ReferrerFilter{Annotations: []AnnotationMatcher{{Key: "version", Pattern: regexp.MustCompile(`^v1\.`)}}},
+			want:   []ocispec.Descriptor{sbom},
+		},
+		{
+			name: "all annotations",
+			filter: &ReferrerFilter{Annotations: []AnnotationMatcher{
+				{Key: "signer", Value: "alice"},
+				{Key: ocispec.AnnotationCreated, Pattern: regexp.MustCompile(`^2025-02`)},
+			}},
+			want: []ocispec.Descriptor{sig3},
+		},
+		{
+			name:   "latest per artifact type",
+			filter: &ReferrerFilter{Latest: 2},
+			want:   []ocispec.Descriptor{sig2, sig3, sbom, other},
+		},
+		{
+			name:   "sort by created",
+			filter: &ReferrerFilter{Sort: SortByCreated},
+			want:   []ocispec.Descriptor{sig2, sig3, sbom, sig1, other},
+		},
+		{
+			name:   "sort by artifact type",
+			filter: &ReferrerFilter{Sort: SortByArtifactType},
+			want:   []ocispec.Descriptor{other, sbom, sig1, sig2, sig3},
+		},
+		{
+			name:   "latest sorted by created",
+			filter: &ReferrerFilter{ArtifactTypes: []string{"sig"}, Latest: 1, Sort: SortByCreated},
+			want:   []ocispec.Descriptor{sig2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.filter.Apply(referrers)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReferrerFilter.Apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if !reflect.DeepEqual(referrers, []ocispec.Descriptor{sig1, sig2, sig3, sbom, other}) {
+		t.Error("ReferrerFilter.Apply() modified the input referrers")
+	}
+}
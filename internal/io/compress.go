@@ -0,0 +1,172 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the compression algorithm of an archive.
+type Compression int
+
+const (
+	// CompressionNone indicates the archive is not compressed.
+	CompressionNone Compression = iota
+	// CompressionGzip indicates the archive is compressed with gzip.
+	CompressionGzip
+	// CompressionZstd indicates the archive is compressed with zstd.
+	CompressionZstd
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// String returns the name of the compression algorithm.
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionGzip:
+		return "gzip"
+	case CompressionZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("Compression(%d)", int(c))
+	}
+}
+
+// ParseCompression parses the name of a compression algorithm.
+func ParseCompression(name string) (Compression, error) {
+	switch strings.ToLower(name) {
+	case "none":
+		return CompressionNone, nil
+	case "gzip":
+		return CompressionGzip, nil
+	case "zstd":
+		return CompressionZstd, nil
+	default:
+		return CompressionNone, fmt.Errorf("unsupported compression %q, supported values are none, gzip and zstd", name)
+	}
+}
+
+// CompressionFromPath returns the compression algorithm indicated by the
+// extension of a tar archive path, and whether the path has an extension of a
+// tar archive.
+func CompressionFromPath(path string) (Compression, bool) {
+	path = strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(path, ".tar"):
+		return CompressionNone, true
+	case strings.HasSuffix(path, ".tar.gz"), strings.HasSuffix(path, ".tgz"):
+		return CompressionGzip, true
+	case strings.HasSuffix(path, ".tar.zst"), strings.HasSuffix(path, ".tzst"):
+		return CompressionZstd, true
+	default:
+		return CompressionNone, false
+	}
+}
+
+// DetectCompression detects the compression algorithm from the magic bytes at
+// the beginning of the content.
+func DetectCompression(magic []byte) Compression {
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return CompressionGzip
+	case bytes.HasPrefix(magic, zstdMagic):
+		return CompressionZstd
+	default:
+		return CompressionNone
+	}
+}
+
+// DetectFileCompression detects the compression algorithm of the file at path
+// from its magic bytes.
+func DetectFileCompression(path string) (Compression, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return CompressionNone, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	defer func() {
+		_ = fp.Close()
+	}()
+
+	magic := make([]byte, len(zstdMagic))
+	n, err := io.ReadFull(fp, magic)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return CompressionNone, fmt.Errorf("failed to read magic number from file %q: %w", path, err)
+	}
+	return DetectCompression(magic[:n]), nil
+}
+
+// NewCompressWriter returns a writer compressing the content written to w
+// with the compression algorithm c. Closing the returned writer flushes the
+// compressed content but does not close w.
+func NewCompressWriter(w io.Writer, c Compression) (io.WriteCloser, error) {
+	switch c {
+	case CompressionNone:
+		return nopWriteCloser{w}, nil
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported compression %v", c)
+	}
+}
+
+// NewDecompressReader returns a reader decompressing the content read from r,
+// with the compression algorithm detected from the magic bytes. The content
+// is returned as is if it is not compressed.
+func NewDecompressReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read magic bytes: %w", err)
+	}
+	switch DetectCompression(magic) {
+	case CompressionGzip:
+		return gzip.NewReader(br)
+	case CompressionZstd:
+		decoder, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	default:
+		return io.NopCloser(br), nil
+	}
+}
+
+// nopWriteCloser is a writer with a no-op Close method.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close implements io.Closer.
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	iotest "oras.land/oras/internal/io"
+)
+
+func TestParseCompression(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    iotest.Compression
+		wantErr bool
+	}{
+		{name: "none", want: iotest.CompressionNone},
+		{name: "gzip", want: iotest.CompressionGzip},
+		{name: "ZSTD", want: iotest.CompressionZstd},
+		{name: "bzip2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := iotest.ParseCompression(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCompression() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCompression() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompressionFromPath(t *testing.T) {
+	tests := []struct {
+		path      string
+		want      iotest.Compression
+		wantIsTar bool
+	}{
+		{path: "backup.tar", want: iotest.CompressionNone, wantIsTar: true},
+		{path: "backup.tar.gz", want: iotest.CompressionGzip, wantIsTar: true},
+		{path: "backup.TGZ", want: iotest.CompressionGzip, wantIsTar: true},
+		{path: "backup.tar.zst", want: iotest.CompressionZstd, wantIsTar: true},
+		{path: "backup.tzst", want: iotest.CompressionZstd, wantIsTar: true},
+		{path: "backup.gz", want: iotest.CompressionNone, wantIsTar: false},
+		{path: "backup", want: iotest.CompressionNone, wantIsTar: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, gotIsTar := iotest.CompressionFromPath(tt.path)
+			if got != tt.want || gotIsTar != tt.wantIsTar {
+				t.Errorf("CompressionFromPath() = (%v, %v), want (%v, %v)", got, gotIsTar, tt.want, tt.wantIsTar)
+			}
+		})
+	}
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	content := bytes.Repeat([]byte("hello world "), 1024)
+	for _, compression := range []iotest.Compression{iotest.CompressionNone, iotest.CompressionGzip, iotest.CompressionZstd} {
+		t.Run(compression.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := iotest.NewCompressWriter(&buf, compression)
+			if err != nil {
+				t.Fatalf("NewCompressWriter() error = %v", err)
+			}
+			if _, err := w.Write(content); err != nil {
+				t.Fatalf("failed to write content: %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("failed to close writer: %v", err)
+			}
+			if got := iotest.DetectCompression(buf.Bytes()); got != compression {
+				t.Errorf("DetectCompression() = %v, want %v", got, compression)
+			}
+
+			r, err := iotest.NewDecompressReader(&buf)
+			if err != nil {
+				t.Fatalf("NewDecompressReader() error = %v", err)
+			}
+			defer func() {
+				_ = r.Close()
+			}()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("failed to read content: %v", err)
+			}
+			if !bytes.Equal(got, content) {
+				t.Errorf("decompressed content mismatch: got %d bytes, want %d bytes", len(got), len(content))
+			}
+		})
+	}
+}
+
+func TestNewDecompressReader_ShortContent(t *testing.T) {
+	r, err := iotest.NewDecompressReader(bytes.NewReader([]byte("a")))
+	if err != nil {
+		t.Fatalf("NewDecompressReader() error = %v", err)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read content: %v", err)
+	}
+	if string(got) != "a" {
+		t.Errorf("NewDecompressReader() content = %q, want %q", got, "a")
+	}
+}
+
+func TestDetectFileCompression(t *testing.T) {
+	tmpDir := t.TempDir()
+	tests := []struct {
+		name    string
+		content []byte
+		want    iotest.Compression
+	}{
+		{name: "gzip", content: []byte{0x1f, 0x8b, 0x08, 0x00, 0x00}, want: iotest.CompressionGzip},
+		{name: "zstd", content: []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00}, want: iotest.CompressionZstd},
+		{name: "plain", content: []byte("plain content"), want: iotest.CompressionNone},
+		{name: "empty", content: nil, want: iotest.CompressionNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, tt.name)
+			if err := os.WriteFile(path, tt.content, 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			got, err := iotest.DetectFileCompression(path)
+			if err != nil {
+				t.Fatalf("DetectFileCompression() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectFileCompression() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("file not found", func(t *testing.T) {
+		if _, err := iotest.DetectFileCompression(filepath.Join(tmpDir, "missing")); err == nil {
+			t.Error("DetectFileCompression() expected error for missing file")
+		}
+	})
+}
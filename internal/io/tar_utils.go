@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 )
@@ -77,3 +78,131 @@ func IsTarFile(path string) (bool, error) {
 	}
 	return bytes.Equal(magic, []byte("ustar")), nil
 }
+
+// ExtractTarDirectory extracts the tar archive read from reader into
+// targetDir. Only directories and regular files are extracted, and entries
+// escaping targetDir are rejected.
+func ExtractTarDirectory(reader io.Reader, targetDir string) error {
+	root, err := os.OpenRoot(targetDir)
+	if err != nil {
+		return fmt.Errorf("failed to open target directory: %w", err)
+	}
+	defer func() {
+		_ = root.Close()
+	}()
+
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// drain the trailing content so that a decompressing reader
+				// verifies its checksum
+				if _, err := io.Copy(io.Discard, reader); err != nil {
+					return fmt.Errorf("failed to read tar archive: %w", err)
+				}
+				return nil
+			}
+			return fmt.Errorf("failed to read tar header: %w", err)
+		}
+		name := filepath.FromSlash(strings.TrimSuffix(header.Name, "/"))
+		if name == "." {
+			continue
+		}
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("invalid tar entry %q: path escapes the target directory", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := root.MkdirAll(name, 0755); err != nil {
+				return fmt.Errorf("failed to create directory %q: %w", name, err)
+			}
+		case tar.TypeReg:
+			if err := extractTarFile(root, name, tr); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported tar entry %q of type %q", header.Name, header.Typeflag)
+		}
+	}
+}
+
+// extractTarFile writes the content of the current tar entry to name under root.
+func extractTarFile(root *os.Root, name string, r io.Reader) (extractErr error) {
+	if dir := filepath.Dir(name); dir != "." {
+		if err := root.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %q: %w", dir, err)
+		}
+	}
+	fp, err := root.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create file %q: %w", name, err)
+	}
+	defer func() {
+		if err := fp.Close(); extractErr == nil {
+			extractErr = err
+		}
+	}()
+	if _, err := io.Copy(fp, r); err != nil {
+		return fmt.Errorf("failed to extract file %q: %w", name, err)
+	}
+	return nil
+}
+
+// ErrNotTarArchive is returned when the content is not a tar archive.
+var ErrNotTarArchive = errors.New("not a tar archive")
+
+// WalkTar reads the tar archive from r, decompressing it with the compression
+// algorithm detected from the magic bytes, and calls fn for the header of each
+// entry in the archive.
+func WalkTar(r io.Reader, fn func(hdr *tar.Header) error) error {
+	dr, err := NewDecompressReader(r)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dr.Close() }()
+
+	tr := tar.NewReader(dr)
+	for first := true; ; first = false {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if first && (errors.Is(err, tar.ErrHeader) || errors.Is(err, io.ErrUnexpectedEOF)) {
+				return fmt.Errorf("%w: %v", ErrNotTarArchive, err)
+			}
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+		if err := fn(hdr); err != nil {
+			return err
+		}
+	}
+}
+
+// MatchPath reports whether name matches any of the shell file name patterns.
+// A pattern containing a slash is matched against the whole name, and other
+// patterns are matched against the base name. All names match if no pattern
+// is provided.
+func MatchPath(name string, patterns []string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	name = strings.Trim(path.Clean("/"+name), "/")
+	for _, pattern := range patterns {
+		target := name
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(name)
+		} else {
+			pattern = strings.Trim(pattern, "/")
+		}
+		matched, err := path.Match(pattern, target)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -18,9 +18,12 @@ package io_test
 import (
 	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	iotest "oras.land/oras/internal/io"
@@ -249,3 +252,225 @@ func TestIsTarFile(t *testing.T) {
 		}
 	})
 }
+
+func TestExtractTarDirectory(t *testing.T) {
+	// Create a source directory and archive it
+	srcDir := t.TempDir()
+	testFiles := map[string]string{
+		"oci-layout":          `{"imageLayoutVersion":"1.0.0"}`,
+		"index.json":          `{"schemaVersion":2}`,
+		"blobs/sha256/abcdef": "blob content",
+	}
+	for path, content := range testFiles {
+		fullPath := filepath.Join(srcDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file %s: %v", path, err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := iotest.TarDirectory(&buf, srcDir); err != nil {
+		t.Fatalf("TarDirectory failed: %v", err)
+	}
+
+	// Extract and compare
+	dstDir := t.TempDir()
+	if err := iotest.ExtractTarDirectory(&buf, dstDir); err != nil {
+		t.Fatalf("ExtractTarDirectory failed: %v", err)
+	}
+	for path, want := range testFiles {
+		got, err := os.ReadFile(filepath.Join(dstDir, path))
+		if err != nil {
+			t.Fatalf("Failed to read extracted file %s: %v", path, err)
+		}
+		if string(got) != want {
+			t.Errorf("Extracted file %s = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestExtractTarDirectory_InvalidEntries(t *testing.T) {
+	tests := []struct {
+		name   string
+		header *tar.Header
+	}{
+		{
+			name:   "path traversal",
+			header: &tar.Header{Name: "../escape", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+		{
+			name:   "absolute path",
+			header: &tar.Header{Name: "/etc/passwd", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+		{
+			name:   "symlink",
+			header: &tar.Header{Name: "symlink", Typeflag: tar.TypeSymlink, Linkname: "target", Mode: 0755},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			if err := tw.WriteHeader(tt.header); err != nil {
+				t.Fatalf("Failed to write tar header: %v", err)
+			}
+			if err := tw.Close(); err != nil {
+				t.Fatalf("Failed to close tar writer: %v", err)
+			}
+			if err := iotest.ExtractTarDirectory(&buf, t.TempDir()); err == nil {
+				t.Error("Expected error for invalid tar entry, but got nil")
+			}
+		})
+	}
+
+	t.Run("target directory does not exist", func(t *testing.T) {
+		if err := iotest.ExtractTarDirectory(&bytes.Buffer{}, "/path/does/not/exist"); err == nil {
+			t.Error("Expected error for non-existent target directory, but got nil")
+		}
+	})
+}
+
+func TestExtractTarDirectory_CorruptedGzip(t *testing.T) {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	content := []byte("hello world")
+	if err := tw.WriteHeader(&tar.Header{Name: "hello.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}); err != nil {
+		t.Fatalf("Failed to write tar header: %v", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatalf("Failed to write tar content: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Failed to close tar writer: %v", err)
+	}
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write(archive.Bytes()); err != nil {
+		t.Fatalf("Failed to write gzip content: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("Failed to close gzip writer: %v", err)
+	}
+	// corrupt the CRC-32 in the gzip trailer
+	compressed := buf.Bytes()
+	compressed[len(compressed)-8] ^= 0xff
+
+	gr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("Failed to create gzip reader: %v", err)
+	}
+	err = iotest.ExtractTarDirectory(gr, t.TempDir())
+	if !errors.Is(err, gzip.ErrChecksum) {
+		t.Errorf("ExtractTarDirectory() error = %v, want %v", err, gzip.ErrChecksum)
+	}
+}
+
+func TestWalkTar(t *testing.T) {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	entries := []*tar.Header{
+		{Name: "usr/bin/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "usr/bin/foo", Typeflag: tar.TypeReg, Mode: 0755, Size: 3},
+		{Name: "usr/bin/bar", Typeflag: tar.TypeSymlink, Linkname: "foo", Mode: 0777},
+	}
+	for _, hdr := range entries {
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Size > 0 {
+			if _, err := tw.Write([]byte("foo")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	compress := func(c iotest.Compression) []byte {
+		var buf bytes.Buffer
+		w, err := iotest.NewCompressWriter(&buf, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(archive.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name       string
+		content    []byte
+		want       []string
+		wantErr    error
+		wantAnyErr bool
+	}{
+		{name: "plain", content: archive.Bytes(), want: []string{"usr/bin/", "usr/bin/foo", "usr/bin/bar"}},
+		{name: "gzip", content: compress(iotest.CompressionGzip), want: []string{"usr/bin/", "usr/bin/foo", "usr/bin/bar"}},
+		{name: "zstd", content: compress(iotest.CompressionZstd), want: []string{"usr/bin/", "usr/bin/foo", "usr/bin/bar"}},
+		{name: "empty", content: nil},
+		{name: "not a tar archive", content: []byte("hello world"), wantErr: iotest.ErrNotTarArchive},
+		{name: "truncated", content: archive.Bytes()[:1000], wantAnyErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := iotest.WalkTar(bytes.NewReader(tt.content), func(hdr *tar.Header) error {
+				got = append(got, hdr.Name)
+				return nil
+			})
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("WalkTar() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.wantAnyErr:
+				if err == nil || errors.Is(err, iotest.ErrNotTarArchive) {
+					t.Fatalf("WalkTar() error = %v, want a read error", err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("WalkTar() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WalkTar() entries = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		patterns []string
+		want     bool
+		wantErr  bool
+	}{
+		{name: "no pattern", path: "usr/bin/foo", want: true},
+		{name: "base name", path: "./usr/lib/libc.so", patterns: []string{"*.so"}, want: true},
+		{name: "full path", path: "./usr/bin/foo", patterns: []string{"/usr/bin/foo"}, want: true},
+		{name: "full path glob", path: "usr/bin/foo", patterns: []string{"usr/*/foo"}, want: true},
+		{name: "glob does not cross slash", path: "usr/bin/foo", patterns: []string{"usr/*"}, want: false},
+		{name: "any pattern", path: "lib/a.a", patterns: []string{"*.so", "*.a"}, want: true},
+		{name: "no match", path: "usr/bin/foo", patterns: []string{"bar"}, want: false},
+		{name: "invalid pattern", path: "usr/bin/foo", patterns: []string{"["}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := iotest.MatchPath(tt.path, tt.patterns)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MatchPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MatchPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
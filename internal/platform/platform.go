@@ -0,0 +1,43 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package platform formats and matches the platforms of manifests.
+package platform
+
+import ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+// String returns the platform in the form of
+// os/arch[/variant][:os_version], as accepted by the --platform flag.
+func String(p *ocispec.Platform) string {
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	if p.OSVersion != "" {
+		s += ":" + p.OSVersion
+	}
+	return s
+}
+
+// Match returns true if got matches want. The architecture, the variant and the
+// OS version are matched only if they are specified in want.
+func Match(got *ocispec.Platform, want *ocispec.Platform) bool {
+	if got == nil || want == nil {
+		return false
+	}
+	return got.OS == want.OS &&
+		(want.Architecture == "" || got.Architecture == want.Architecture) &&
+		(want.Variant == "" || got.Variant == want.Variant) &&
+		(want.OSVersion == "" || got.OSVersion == want.OSVersion)
+}
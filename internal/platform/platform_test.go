@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package platform
+
+import (
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		platform ocispec.Platform
+		want     string
+	}{
+		{platform: ocispec.Platform{OS: "linux", Architecture: "amd64"}, want: "linux/amd64"},
+		{platform: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, want: "linux/arm/v7"},
+		{platform: ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763"}, want: "windows/amd64:10.0.17763"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := String(&tt.platform); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	armV7 := &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}
+	tests := []struct {
+		name string
+		got  *ocispec.Platform
+		want *ocispec.Platform
+		ok   bool
+	}{
+		{name: "exact", got: armV7, want: &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, ok: true},
+		{name: "any variant", got: armV7, want: &ocispec.Platform{OS: "linux", Architecture: "arm"}, ok: true},
+		{name: "different variant", got: armV7, want: &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v6"}},
+		{name: "different architecture", got: armV7, want: &ocispec.Platform{OS: "linux", Architecture: "arm64"}},
+		{name: "any architecture", got: armV7, want: &ocispec.Platform{OS: "linux"}, ok: true},
+		{name: "different OS", got: armV7, want: &ocispec.Platform{OS: "windows"}},
+		{name: "different OS version", got: armV7, want: &ocispec.Platform{OS: "linux", Architecture: "arm", OSVersion: "1"}},
+		{name: "no platform", want: armV7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.got, tt.want); got != tt.ok {
+				t.Errorf("Match() = %v, want %v", got, tt.ok)
+			}
+		})
+	}
+}
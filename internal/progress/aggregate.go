@@ -0,0 +1,113 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package progress
+
+import "sync"
+
+// Aggregator aggregates the progress of the parts of a content transmitted
+// concurrently into a single tracker.
+type Aggregator struct {
+	tracker Tracker
+	mu      sync.Mutex
+	offset  int64
+}
+
+// NewAggregator returns an aggregator reporting the total progress of the
+// parts to t.
+func NewAggregator(t Tracker) *Aggregator {
+	return &Aggregator{tracker: t}
+}
+
+// Part returns a tracker for a part of the content. The offsets reported to
+// the part tracker are relative to the start of the part.
+// Only the transmitting state of the part is reported to the aggregated
+// tracker, and the failures of the part are left to the caller to report.
+func (a *Aggregator) Part() Tracker {
+	return &partTracker{aggregator: a}
+}
+
+// add adds n transmitted bytes to the aggregated progress.
+func (a *Aggregator) add(n int64) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.offset += n
+	return a.tracker.Update(Status{
+		State:  StateTransmitting,
+		Offset: a.offset,
+	})
+}
+
+// partTracker tracks a part of the content.
+type partTracker struct {
+	aggregator *Aggregator
+	offset     int64
+}
+
+// Close closes the tracker.
+func (t *partTracker) Close() error {
+	return nil
+}
+
+// Update updates the status of the part.
+func (t *partTracker) Update(status Status) error {
+	if status.State != StateTransmitting || status.Offset < 0 {
+		return nil
+	}
+	n := status.Offset - t.offset
+	t.offset = status.Offset
+	return t.aggregator.add(n)
+}
+
+// Fail ignores the failure of the part.
+func (t *partTracker) Fail(error) error {
+	return nil
+}
+
+// Restart returns a tracker for transmitting the whole content again from the
+// start, e.g. after the parts fail. The progress reported to the aggregated
+// tracker does not go backwards: the offsets are reported only once they pass
+// the progress already aggregated from the parts.
+func (a *Aggregator) Restart() Tracker {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return &restartTracker{
+		tracker: a.tracker,
+		resumed: a.offset,
+	}
+}
+
+// restartTracker tracks the content transmitted again from the start.
+type restartTracker struct {
+	tracker Tracker
+	resumed int64
+}
+
+// Close closes the tracker.
+func (t *restartTracker) Close() error {
+	return nil
+}
+
+// Update updates the status once the offset passes the aggregated progress.
+func (t *restartTracker) Update(status Status) error {
+	if status.State == StateTransmitting && status.Offset <= t.resumed {
+		return nil
+	}
+	return t.tracker.Update(status)
+}
+
+// Fail marks the content as failed.
+func (t *restartTracker) Fail(err error) error {
+	return t.tracker.Fail(err)
+}
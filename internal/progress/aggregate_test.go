@@ -0,0 +1,116 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package progress
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestAggregator(t *testing.T) {
+	var mu sync.Mutex
+	var statuses []Status
+	tracker := TrackerFunc(func(status Status, err error) error {
+		if err != nil {
+			t.Errorf("TrackerFunc err = %v, want nil", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		statuses = append(statuses, status)
+		return nil
+	})
+	aggregator := NewAggregator(tracker)
+
+	var wg sync.WaitGroup
+	for _, part := range []string{"hello ", "world"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			partTracker := aggregator.Part()
+			if err := Start(partTracker); err != nil {
+				t.Errorf("Start() error = %v", err)
+			}
+			r := TrackReader(partTracker, bytes.NewReader([]byte(part)))
+			if _, err := io.CopyBuffer(io.MultiWriter(io.Discard), r, make([]byte, 2)); err != nil {
+				t.Errorf("io.Copy() error = %v", err)
+			}
+			if err := Done(partTracker); err != nil {
+				t.Errorf("Done() error = %v", err)
+			}
+			if err := partTracker.Fail(io.ErrUnexpectedEOF); err != nil {
+				t.Errorf("Fail() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var last int64
+	for _, status := range statuses {
+		if status.State != StateTransmitting {
+			t.Errorf("aggregated state = %v, want %v", status.State, StateTransmitting)
+		}
+		if status.Offset <= last {
+			t.Errorf("aggregated offset = %d, want greater than %d", status.Offset, last)
+		}
+		last = status.Offset
+	}
+	if want := int64(len("hello world")); last != want {
+		t.Errorf("aggregated offset = %d, want %d", last, want)
+	}
+}
+
+func TestAggregator_Restart(t *testing.T) {
+	var statuses []Status
+	tracker := TrackerFunc(func(status Status, err error) error {
+		if err != nil {
+			t.Errorf("TrackerFunc err = %v, want nil", err)
+		}
+		statuses = append(statuses, status)
+		return nil
+	})
+	aggregator := NewAggregator(tracker)
+
+	// a part fails after transmitting some bytes
+	part := TrackReader(aggregator.Part(), bytes.NewReader([]byte("hello")))
+	if _, err := io.Copy(io.Discard, part); err != nil {
+		t.Fatalf("io.Copy() error = %v", err)
+	}
+	restarted := len(statuses)
+
+	r := TrackReader(aggregator.Restart(), bytes.NewReader([]byte("hello world")))
+	if _, err := io.CopyBuffer(io.MultiWriter(io.Discard), r, make([]byte, 2)); err != nil {
+		t.Fatalf("io.Copy() error = %v", err)
+	}
+	if err := Done(aggregator.Restart()); err != nil {
+		t.Fatalf("Done() error = %v", err)
+	}
+
+	// the progress continues from the bytes transmitted by the part
+	last := int64(len("hello"))
+	for _, status := range statuses[restarted : len(statuses)-1] {
+		if status.Offset <= last {
+			t.Errorf("restarted offset = %d, want greater than %d", status.Offset, last)
+		}
+		last = status.Offset
+	}
+	if want := int64(len("hello world")); last != want {
+		t.Errorf("restarted offset = %d, want %d", last, want)
+	}
+	if got := statuses[len(statuses)-1].State; got != StateTransmitted {
+		t.Errorf("final state = %v, want %v", got, StateTransmitted)
+	}
+}
@@ -71,8 +71,23 @@ func Done(t Tracker) error {
 	})
 }
 
+// TrackableReader is a reader reporting the progress of its transmission on
+// its own, such as a reader downloading the parts of the content concurrently
+// before being read.
+type TrackableReader interface {
+	io.Reader
+
+	// Track reports the progress of the transmission to t.
+	Track(t Tracker)
+}
+
 // TrackReader bind a reader with a tracker.
+// If r is a [TrackableReader], r reports its own progress to t.
 func TrackReader(t Tracker, r io.Reader) io.Reader {
+	if tr, ok := r.(TrackableReader); ok {
+		tr.Track(t)
+		return tr
+	}
 	rt := readTracker{
 		base:    r,
 		tracker: t,
@@ -412,3 +412,29 @@ func TestTrackReader(t *testing.T) {
 		}
 	})
 }
+
+type testTrackableReader struct {
+	io.Reader
+	tracker Tracker
+}
+
+func (r *testTrackableReader) Track(t Tracker) {
+	r.tracker = t
+}
+
+func TestTrackReader_TrackableReader(t *testing.T) {
+	tracker := TrackerFunc(func(status Status, err error) error {
+		t.Errorf("TrackerFunc called with status %v and err %v, want no call", status, err)
+		return nil
+	})
+	r := &testTrackableReader{Reader: bytes.NewReader([]byte("foobar"))}
+	if got := TrackReader(tracker, r); got != r {
+		t.Errorf("TrackReader() = %v, want %v", got, r)
+	}
+	if r.tracker == nil {
+		t.Fatal("TrackReader() did not pass the tracker to the trackable reader")
+	}
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package registryutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/sync/errgroup"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/progress"
+)
+
+// parallelTarget downloads large blobs in concurrent ranged requests.
+type parallelTarget struct {
+	oras.ReadOnlyTarget
+	repo      *remote.Repository
+	parts     int
+	threshold int64
+}
+
+// NewParallelTarget returns a target fetching the blobs of repo not smaller
+// than threshold in parts of concurrent ranged requests. Other content is
+// fetched from source.
+func NewParallelTarget(source oras.ReadOnlyTarget, repo *remote.Repository, parts int, threshold int64) oras.ReadOnlyTarget {
+	t := &parallelTarget{
+		ReadOnlyTarget: source,
+		repo:           repo,
+		parts:          parts,
+		threshold:      threshold,
+	}
+	if refFetcher, ok := source.(registry.ReferenceFetcher); ok {
+		return &parallelReferenceTarget{
+			parallelTarget:   t,
+			ReferenceFetcher: refFetcher,
+		}
+	}
+	return t
+}
+
+// Fetch fetches the content identified by the descriptor. The blob is
+// downloaded on the first read from the returned reader.
+func (t *parallelTarget) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if t.parts <= 1 || target.Size < t.threshold || target.Size < int64(t.parts) || descriptor.IsManifest(target) {
+		return t.ReadOnlyTarget.Fetch(ctx, target)
+	}
+	return &partsReader{
+		ctx:    ctx,
+		target: t,
+		desc:   target,
+	}, nil
+}
+
+// parallelReferenceTarget is a parallelTarget fetching by references.
+type parallelReferenceTarget struct {
+	*parallelTarget
+	registry.ReferenceFetcher
+}
+
+// partsReader reads a blob downloaded in parts into a pre-allocated temporary
+// file. The blob is fetched in a single request if the registry does not
+// support range requests.
+type partsReader struct {
+	ctx     context.Context
+	target  *parallelTarget
+	desc    ocispec.Descriptor
+	tracker progress.Tracker
+
+	started bool
+	reader  io.Reader
+	err     error
+	file    *os.File
+	rc      io.ReadCloser
+}
+
+// Track reports the aggregated progress of the parts to t.
+func (r *partsReader) Track(t progress.Tracker) {
+	r.tracker = t
+}
+
+// Read reads the downloaded blob, starting the download on the first call.
+func (r *partsReader) Read(p []byte) (int, error) {
+	if !r.started {
+		r.started = true
+		r.reader, r.err = r.download()
+		if r.err != nil && r.tracker != nil {
+			if err := r.tracker.Fail(r.err); err != nil {
+				r.err = err
+			}
+		}
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.reader.Read(p)
+}
+
+// Close closes the reader and removes the temporary file.
+func (r *partsReader) Close() error {
+	var errs []error
+	if r.rc != nil {
+		errs = append(errs, r.rc.Close())
+	}
+	errs = append(errs, r.removeFile())
+	return errors.Join(errs...)
+}
+
+// download downloads the blob in parts. Like other fetched content, the blob
+// is left to the reader to verify against the descriptor.
+func (r *partsReader) download() (io.Reader, error) {
+	file, err := os.CreateTemp("", "oras_blob_*")
+	if err != nil {
+		return nil, err
+	}
+	r.file = file
+	if err := file.Truncate(r.desc.Size); err != nil {
+		return nil, err
+	}
+
+	var aggregator *progress.Aggregator
+	if r.tracker != nil {
+		aggregator = progress.NewAggregator(r.tracker)
+	}
+	partSize := (r.desc.Size + int64(r.target.parts) - 1) / int64(r.target.parts)
+	eg, egCtx := errgroup.WithContext(r.ctx)
+	for offset := int64(0); offset < r.desc.Size; offset += partSize {
+		length := min(partSize, r.desc.Size-offset)
+		eg.Go(func() error {
+			rc, err := FetchBlobRange(egCtx, r.target.repo, r.desc, offset, length)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = rc.Close() }()
+			var part io.Reader = rc
+			if aggregator != nil {
+				part = progress.TrackReader(aggregator.Part(), rc)
+			}
+			n, err := io.Copy(io.NewOffsetWriter(file, offset), part)
+			if err != nil {
+				return err
+			}
+			if n != length {
+				return fmt.Errorf("failed to download bytes %d-%d of %s: %w", offset, offset+length-1, r.desc.Digest, io.ErrUnexpectedEOF)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		if !errors.Is(err, ErrRangeNotSupported) {
+			return nil, err
+		}
+		// fall back to fetch the blob in a single request
+		if err := r.removeFile(); err != nil {
+			return nil, err
+		}
+		if r.rc, err = r.target.ReadOnlyTarget.Fetch(r.ctx, r.desc); err != nil {
+			return nil, err
+		}
+		if aggregator != nil {
+			// continue the progress reported by the parts
+			return progress.TrackReader(aggregator.Restart(), r.rc), nil
+		}
+		return r.rc, nil
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// removeFile closes and removes the temporary file if created.
+func (r *partsReader) removeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	file := r.file
+	r.file = nil
+	closeErr := file.Close()
+	if err := os.Remove(file.Name()); err != nil {
+		return err
+	}
+	return closeErr
+}
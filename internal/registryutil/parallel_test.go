@@ -0,0 +1,131 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package registryutil
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/internal/progress"
+)
+
+func TestParallelTarget_Fetch(t *testing.T) {
+	blob := []byte(strings.Repeat("hello world ", 100))
+	desc := ocispec.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	var ranged, whole atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.Header.Get("Range") != "" {
+			ranged.Add(1)
+		} else if r.Method == http.MethodGet {
+			whole.Add(1)
+		}
+		switch r.URL.Path {
+		case "/v2/test/blobs/" + desc.Digest.String():
+			w.Header().Set("Docker-Content-Digest", desc.Digest.String())
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(blob))
+		case "/v2/ignore/blobs/" + desc.Digest.String():
+			w.Header().Set("Docker-Content-Digest", desc.Digest.String())
+			_, _ = w.Write(blob)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		repo       string
+		parts      int
+		threshold  int64
+		wantRanged int32
+		wantWhole  int32
+	}{
+		// each part is fetched before seeking to its offset with a range request
+		{name: "download in parts", repo: "test", parts: 4, threshold: 1024, wantRanged: 3, wantWhole: 4},
+		{name: "below threshold", repo: "test", parts: 4, threshold: 4096, wantWhole: 1},
+		// parts cancelled on fallback may not reach the registry
+		{name: "fall back without range support", repo: "ignore", parts: 3, threshold: 1024, wantWhole: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranged.Store(0)
+			whole.Store(0)
+			repo, err := remote.NewRepository(uri.Host + "/" + tt.repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			repo.PlainHTTP = true
+			target := NewParallelTarget(repo.Blobs(), repo, tt.parts, tt.threshold)
+
+			var mu sync.Mutex
+			var offset int64
+			tracker := progress.TrackerFunc(func(status progress.Status, err error) error {
+				if err != nil {
+					t.Errorf("tracker err = %v, want nil", err)
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				if status.Offset < offset {
+					t.Errorf("tracked offset = %d, want not less than %d", status.Offset, offset)
+				}
+				offset = status.Offset
+				return nil
+			})
+			rc, err := target.Fetch(context.Background(), desc)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			got, err := io.ReadAll(progress.TrackReader(tracker, rc))
+			if err != nil {
+				t.Fatalf("failed to read fetched content: %v", err)
+			}
+			if err := rc.Close(); err != nil {
+				t.Errorf("Close() error = %v", err)
+			}
+			if !bytes.Equal(got, blob) {
+				t.Errorf("Fetch() content = %q, want %q", got, blob)
+			}
+			if offset != desc.Size {
+				t.Errorf("tracked offset = %d, want %d", offset, desc.Size)
+			}
+			if got := ranged.Load(); got != tt.wantRanged {
+				t.Errorf("ranged requests = %d, want %d", got, tt.wantRanged)
+			}
+			if got := whole.Load(); tt.wantWhole >= 0 && got != tt.wantWhole || tt.wantWhole < 0 && got == 0 {
+				t.Errorf("whole requests = %d, want %d", got, tt.wantWhole)
+			}
+		})
+	}
+}
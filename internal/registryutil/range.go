@@ -0,0 +1,49 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package registryutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/internal/contentutil"
+)
+
+// ErrRangeNotSupported is returned when a registry ignores range requests.
+var ErrRangeNotSupported = errors.New("the registry does not support range requests")
+
+// FetchBlobRange fetches length bytes of the blob desc from offset in repo.
+// The range is read by seeking the fetched blob, which issues a range request
+// to the registry.
+func FetchBlobRange(ctx context.Context, repo *remote.Repository, desc ocispec.Descriptor, offset int64, length int64) (io.ReadCloser, error) {
+	rc, err := repo.Blobs().Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := rc.(io.Seeker); !ok {
+		// the registry does not advertise range requests
+		_ = rc.Close()
+		return nil, fmt.Errorf("%s: %w", desc.Digest, ErrRangeNotSupported)
+	}
+	rc, err = contentutil.ReadRange(rc, offset, length)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRangeNotSupported, err)
+	}
+	return rc, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package registryutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+func TestFetchBlobRange(t *testing.T) {
+	blob := []byte("hello world")
+	desc := ocispec.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	missing := digest.FromString("missing")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/test/blobs/" + desc.Digest.String():
+			w.Header().Set("Accept-Ranges", "bytes")
+			rangeHeader := r.Header.Get("Range")
+			if rangeHeader == "" {
+				_, _ = w.Write(blob)
+				return
+			}
+			if want := "bytes=6-10"; rangeHeader != want {
+				t.Errorf("Range = %q, want %q", rangeHeader, want)
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 6-10/%d", len(blob)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(blob[6:])
+		case "/v2/ignore/blobs/" + desc.Digest.String():
+			// advertise range requests but serve the whole blob
+			w.Header().Set("Accept-Ranges", "bytes")
+			_, _ = w.Write(blob)
+		case "/v2/unsupported/blobs/" + desc.Digest.String():
+			_, _ = w.Write(blob)
+		case "/v2/test/blobs/" + missing.String():
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		}
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRepo := func(name string) *remote.Repository {
+		repo, err := remote.NewRepository(uri.Host + "/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repo.PlainHTTP = true
+		return repo
+	}
+	ctx := context.Background()
+
+	t.Run("partial content", func(t *testing.T) {
+		rc, err := FetchBlobRange(ctx, newRepo("test"), desc, 6, 5)
+		if err != nil {
+			t.Fatalf("FetchBlobRange() error = %v", err)
+		}
+		defer func() { _ = rc.Close() }()
+		got, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "world"; string(got) != want {
+			t.Errorf("FetchBlobRange() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("range ignored", func(t *testing.T) {
+		_, err := FetchBlobRange(ctx, newRepo("ignore"), desc, 6, 5)
+		if !errors.Is(err, ErrRangeNotSupported) {
+			t.Errorf("FetchBlobRange() error = %v, want %v", err, ErrRangeNotSupported)
+		}
+	})
+
+	t.Run("range not advertised", func(t *testing.T) {
+		_, err := FetchBlobRange(ctx, newRepo("unsupported"), desc, 6, 5)
+		if !errors.Is(err, ErrRangeNotSupported) {
+			t.Errorf("FetchBlobRange() error = %v, want %v", err, ErrRangeNotSupported)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		missingDesc := desc
+		missingDesc.Digest = missing
+		_, err := FetchBlobRange(ctx, newRepo("test"), missingDesc, 6, 5)
+		if !errors.Is(err, errdef.ErrNotFound) {
+			t.Errorf("FetchBlobRange() error = %v, want %v", err, errdef.ErrNotFound)
+		}
+	})
+
+	t.Run("range not satisfiable", func(t *testing.T) {
+		_, err := FetchBlobRange(ctx, newRepo("other"), desc, 6, 5)
+		var errResp *errcode.ErrorResponse
+		if !errors.As(err, &errResp) || errResp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+			t.Errorf("FetchBlobRange() error = %v, want status %d", err, http.StatusRequestedRangeNotSatisfiable)
+		}
+	})
+}
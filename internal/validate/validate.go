@@ -0,0 +1,327 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validate checks manifests and indexes before they are pushed.
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/image-spec/schema"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras/internal/docker"
+)
+
+// MediaTypeRegexp is the regular expression pattern required for a valid
+// media type, as defined in the image spec schema:
+// - https://github.com/opencontainers/image-spec/blob/v1.1.1/schema/defs-descriptor.json#L7
+var MediaTypeRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}/[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}$`)
+
+// MaxManifestSize is the maximum size of a manifest accepted by most
+// registries.
+const MaxManifestSize = 4 * 1024 * 1024
+
+// MaxAnnotationsSize is the maximum total size of the keys and values of the
+// annotations of a manifest or a descriptor.
+const MaxAnnotationsSize = 64 * 1024
+
+// rootPath is the JSON path of the manifest itself.
+const rootPath = "$"
+
+// Issue is a problem found in a manifest.
+type Issue struct {
+	// Path is the JSON path of the invalid value, such as
+	// "$.layers[0].digest".
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// String returns the issue in the form of "<path>: <message>".
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// manifest contains the validated fields of manifests and indexes.
+type manifest struct {
+	MediaType    string               `json:"mediaType"`
+	ArtifactType string               `json:"artifactType"`
+	Config       *ocispec.Descriptor  `json:"config"`
+	Layers       []ocispec.Descriptor `json:"layers"`
+	Manifests    []ocispec.Descriptor `json:"manifests"`
+	Subject      *ocispec.Descriptor  `json:"subject"`
+	Annotations  map[string]string    `json:"annotations"`
+}
+
+// Manifest validates the content of a manifest or an index of the given media
+// type. Image manifests and indexes are validated against the image spec JSON
+// schemas. The media types and annotations of the manifest and its
+// descriptors are checked, and the referenced config, layers and manifests
+// are checked to exist in storage if storage is not nil.
+// The returned issues are ordered by path. A non-nil error is returned only
+// if the validation cannot be performed.
+func Manifest(ctx context.Context, storage content.ReadOnlyStorage, mediaType string, manifestBytes []byte) ([]Issue, error) {
+	var validator schema.Validator
+	switch mediaType {
+	case ocispec.MediaTypeImageManifest:
+		validator = schema.ValidatorMediaTypeManifest
+	case ocispec.MediaTypeImageIndex:
+		validator = schema.ValidatorMediaTypeImageIndex
+	case docker.MediaTypeManifest, docker.MediaTypeManifestList:
+		// no JSON schema is available for docker manifests
+	default:
+		return nil, fmt.Errorf("%s: %w", mediaType, errdef.ErrUnsupported)
+	}
+
+	v := &validation{}
+	if len(manifestBytes) > MaxManifestSize {
+		v.add(rootPath, "manifest size %d exceeds the limit of %d bytes", len(manifestBytes), MaxManifestSize)
+	}
+	if !json.Valid(manifestBytes) {
+		v.add(rootPath, "invalid JSON")
+		return v.result(), nil
+	}
+	if validator != "" {
+		v.validateSchema(validator, manifestBytes)
+	}
+	var m manifest
+	if err := json.Unmarshal(manifestBytes, &m); err != nil {
+		if len(v.issues) == 0 {
+			v.add(rootPath, "%v", err)
+		}
+		return v.result(), nil
+	}
+
+	if m.MediaType != "" && m.MediaType != mediaType {
+		v.add("$.mediaType", "media type %q does not match the expected media type %q", m.MediaType, mediaType)
+	}
+	if m.ArtifactType != "" {
+		v.checkMediaType("$.artifactType", m.ArtifactType)
+	}
+	v.checkAnnotations("$.annotations", m.Annotations)
+	if m.Config != nil {
+		v.checkDescriptor(ctx, storage, "$.config", *m.Config, true)
+	}
+	for i, layer := range m.Layers {
+		v.checkDescriptor(ctx, storage, fmt.Sprintf("$.layers[%d]", i), layer, true)
+	}
+	for i, desc := range m.Manifests {
+		v.checkDescriptor(ctx, storage, fmt.Sprintf("$.manifests[%d]", i), desc, true)
+	}
+	if m.Subject != nil {
+		// the subject is not required to exist
+		v.checkDescriptor(ctx, storage, "$.subject", *m.Subject, false)
+	}
+	if err := v.err; err != nil {
+		return nil, err
+	}
+	return v.result(), nil
+}
+
+// validation collects the issues of a manifest.
+type validation struct {
+	issues []Issue
+	err    error
+}
+
+// add adds an issue at path.
+func (v *validation) add(path string, format string, args ...any) {
+	v.issues = append(v.issues, Issue{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// reported returns true if an issue is already found at path.
+func (v *validation) reported(path string) bool {
+	return slices.ContainsFunc(v.issues, func(issue Issue) bool {
+		return issue.Path == path
+	})
+}
+
+// result returns the issues ordered by path.
+func (v *validation) result() []Issue {
+	slices.SortStableFunc(v.issues, func(a, b Issue) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return v.issues
+}
+
+// validateSchema validates manifestBytes against the JSON schema of
+// validator.
+func (v *validation) validateSchema(validator schema.Validator, manifestBytes []byte) {
+	err := validator.Validate(bytes.NewReader(manifestBytes))
+	if err == nil {
+		return
+	}
+	var validationErr *jsonschema.ValidationError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErr):
+		v.addSchemaErrors(validationErr)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		// the field of a type error is in the form of "layers.0.size"
+		path := jsonPath("/" + strings.ReplaceAll(typeErr.Field, ".", "/"))
+		v.add(path, "expected %s, but got %s", jsonType(typeErr.Type), typeErr.Value)
+	default:
+		v.add(rootPath, "%v", err)
+	}
+}
+
+// addSchemaErrors adds the leaf errors of a JSON schema validation error.
+func (v *validation) addSchemaErrors(err *jsonschema.ValidationError) {
+	if len(err.Causes) == 0 {
+		path := jsonPath(err.InstanceLocation)
+		issue := Issue{Path: path, Message: err.Message}
+		if !slices.Contains(v.issues, issue) {
+			v.issues = append(v.issues, issue)
+		}
+		return
+	}
+	for _, cause := range err.Causes {
+		v.addSchemaErrors(cause)
+	}
+}
+
+// checkDescriptor checks the media type, the annotations and the digest of
+// desc, and checks whether the described content exists in storage if required.
+func (v *validation) checkDescriptor(ctx context.Context, storage content.ReadOnlyStorage, path string, desc ocispec.Descriptor, required bool) {
+	v.checkMediaType(path+".mediaType", desc.MediaType)
+	if desc.ArtifactType != "" {
+		v.checkMediaType(path+".artifactType", desc.ArtifactType)
+	}
+	v.checkAnnotations(path+".annotations", desc.Annotations)
+	if err := desc.Digest.Validate(); err != nil {
+		if !v.reported(path + ".digest") {
+			v.add(path+".digest", "invalid digest %q: %v", desc.Digest, err)
+		}
+		return
+	}
+	if !required || storage == nil || v.err != nil || v.reported(path+".mediaType") {
+		// the existence of content is not checked with an invalid media type,
+		// as some storages look up content by the whole descriptor
+		return
+	}
+	exists, err := storage.Exists(ctx, desc)
+	if err != nil {
+		v.err = fmt.Errorf("failed to check the existence of %s: %w", desc.Digest, err)
+		return
+	}
+	if !exists {
+		v.add(path, "referenced content %s does not exist in the target", desc.Digest)
+	}
+}
+
+// checkMediaType checks whether mediaType uses valid media type syntax. It
+// is skipped if the schema validation already reports an issue at path.
+func (v *validation) checkMediaType(path string, mediaType string) {
+	if v.reported(path) {
+		return
+	}
+	if !MediaTypeRegexp.MatchString(mediaType) {
+		v.add(path, "invalid media type %q", mediaType)
+	}
+}
+
+// checkAnnotations checks whether the total size of annotations is within
+// MaxAnnotationsSize.
+func (v *validation) checkAnnotations(path string, annotations map[string]string) {
+	var size int
+	for key, value := range annotations {
+		size += len(key) + len(value)
+	}
+	if size > MaxAnnotationsSize {
+		v.add(path, "annotations size %d exceeds the limit of %d bytes", size, MaxAnnotationsSize)
+	}
+}
+
+// identifierRegexp matches the object keys which can be written in the dot
+// notation of JSON paths.
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// jsonPath converts a JSON pointer, such as "/layers/0/digest", to a JSON
+// path, such as "$.layers[0].digest".
+func jsonPath(pointer string) string {
+	var sb strings.Builder
+	sb.WriteString(rootPath)
+	pointer = strings.TrimPrefix(pointer, "#")
+	if pointer == "" {
+		return sb.String()
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch {
+		case isIndex(token):
+			fmt.Fprintf(&sb, "[%s]", token)
+		case identifierRegexp.MatchString(token):
+			fmt.Fprintf(&sb, ".%s", token)
+		default:
+			fmt.Fprintf(&sb, "[%s]", strconv.Quote(token))
+		}
+	}
+	return sb.String()
+}
+
+// jsonType returns the JSON type of the values decoded into t.
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// isIndex returns true if token is an array index.
+func isIndex(token string) bool {
+	if token == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(token, 10, 64)
+	return err == nil
+}
+
+// IssuesError is returned when issues are found in a manifest.
+type IssuesError []Issue
+
+// Error implements the error interface.
+func (e IssuesError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "found %d issue(s) in the manifest:", len(e))
+	for _, issue := range e {
+		fmt.Fprintf(&sb, "\n  %s", issue)
+	}
+	return sb.String()
+}
@@ -0,0 +1,212 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func TestManifest(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	push := func(mediaType string, data []byte) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(mediaType, data)
+		if err := store.Push(ctx, desc, bytes.NewReader(data)); err != nil {
+			t.Fatalf("failed to push: %v", err)
+		}
+		return desc
+	}
+	config := push(ocispec.MediaTypeEmptyJSON, []byte("{}"))
+	layer := push("test/layer", []byte("hello"))
+	missing := content.NewDescriptorFromBytes("test/layer", []byte("missing"))
+	subject := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("subject"))
+
+	// newManifest returns a valid manifest as a map to be modified.
+	newManifest := func() map[string]any {
+		var m map[string]any
+		b, _ := json.Marshal(ocispec.Manifest{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    config,
+			Layers:    []ocispec.Descriptor{layer},
+			Subject:   &subject,
+		})
+		_ = json.Unmarshal(b, &m)
+		m["schemaVersion"] = 2
+		return m
+	}
+	layers := func(m map[string]any) map[string]any {
+		return m["layers"].([]any)[0].(map[string]any)
+	}
+	manifestBytes, err := json.Marshal(newManifest())
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	manifestDesc := push(ocispec.MediaTypeImageManifest, manifestBytes)
+
+	tests := []struct {
+		name      string
+		mediaType string
+		manifest  func() map[string]any
+		content   string
+		want      []Issue
+	}{
+		{
+			name:      "valid manifest",
+			mediaType: ocispec.MediaTypeImageManifest,
+			manifest:  newManifest,
+		},
+		{
+			name:      "valid index",
+			mediaType: ocispec.MediaTypeImageIndex,
+			manifest: func() map[string]any {
+				return map[string]any{
+					"schemaVersion": 2,
+					"mediaType":     ocispec.MediaTypeImageIndex,
+					"manifests":     []ocispec.Descriptor{manifestDesc},
+				}
+			},
+		},
+		{
+			name:      "invalid JSON",
+			mediaType: ocispec.MediaTypeImageManifest,
+			content:   `{"schemaVersion":`,
+			want:      []Issue{{Path: "$", Message: "invalid JSON"}},
+		},
+		{
+			name:      "schema violations",
+			mediaType: ocispec.MediaTypeImageManifest,
+			manifest: func() map[string]any {
+				m := newManifest()
+				m["schemaVersion"] = 3
+				layers(m)["mediaType"] = "invalid"
+				return m
+			},
+			want: []Issue{
+				{Path: "$.layers[0].mediaType", Message: "does not match pattern '" + MediaTypeRegexp.String() + "'"},
+				{Path: "$.schemaVersion", Message: "must be <= 2 but found 3"},
+			},
+		},
+		{
+			name:      "type mismatch",
+			mediaType: ocispec.MediaTypeImageManifest,
+			manifest: func() map[string]any {
+				m := newManifest()
+				layers(m)["size"] = "5"
+				return m
+			},
+			want: []Issue{{Path: "$.layers[0].size", Message: "expected integer, but got string"}},
+		},
+		{
+			name:      "media type mismatch",
+			mediaType: ocispec.MediaTypeImageManifest,
+			manifest: func() map[string]any {
+				m := newManifest()
+				m["mediaType"] = ocispec.MediaTypeImageIndex
+				return m
+			},
+			want: []Issue{{Path: "$.mediaType", Message: `media type "application/vnd.oci.image.index.v1+json" does not match the expected media type "application/vnd.oci.image.manifest.v1+json"`}},
+		},
+		{
+			name:      "invalid digest and missing content",
+			mediaType: ocispec.MediaTypeImageManifest,
+			manifest: func() map[string]any {
+				m := newManifest()
+				m["layers"] = []any{layers(m), missing}
+				m["config"].(map[string]any)["digest"] = "sha256:abc"
+				return m
+			},
+			want: []Issue{
+				{Path: "$.config.digest", Message: `invalid digest "sha256:abc": invalid checksum digest length`},
+				{Path: "$.layers[1]", Message: "referenced content " + string(missing.Digest) + " does not exist in the target"},
+			},
+		},
+		{
+			name:      "annotations too large",
+			mediaType: ocispec.MediaTypeImageManifest,
+			manifest: func() map[string]any {
+				m := newManifest()
+				layers(m)["annotations"] = map[string]string{"org.example.key": strings.Repeat("x", MaxAnnotationsSize)}
+				return m
+			},
+			want: []Issue{{Path: "$.layers[0].annotations", Message: "annotations size 65551 exceeds the limit of 65536 bytes"}},
+		},
+		{
+			name:      "docker manifest without schema",
+			mediaType: "application/vnd.docker.distribution.manifest.v2+json",
+			manifest: func() map[string]any {
+				m := newManifest()
+				m["mediaType"] = "application/vnd.docker.distribution.manifest.v2+json"
+				m["schemaVersion"] = 3
+				layers(m)["digest"] = string(missing.Digest)
+				return m
+			},
+			want: []Issue{{Path: "$.layers[0]", Message: "referenced content " + string(missing.Digest) + " does not exist in the target"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifestBytes := []byte(tt.content)
+			if tt.manifest != nil {
+				var err error
+				if manifestBytes, err = json.Marshal(tt.manifest()); err != nil {
+					t.Fatalf("failed to marshal: %v", err)
+				}
+			}
+			got, err := Manifest(ctx, store, tt.mediaType, manifestBytes)
+			if err != nil {
+				t.Fatalf("Manifest() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Manifest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("unsupported media type", func(t *testing.T) {
+		if _, err := Manifest(ctx, store, "test/manifest", []byte("{}")); !errors.Is(err, errdef.ErrUnsupported) {
+			t.Errorf("Manifest() error = %v, want %v", err, errdef.ErrUnsupported)
+		}
+	})
+}
+
+func Test_jsonPath(t *testing.T) {
+	tests := []struct {
+		pointer string
+		want    string
+	}{
+		{pointer: "", want: "$"},
+		{pointer: "/layers/0/digest", want: "$.layers[0].digest"},
+		{pointer: "/annotations/org.opencontainers.image.title", want: `$.annotations["org.opencontainers.image.title"]`},
+		{pointer: "/annotations/a~1b~0c", want: `$.annotations["a/b~c"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pointer, func(t *testing.T) {
+			if got := jsonPath(tt.pointer); got != tt.want {
+				t.Errorf("jsonPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -760,6 +760,7 @@ var _ = Describe("OCI image layout users:", func() {
 		It("should push a manifest from stdin and tag", func() {
 			tag := "from-stdin"
 			root := GinkgoT().TempDir()
+			prepare(root)
 			ref := LayoutRef(root, tag)
 			ORAS("manifest", "push", Flags.Layout, ref, "-").
 				MatchKeyWords("Pushed", ref, "Digest:", manifestDigest).
@@ -788,6 +789,24 @@ var _ = Describe("OCI image layout users:", func() {
 			validate(root, manifestDigest, tag)
 		})
 
+		It("should fail to push a manifest referencing missing content", func() {
+			root := GinkgoT().TempDir()
+			ORAS("manifest", "push", Flags.Layout, root, "-").
+				WithInput(strings.NewReader(manifest)).
+				ExpectFailure().
+				MatchErrKeyWords("Error:", "1 referenced content(s) not found", scratchDigest, "--upload-from").Exec()
+		})
+
+		It("should push a manifest and upload missing content from an OCI image layout", func() {
+			src := GinkgoT().TempDir()
+			prepare(src)
+			root := GinkgoT().TempDir()
+			ORAS("manifest", "push", Flags.Layout, root, "-", "--upload-from", src).
+				MatchKeyWords("Uploaded", "Pushed", root, "Digest:", manifestDigest).
+				WithInput(strings.NewReader(manifest)).Exec()
+			validate(root, manifestDigest, "")
+		})
+
 		It("should push a manifest from stdin, only when media type flag is set", func() {
 			manifest := fmt.Sprintf(`{"schemaVersion":2,"config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"%s","size":%d}}`, scratchDigest, scratchSize)
 			manifestDigest := "sha256:8fc649142bbc0a2aa5015d5ef5a922df9d2d7f2dcf3095dbebfaf7c271eca444"